@@ -6,20 +6,23 @@ import (
 	"time"
 
 	"github.com/savabush/taskTracker/internal/cmd"
+	"github.com/savabush/taskTracker/internal/services"
 	"github.com/savabush/taskTracker/internal/utils"
 	"github.com/spf13/cobra"
 )
 
 func main() {
-	// Define verbose flag
+	// Define verbose and storage flags
 	var verbose bool
+	var storageKind string
+	var storageOptions services.StorageOptions
 
 	// Create the root command
 	var rootCmd = &cobra.Command{
 		Use:   "taskTracker",
 		Short: "A simple task tracker",
 		Long:  `taskTracker is a simple task tracker that allows you to add, edit, and delete tasks.`,
-		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 			// Configure logger based on verbose flag
 			logLevel := slog.LevelInfo
 			if verbose {
@@ -43,15 +46,42 @@ func main() {
 			if verbose {
 				slog.Debug("Starting taskTracker in debug mode")
 			}
+
+			// Select the storage backend
+			storage, err := services.NewStorage(storageKind, storageOptions)
+			if err != nil {
+				return err
+			}
+			services.SetStorage(storage)
+			slog.Debug("Using storage backend", "storage", storageKind)
+			return nil
+		},
+		PersistentPostRun: func(c *cobra.Command, args []string) {
+			// Commit changes when the task store is synced with git
+			cmd.AutoCommit(c, args)
 		},
 	}
 
 	// Add verbose flag to root command
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "Enable verbose (debug) logging")
+	rootCmd.PersistentFlags().StringVar(&storageKind, "storage", envOr("TASK_TRACKER_STORAGE", services.StorageJSON), "Storage backend: json, todotxt, markdown or oplog (env TASK_TRACKER_STORAGE)")
+	rootCmd.PersistentFlags().StringVar(&storageOptions.TodoFile, "todo-file", envOr("TODO_FILE", "todo.txt"), "todo.txt file used by the todotxt storage (env TODO_FILE)")
+	rootCmd.PersistentFlags().StringVar(&storageOptions.MarkdownFile, "tasks-file", envOr("TASKS_FILE", "TASKS.md"), "Markdown file used by the markdown storage (env TASKS_FILE)")
+	rootCmd.PersistentFlags().StringVar(&storageOptions.LogDir, "log-dir", envOr("TASK_TRACKER_LOG_DIR", "tasks.oplog"), "Operation log directory used by the oplog storage (env TASK_TRACKER_LOG_DIR)")
+	rootCmd.PersistentFlags().StringVar(&storageOptions.Replica, "replica", os.Getenv("TASK_TRACKER_REPLICA"), "Name of this machine in the operation log, defaults to the host name (env TASK_TRACKER_REPLICA)")
 
 	// Add commands
-	rootCmd.AddCommand(cmd.AddCmd, cmd.ListCmd, cmd.MarkInProgressCmd, cmd.MarkCompletedCmd, cmd.DeleteCmd)
+	rootCmd.AddCommand(cmd.AddCmd, cmd.ListCmd, cmd.MarkInProgressCmd, cmd.MarkCompletedCmd, cmd.DeleteCmd, cmd.SearchCmd, cmd.ViewCmd, cmd.StatsCmd, cmd.ShowCmd, cmd.StartCmd, cmd.StopCmd, cmd.TimelogCmd, cmd.EditCmd, cmd.RemindCmd, cmd.ImportCmd, cmd.ExportCmd, cmd.SyncCmd, cmd.ReplicateCmd, cmd.ServeCmd, cmd.TuiCmd, cmd.TagCmd, cmd.ScanCmd)
 
-	// Execute root command
-	rootCmd.Execute()
+	// Execute root command and exit with the code for its error
+	os.Exit(cmd.Execute(rootCmd))
+}
+
+// envOr returns the value of the environment variable key, or fallback if it
+// is unset or empty.
+func envOr(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
 }
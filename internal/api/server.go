@@ -0,0 +1,359 @@
+// Package api serves the task store over a JSON REST API.
+package api
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"log/slog"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/savabush/taskTracker/internal/services"
+	"github.com/savabush/taskTracker/pkg/tasktracker"
+)
+
+// web holds the Kanban board served at /. It only uses the API, and has no
+// external assets so that it works offline.
+//
+//go:embed web
+var web embed.FS
+
+// Server handles the REST API and the web UI. Requests are served one at a
+// time, since each loads the task store, changes it and saves it again.
+//
+//	GET    /api/tasks?filter=...&sort=...  list tasks, filtered as in list
+//	POST   /api/tasks                      create a task
+//	GET    /api/tasks/{task}               get a task by ID or title
+//	PATCH  /api/tasks/{task}               change the given fields
+//	DELETE /api/tasks/{task}               delete a task
+//	POST   /api/tasks/{task}/start         mark a task as in progress
+//	POST   /api/tasks/{task}/complete      mark a task as completed
+//	POST   /api/tasks/{task}/reopen        mark a task as pending again
+//
+// Requests from other web sites are refused: the Host header must name the
+// server, an Origin header must match it, and request bodies must be sent as
+// application/json, which browsers do not allow across sites without CORS.
+type Server struct {
+	// AllowedHosts lists the host names the server may be reached under,
+	// besides localhost and IP addresses.
+	AllowedHosts []string
+
+	mu  sync.Mutex
+	mux *http.ServeMux
+}
+
+func NewServer() *Server {
+	s := &Server{mux: http.NewServeMux()}
+	s.mux.HandleFunc("GET /api/tasks", s.listTasks)
+	s.mux.HandleFunc("POST /api/tasks", s.createTask)
+	s.mux.HandleFunc("GET /api/tasks/{task}", s.getTask)
+	s.mux.HandleFunc("PATCH /api/tasks/{task}", s.updateTask)
+	s.mux.HandleFunc("DELETE /api/tasks/{task}", s.deleteTask)
+	s.mux.HandleFunc("POST /api/tasks/{task}/start", s.startTask)
+	s.mux.HandleFunc("POST /api/tasks/{task}/complete", s.completeTask)
+	s.mux.HandleFunc("POST /api/tasks/{task}/reopen", s.reopenTask)
+	ui, _ := fs.Sub(web, "web")
+	s.mux.Handle("GET /", http.FileServerFS(ui))
+	return s
+}
+
+// Lock and Unlock make the server a sync.Locker, so that other servers on the
+// same task store can share its lock.
+func (s *Server) Lock()   { s.mu.Lock() }
+func (s *Server) Unlock() { s.mu.Unlock() }
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	slog.Debug("API request", "method", r.Method, "path", r.URL.Path)
+	if err := s.checkRequest(r); err != nil {
+		slog.Warn("API request refused", "method", r.Method, "path", r.URL.Path, "host", r.Host, "origin", r.Header.Get("Origin"), "error", err)
+		writeError(w, err)
+		return
+	}
+	s.mux.ServeHTTP(w, r)
+}
+
+// checkRequest refuses requests that a web page of another site could have
+// made: through DNS rebinding, which sends the host name of that site, or as
+// a cross-site request, which carries its origin or a form content type.
+func (s *Server) checkRequest(r *http.Request) error {
+	if !s.allowedHost(r.Host) {
+		return errorf(http.StatusForbidden, "host not allowed")
+	}
+	if origin := r.Header.Get("Origin"); origin != "" {
+		u, err := url.Parse(origin)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || !strings.EqualFold(u.Host, r.Host) {
+			return errorf(http.StatusForbidden, "cross-origin requests are not allowed")
+		}
+	}
+	contentType := r.Header.Get("Content-Type")
+	if contentType == "" && r.ContentLength == 0 {
+		return nil
+	}
+	if mediaType, _, err := mime.ParseMediaType(contentType); err != nil || mediaType != "application/json" {
+		return errorf(http.StatusUnsupportedMediaType, "Content-Type must be application/json")
+	}
+	return nil
+}
+
+// allowedHost reports whether host, with or without a port, is localhost, an
+// IP address or one of the allowed host names.
+func (s *Server) allowedHost(host string) bool {
+	if name, _, err := net.SplitHostPort(host); err == nil {
+		host = name
+	}
+	host = strings.TrimSuffix(strings.Trim(host, "[]"), ".")
+	if net.ParseIP(host) != nil || strings.EqualFold(host, "localhost") {
+		return true
+	}
+	for _, allowed := range s.AllowedHosts {
+		if strings.EqualFold(host, allowed) {
+			return true
+		}
+	}
+	return false
+}
+
+// httpError is an error with the status code it is reported with.
+type httpError struct {
+	status  int
+	message string
+}
+
+func (e *httpError) Error() string {
+	return e.message
+}
+
+func errorf(status int, message string) error {
+	return &httpError{status: status, message: message}
+}
+
+func writeJSON(w http.ResponseWriter, status int, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(value)
+}
+
+// writeError reports err as {"error": "..."}. Errors of the task store get
+// the matching status; other errors are internal errors, such as a task
+// store that cannot be loaded.
+func writeError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	var httpErr *httpError
+	var invalidErr *tasktracker.InvalidTaskError
+	var parseErr *tasktracker.ParseError
+	switch {
+	case errors.As(err, &httpErr):
+		status = httpErr.status
+	case errors.Is(err, tasktracker.ErrTaskNotFound):
+		status, err = http.StatusNotFound, errors.New("task not found")
+	case errors.Is(err, tasktracker.ErrDuplicateTitle):
+		status, err = http.StatusConflict, errors.New("task already exists")
+	case errors.Is(err, tasktracker.ErrInvalidTransition):
+		status = http.StatusConflict
+	case errors.Is(err, tasktracker.ErrAmbiguousID), errors.As(err, &invalidErr):
+		status = http.StatusBadRequest
+	case errors.As(err, &parseErr):
+		status, err = http.StatusBadRequest, errors.New("invalid filter: "+parseErr.Error())
+	default:
+		slog.Error("API request failed", "error", err)
+	}
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
+
+// batch runs fn on the task store. Requests are served one at a time, also
+// across servers sharing the lock.
+func (s *Server) batch(r *http.Request, fn func(b *tasktracker.Batch) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	client, err := tasktracker.New()
+	if err != nil {
+		return err
+	}
+	return client.Batch(r.Context(), fn)
+}
+
+// taskLocation is the URL of a task.
+func taskLocation(task tasktracker.Task) string {
+	return "/api/tasks/" + url.PathEscape(task.ID)
+}
+
+func (s *Server) listTasks(w http.ResponseWriter, r *http.Request) {
+	opts := tasktracker.ListOptions{Filter: r.URL.Query().Get("filter"), Sort: r.URL.Query().Get("sort")}
+	if err := services.ValidateSortOrder(opts.Sort); err != nil {
+		writeError(w, errorf(http.StatusBadRequest, err.Error()))
+		return
+	}
+	var tasks []tasktracker.Task
+	err := s.batch(r, func(b *tasktracker.Batch) (err error) {
+		tasks, err = b.List(opts)
+		return err
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if tasks == nil {
+		tasks = []tasktracker.Task{}
+	}
+	writeJSON(w, http.StatusOK, tasks)
+}
+
+func (s *Server) getTask(w http.ResponseWriter, r *http.Request) {
+	s.respond(w, r, http.StatusOK, func(b *tasktracker.Batch, key string) (tasktracker.Task, error) {
+		return b.Get(key)
+	})
+}
+
+func (s *Server) createTask(w http.ResponseWriter, r *http.Request) {
+	input, err := decodeTaskInput(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if input.Title == nil {
+		writeError(w, errorf(http.StatusBadRequest, "title is required"))
+		return
+	}
+
+	var task tasktracker.Task
+	input.apply(&task)
+	err = s.batch(r, func(b *tasktracker.Batch) (err error) {
+		task, err = b.Add(task)
+		return err
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Location", taskLocation(task))
+	writeJSON(w, http.StatusCreated, task)
+}
+
+func (s *Server) updateTask(w http.ResponseWriter, r *http.Request) {
+	input, err := decodeTaskInput(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	s.respond(w, r, http.StatusOK, func(b *tasktracker.Batch, key string) (tasktracker.Task, error) {
+		return b.Update(key, input.apply)
+	})
+}
+
+func (s *Server) deleteTask(w http.ResponseWriter, r *http.Request) {
+	err := s.batch(r, func(b *tasktracker.Batch) error {
+		return b.Delete(r.PathValue("task"))
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) startTask(w http.ResponseWriter, r *http.Request) {
+	s.respond(w, r, http.StatusOK, (*tasktracker.Batch).Start)
+}
+
+// completeTask marks a task as completed. A completed recurring task keeps
+// its ID under a dated title.
+func (s *Server) completeTask(w http.ResponseWriter, r *http.Request) {
+	s.respond(w, r, http.StatusOK, (*tasktracker.Batch).Complete)
+}
+
+func (s *Server) reopenTask(w http.ResponseWriter, r *http.Request) {
+	s.respond(w, r, http.StatusOK, (*tasktracker.Batch).Reopen)
+}
+
+// respond runs op on the task named in the path and responds with the task
+// it returns.
+func (s *Server) respond(w http.ResponseWriter, r *http.Request, status int, op func(b *tasktracker.Batch, key string) (tasktracker.Task, error)) {
+	var task tasktracker.Task
+	err := s.batch(r, func(b *tasktracker.Batch) (err error) {
+		task, err = op(b, r.PathValue("task"))
+		return err
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, status, task)
+}
+
+// taskInput holds the fields of a create or update request. Fields that are
+// absent are left unchanged; empty strings clear a field. The values are
+// checked when the task is saved, as for the Go API.
+type taskInput struct {
+	Title       *string   `json:"title"`
+	Description *string   `json:"description"`
+	Notes       *[]string `json:"notes"`
+	Tags        *[]string `json:"tags"`
+	Priority    *string   `json:"priority"`
+	Due         *string   `json:"due"`
+	Recurrence  *string   `json:"recurrence"`
+	Estimate    *string   `json:"estimate"`
+
+	due      time.Time
+	estimate tasktracker.Estimate
+}
+
+// decodeTaskInput reads a request body, parsing the due date and estimate
+// from their text form.
+func decodeTaskInput(r *http.Request) (*taskInput, error) {
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	var input taskInput
+	if err := decoder.Decode(&input); err != nil {
+		return nil, errorf(http.StatusBadRequest, "invalid request body: "+err.Error())
+	}
+	if input.Due != nil && *input.Due != "" {
+		due, err := time.ParseInLocation(services.DateLayout, *input.Due, time.Local)
+		if err != nil {
+			return nil, errorf(http.StatusBadRequest, "due date must be in YYYY-MM-DD format")
+		}
+		input.due = due
+	}
+	if input.Estimate != nil {
+		estimate, err := tasktracker.ParseEstimate(*input.Estimate)
+		if err != nil {
+			return nil, errorf(http.StatusBadRequest, err.Error())
+		}
+		input.estimate = estimate
+	}
+	return &input, nil
+}
+
+// apply sets the fields present in the request on task.
+func (in *taskInput) apply(task *tasktracker.Task) {
+	if in.Title != nil {
+		task.Title = *in.Title
+	}
+	if in.Description != nil {
+		task.Description = *in.Description
+	}
+	if in.Notes != nil {
+		task.Notes = *in.Notes
+	}
+	if in.Tags != nil {
+		task.Tags = *in.Tags
+	}
+	if in.Priority != nil {
+		task.Priority = tasktracker.TaskPriority(*in.Priority)
+	}
+	if in.Due != nil {
+		task.Due = in.due
+	}
+	if in.Recurrence != nil {
+		task.Recurrence = *in.Recurrence
+	}
+	if in.Estimate != nil {
+		task.Estimate = in.estimate
+	}
+}
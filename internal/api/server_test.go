@@ -0,0 +1,272 @@
+package api
+
+import (
+	"encoding/json"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/savabush/taskTracker/internal/services"
+)
+
+func useTempTaskFile(t *testing.T) string {
+	t.Helper()
+	tmpFile, err := os.CreateTemp(t.TempDir(), "tasks_test*.json")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	tmpFile.Write([]byte(`{"tasks":{}}`))
+	tmpFile.Close()
+
+	origFileName := services.GetTasksFileName()
+	services.SetTasksFileName(tmpFile.Name())
+	t.Cleanup(func() { services.SetTasksFileName(origFileName) })
+	return tmpFile.Name()
+}
+
+// loadService loads the task store, failing the test if it cannot be loaded.
+func loadService(t *testing.T) *services.TaskService {
+	t.Helper()
+	service, err := services.NewTaskService()
+	if err != nil {
+		t.Fatalf("Failed to load tasks: %v", err)
+	}
+	return service
+}
+
+// request sends a request to the server and decodes the JSON response into
+// out, if given.
+func request(t *testing.T, handler http.Handler, method, path, body string, out any) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Host = "localhost:8080"
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if out != nil {
+		if err := json.Unmarshal(rec.Body.Bytes(), out); err != nil {
+			t.Fatalf("%s %s: invalid JSON %q: %v", method, path, rec.Body.String(), err)
+		}
+	}
+	return rec
+}
+
+func TestServer_CRUD(t *testing.T) {
+	useTempTaskFile(t)
+	server := NewServer()
+
+	var created services.Task
+	rec := request(t, server, "POST", "/api/tasks", `{"title":"Write report","tags":["work"],"priority":"high","due":"2026-10-20","estimate":"2h"}`, &created)
+	if rec.Code != http.StatusCreated || rec.Header().Get("Location") != "/api/tasks/"+created.ID {
+		t.Fatalf("POST = %d %q, Location %q", rec.Code, rec.Body.String(), rec.Header().Get("Location"))
+	}
+	if created.Priority != services.TaskPriorityHigh || created.Estimate.String() != "2h" || created.Due.Format(services.DateLayout) != "2026-10-20" {
+		t.Errorf("Unexpected created task %+v", created)
+	}
+	request(t, server, "POST", "/api/tasks", `{"title":"Call mom"}`, nil)
+
+	var tasks []services.Task
+	if rec := request(t, server, "GET", "/api/tasks?filter=tag:work", "", &tasks); rec.Code != http.StatusOK || len(tasks) != 1 {
+		t.Errorf("GET filtered = %d, %v", rec.Code, tasks)
+	}
+	if request(t, server, "GET", "/api/tasks?sort=-title", "", &tasks); len(tasks) != 2 || tasks[0].Title != "Write report" {
+		t.Errorf("Expected sorted tasks, got %v", tasks)
+	}
+
+	// Tasks are addressed by ID or title
+	var task services.Task
+	if rec := request(t, server, "GET", "/api/tasks/"+created.ID, "", &task); rec.Code != http.StatusOK || task.Title != "Write report" {
+		t.Errorf("GET by ID = %d, %+v", rec.Code, task)
+	}
+	if rec := request(t, server, "GET", "/api/tasks/Call%20mom", "", &task); rec.Code != http.StatusOK || task.Title != "Call mom" {
+		t.Errorf("GET by title = %d, %+v", rec.Code, task)
+	}
+
+	// Fields left out of a response keep their value when decoding
+	task = services.Task{}
+	rec = request(t, server, "PATCH", "/api/tasks/"+created.ID, `{"title":"Write final report","due":"","notes":["ask Sam"]}`, &task)
+	if rec.Code != http.StatusOK || task.Title != "Write final report" || !task.Due.IsZero() || task.Priority != services.TaskPriorityHigh || len(task.Notes) != 1 || task.ID != created.ID {
+		t.Errorf("PATCH = %d, %+v", rec.Code, task)
+	}
+
+	if rec := request(t, server, "POST", "/api/tasks/"+created.ID+"/start", "", &task); rec.Code != http.StatusOK || task.Status != services.TaskStatusInProgress {
+		t.Errorf("start = %d, %+v", rec.Code, task)
+	}
+	if rec := request(t, server, "POST", "/api/tasks/"+created.ID+"/complete", "", &task); rec.Code != http.StatusOK || task.Status != services.TaskStatusCompleted {
+		t.Errorf("complete = %d, %+v", rec.Code, task)
+	}
+
+	task = services.Task{}
+	if rec := request(t, server, "POST", "/api/tasks/"+created.ID+"/reopen", "", &task); rec.Code != http.StatusOK || task.Status != services.TaskStatusPending || !task.CompletedAt.IsZero() {
+		t.Errorf("reopen = %d, %+v", rec.Code, task)
+	}
+	if rec := request(t, server, "POST", "/api/tasks/"+created.ID+"/reopen", "", nil); rec.Code != http.StatusConflict {
+		t.Errorf("reopen of a pending task = %d, want %d", rec.Code, http.StatusConflict)
+	}
+
+	if rec := request(t, server, "DELETE", "/api/tasks/"+created.ID, "", nil); rec.Code != http.StatusNoContent {
+		t.Errorf("DELETE = %d", rec.Code)
+	}
+	if _, err := loadService(t).GetTask("Write final report"); err == nil {
+		t.Errorf("Expected task to be deleted from the store")
+	}
+}
+
+func TestServer_Errors(t *testing.T) {
+	useTempTaskFile(t)
+	server := NewServer()
+	request(t, server, "POST", "/api/tasks", `{"title":"Existing"}`, nil)
+	request(t, server, "POST", "/api/tasks", `{"title":"Other"}`, nil)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		status int
+	}{
+		{name: "Get missing", method: "GET", path: "/api/tasks/missing", status: http.StatusNotFound},
+		{name: "Update missing", method: "PATCH", path: "/api/tasks/missing", body: `{}`, status: http.StatusNotFound},
+		{name: "Delete missing", method: "DELETE", path: "/api/tasks/missing", status: http.StatusNotFound},
+		{name: "Complete missing", method: "POST", path: "/api/tasks/missing/complete", status: http.StatusNotFound},
+		{name: "Duplicate title", method: "POST", path: "/api/tasks", body: `{"title":"Existing"}`, status: http.StatusConflict},
+		{name: "Rename onto existing", method: "PATCH", path: "/api/tasks/Other", body: `{"title":"Existing"}`, status: http.StatusConflict},
+		{name: "Missing title", method: "POST", path: "/api/tasks", body: `{"tags":["x"]}`, status: http.StatusBadRequest},
+		{name: "Empty title", method: "POST", path: "/api/tasks", body: `{"title":"  "}`, status: http.StatusBadRequest},
+		{name: "Invalid priority", method: "POST", path: "/api/tasks", body: `{"title":"A","priority":"urgent"}`, status: http.StatusBadRequest},
+		{name: "Invalid due", method: "PATCH", path: "/api/tasks/Existing", body: `{"due":"tomorrow"}`, status: http.StatusBadRequest},
+		{name: "Unknown field", method: "PATCH", path: "/api/tasks/Existing", body: `{"colour":"red"}`, status: http.StatusBadRequest},
+		{name: "Invalid JSON", method: "POST", path: "/api/tasks", body: `{`, status: http.StatusBadRequest},
+		{name: "Invalid filter", method: "GET", path: "/api/tasks?filter=priority>=", status: http.StatusBadRequest},
+		{name: "Invalid sort", method: "GET", path: "/api/tasks?sort=size", status: http.StatusBadRequest},
+		{name: "Method not allowed", method: "PUT", path: "/api/tasks/Existing", status: http.StatusMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := request(t, server, tt.method, tt.path, tt.body, nil)
+			if rec.Code != tt.status {
+				t.Errorf("%s %s = %d %q, want %d", tt.method, tt.path, rec.Code, rec.Body.String(), tt.status)
+			}
+			if tt.status != http.StatusMethodNotAllowed && !strings.Contains(rec.Body.String(), `"error"`) {
+				t.Errorf("Expected JSON error body, got %q", rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestServer_LoadError(t *testing.T) {
+	path := useTempTaskFile(t)
+	truncated := `{"tasks":{"Existing":{"id":"1","title":"Exi`
+	os.WriteFile(path, []byte(truncated), 0644)
+	server := NewServer()
+
+	for _, req := range []struct{ method, path, body string }{
+		{"GET", "/api/tasks", ""},
+		{"POST", "/api/tasks", `{"title":"New"}`},
+		{"PATCH", "/api/tasks/Existing", `{"description":"x"}`},
+		{"POST", "/api/tasks/Existing/complete", ""},
+	} {
+		if rec := request(t, server, req.method, req.path, req.body, nil); rec.Code != http.StatusInternalServerError {
+			t.Errorf("%s %s = %d, want %d", req.method, req.path, rec.Code, http.StatusInternalServerError)
+		}
+	}
+	if data, _ := os.ReadFile(path); string(data) != truncated {
+		t.Errorf("Expected the unreadable store to be left alone, got %q", data)
+	}
+}
+
+func TestServer_CrossSite(t *testing.T) {
+	useTempTaskFile(t)
+	server := NewServer()
+	server.AllowedHosts = []string{"tasks.internal"}
+	request(t, server, "POST", "/api/tasks", `{"title":"Existing"}`, nil)
+
+	tests := []struct {
+		name        string
+		method      string
+		path        string
+		host        string
+		origin      string
+		contentType string
+		body        string
+		status      int
+	}{
+		{name: "Same origin", method: "POST", path: "/api/tasks", origin: "http://localhost:8080", contentType: "application/json; charset=utf-8", body: `{"title":"A"}`, status: http.StatusCreated},
+		{name: "No origin", method: "POST", path: "/api/tasks/Existing/start", status: http.StatusOK},
+		{name: "IP address", method: "GET", path: "/api/tasks", host: "127.0.0.1:8080", status: http.StatusOK},
+		{name: "IPv6 address", method: "GET", path: "/api/tasks", host: "[::1]:8080", status: http.StatusOK},
+		{name: "Allowed host", method: "GET", path: "/api/tasks", host: "Tasks.Internal:8080", status: http.StatusOK},
+		{name: "Rebound host", method: "GET", path: "/api/tasks", host: "attacker.example:8080", status: http.StatusForbidden},
+		{name: "Rebound web UI", method: "GET", path: "/", host: "attacker.example", status: http.StatusForbidden},
+		{name: "Cross-origin read", method: "GET", path: "/api/tasks", origin: "http://attacker.example", status: http.StatusForbidden},
+		{name: "Cross-origin bodyless POST", method: "POST", path: "/api/tasks/Existing/complete", origin: "http://attacker.example", status: http.StatusForbidden},
+		{name: "Null origin", method: "DELETE", path: "/api/tasks/Existing", origin: "null", status: http.StatusForbidden},
+		{name: "Form body", method: "POST", path: "/api/tasks", contentType: "application/x-www-form-urlencoded", body: `{"title":"B"}`, status: http.StatusUnsupportedMediaType},
+		{name: "Plain text body", method: "PATCH", path: "/api/tasks/Existing", contentType: "text/plain", body: `{"priority":"high"}`, status: http.StatusUnsupportedMediaType},
+		{name: "Body without type", method: "POST", path: "/api/tasks", body: `{"title":"C"}`, status: http.StatusUnsupportedMediaType},
+		{name: "Empty form", method: "POST", path: "/api/tasks/Existing/reopen", contentType: "application/x-www-form-urlencoded", status: http.StatusUnsupportedMediaType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Host = "localhost:8080"
+			if tt.host != "" {
+				req.Host = tt.host
+			}
+			if tt.origin != "" {
+				req.Header.Set("Origin", tt.origin)
+			}
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			rec := httptest.NewRecorder()
+			server.ServeHTTP(rec, req)
+			if rec.Code != tt.status {
+				t.Errorf("%s %s = %d %q, want %d", tt.method, tt.path, rec.Code, rec.Body.String(), tt.status)
+			}
+		})
+	}
+
+	var task services.Task
+	request(t, server, "GET", "/api/tasks/Existing", "", &task)
+	if task.Status != services.TaskStatusInProgress || task.Priority != "" {
+		t.Errorf("Expected refused requests to leave the task alone, got %+v", task)
+	}
+}
+
+func TestServer_WebUI(t *testing.T) {
+	server := NewServer()
+
+	for path, want := range map[string]string{
+		"/":          "text/html",
+		"/app.js":    "javascript",
+		"/style.css": "text/css",
+	} {
+		rec := request(t, server, "GET", path, "", nil)
+		if rec.Code != http.StatusOK || !strings.Contains(rec.Header().Get("Content-Type"), want) {
+			t.Errorf("GET %s = %d %q", path, rec.Code, rec.Header().Get("Content-Type"))
+		}
+	}
+	if rec := request(t, server, "GET", "/missing.js", "", nil); rec.Code != http.StatusNotFound {
+		t.Errorf("GET /missing.js = %d, want 404", rec.Code)
+	}
+
+	// The UI must work offline, so it may not load anything from elsewhere
+	files, _ := fs.Glob(web, "web/*")
+	for _, file := range files {
+		content, _ := fs.ReadFile(web, file)
+		if strings.Contains(string(content), "http://") || strings.Contains(string(content), "https://") || strings.Contains(string(content), "//cdn") {
+			t.Errorf("%s references external assets", file)
+		}
+	}
+	if len(files) != 3 {
+		t.Errorf("Expected index.html, app.js and style.css, got %v", files)
+	}
+}
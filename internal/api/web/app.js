@@ -0,0 +1,194 @@
+// Kanban board for the taskTracker REST API. Everything is served by the
+// API server itself, so the board works offline.
+"use strict";
+
+const transitions = {
+  pending: "reopen",
+  inProgress: "start",
+  completed: "complete",
+};
+
+const board = document.getElementById("board");
+const errorBox = document.getElementById("error");
+const filterInput = document.getElementById("filter");
+const template = document.getElementById("card-template");
+
+async function api(method, path, body) {
+  const options = { method, headers: {} };
+  if (body !== undefined) {
+    options.headers["Content-Type"] = "application/json";
+    options.body = JSON.stringify(body);
+  }
+  const response = await fetch(path, options);
+  if (response.status === 204) {
+    return null;
+  }
+  const data = await response.json();
+  if (!response.ok) {
+    throw new Error(data.error || response.statusText);
+  }
+  return data;
+}
+
+function showError(err) {
+  errorBox.textContent = err ? err.message : "";
+  errorBox.hidden = !err;
+}
+
+function taskPath(task) {
+  return "/api/tasks/" + encodeURIComponent(task.id);
+}
+
+function dueDate(task) {
+  return task.due ? task.due.slice(0, 10) : "";
+}
+
+function isOverdue(task) {
+  const today = new Date().toISOString().slice(0, 10);
+  return task.status !== "completed" && task.due && dueDate(task) < today;
+}
+
+async function load() {
+  try {
+    const params = new URLSearchParams({ sort: "-priority,due,title" });
+    if (filterInput.value.trim()) {
+      params.set("filter", filterInput.value.trim());
+    }
+    const tasks = await api("GET", "/api/tasks?" + params);
+    render(tasks);
+    showError(null);
+  } catch (err) {
+    showError(err);
+  }
+}
+
+function render(tasks) {
+  for (const column of board.querySelectorAll(".column")) {
+    const list = column.querySelector(".cards");
+    const matching = tasks.filter((task) => task.status === column.dataset.status);
+    list.replaceChildren(...matching.map(renderCard));
+    column.querySelector(".count").textContent = matching.length;
+  }
+}
+
+function renderCard(task) {
+  const card = template.content.firstElementChild.cloneNode(true);
+  card.dataset.id = task.id;
+  card.querySelector(".title").textContent = task.title;
+
+  const meta = card.querySelector(".meta");
+  const add = (text, className) => {
+    const span = document.createElement("span");
+    span.textContent = text;
+    if (className) {
+      span.className = className;
+    }
+    meta.append(span);
+  };
+  if (task.priority) {
+    add(task.priority, "priority-" + task.priority);
+  }
+  if (task.due) {
+    add("due " + dueDate(task), isOverdue(task) ? "overdue" : "");
+  }
+  if (task.estimate) {
+    add(task.estimate);
+  }
+  for (const tag of task.tags || []) {
+    add("#" + tag, "tag");
+  }
+
+  card.addEventListener("dragstart", (event) => {
+    event.dataTransfer.setData("text/plain", task.id);
+    card.classList.add("dragging");
+  });
+  card.addEventListener("dragend", () => card.classList.remove("dragging"));
+  card.querySelector(".view").addEventListener("click", () => openEditor(card, task));
+  return card;
+}
+
+function openEditor(card, task) {
+  const form = card.querySelector(".edit");
+  form.elements.title.value = task.title;
+  form.elements.description.value = task.description || "";
+  form.elements.tags.value = (task.tags || []).join(", ");
+  form.elements.priority.value = task.priority || "";
+  form.elements.due.value = dueDate(task);
+  form.elements.estimate.value = task.estimate || "";
+  card.querySelector(".view").hidden = true;
+  card.draggable = false;
+  form.hidden = false;
+  form.elements.title.focus();
+
+  form.onsubmit = async (event) => {
+    event.preventDefault();
+    const tags = form.elements.tags.value.split(",").map((tag) => tag.trim()).filter(Boolean);
+    try {
+      await api("PATCH", taskPath(task), {
+        title: form.elements.title.value,
+        description: form.elements.description.value,
+        tags,
+        priority: form.elements.priority.value,
+        due: form.elements.due.value,
+        estimate: form.elements.estimate.value,
+      });
+      await load();
+    } catch (err) {
+      showError(err);
+    }
+  };
+  form.querySelector(".cancel").onclick = load;
+  form.querySelector(".delete").onclick = async () => {
+    if (!confirm('Delete "' + task.title + '"?')) {
+      return;
+    }
+    try {
+      await api("DELETE", taskPath(task));
+      await load();
+    } catch (err) {
+      showError(err);
+    }
+  };
+}
+
+for (const column of board.querySelectorAll(".column")) {
+  column.addEventListener("dragover", (event) => {
+    event.preventDefault();
+    column.classList.add("over");
+  });
+  column.addEventListener("dragleave", () => column.classList.remove("over"));
+  column.addEventListener("drop", async (event) => {
+    event.preventDefault();
+    column.classList.remove("over");
+    const id = event.dataTransfer.getData("text/plain");
+    const card = board.querySelector('.card[data-id="' + CSS.escape(id) + '"]');
+    if (!card || card.closest(".column") === column) {
+      return;
+    }
+    try {
+      await api("POST", "/api/tasks/" + encodeURIComponent(id) + "/" + transitions[column.dataset.status]);
+      await load();
+    } catch (err) {
+      showError(err);
+    }
+  });
+}
+
+document.getElementById("add-form").addEventListener("submit", async (event) => {
+  event.preventDefault();
+  const input = document.getElementById("add-title");
+  try {
+    await api("POST", "/api/tasks", { title: input.value });
+    input.value = "";
+    await load();
+  } catch (err) {
+    showError(err);
+  }
+});
+
+document.getElementById("filter-form").addEventListener("submit", (event) => {
+  event.preventDefault();
+  load();
+});
+
+load();
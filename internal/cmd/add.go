@@ -1,21 +1,60 @@
 package cmd
 
 import (
+	"bufio"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"log/slog"
+	"slices"
 	"strings"
-	"sync"
+	"time"
 
 	"github.com/savabush/taskTracker/internal/services"
+	"github.com/savabush/taskTracker/pkg/tasktracker"
 	"github.com/spf13/cobra"
 )
 
+var (
+	addDescription string
+	addNotes       []string
+	addTags        []string
+	addPriority    string
+	addDue         string
+	addRecurrence  string
+	addEstimate    string
+	addEach        eachOptions
+	addStdin       bool
+	addJSONL       bool
+)
+
 var AddCmd = &cobra.Command{
-	Use:   "add [# strings to add]",
+	Use:   "add [# strings to add | -]",
 	Short: "Add a new task",
-	Long:  `add is used to add a new task to the task list`,
+	Long: `add is used to add new tasks to the task list, one per argument.
+
+If a task cannot be added, for example because its title is taken, the other
+tasks are still added and the result for each task is printed. With
+--fail-fast the tasks are added in order until the first failure; with
+--all-or-nothing no task is added unless all of them can be.
+
+With "-" or --stdin the tasks are read from standard input, one per line, and
+added in the order of the lines:
+
+  grep -rn TODO . | taskTracker add -
+
+With --jsonl every line is a JSON object with the fields title, description,
+notes, tags, priority, due, recurrence and estimate. The other flags set the
+fields a line leaves out.`,
 	Args: func(cmd *cobra.Command, args []string) error {
-		if len(args) < 1 {
+		stdin := readsStdin(args)
+		switch {
+		case stdin && len(args) > 1, stdin && len(args) == 1 && args[0] != "-":
+			return errors.New("takes no tasks as arguments when reading from standard input")
+		case addJSONL && !stdin:
+			return errors.New("--jsonl requires reading tasks from standard input")
+		case !stdin && len(args) < 1:
 			return errors.New("requires a task description")
 		}
 		for _, arg := range args {
@@ -23,24 +62,188 @@ var AddCmd = &cobra.Command{
 				return errors.New("task description cannot be empty")
 			}
 		}
+		if addPriority != "" && !services.TaskPriority(addPriority).IsValid() {
+			return errors.New("priority must be one of: low, medium, high, critical")
+		}
+		if addDue != "" {
+			if _, err := time.ParseInLocation(services.DateLayout, addDue, time.Local); err != nil {
+				return errors.New("due date must be in YYYY-MM-DD format")
+			}
+		}
+		if addRecurrence != "" {
+			if _, err := services.ParseRecurrence(addRecurrence); err != nil {
+				return err
+			}
+		}
+		if _, err := services.ParseEstimate(addEstimate); err != nil {
+			return err
+		}
 
 		return nil
 	},
-	Run: func(cmd *cobra.Command, args []string) {
-		taskService := services.NewTaskService()
+	RunE: func(cmd *cobra.Command, args []string) error {
+		template := tasktracker.Task{
+			Description: addDescription,
+			Notes:       addNotes,
+			Tags:        addTags,
+			Priority:    tasktracker.TaskPriority(addPriority),
+			Recurrence:  addRecurrence,
+		}
+		if addDue != "" {
+			template.Due, _ = time.ParseInLocation(services.DateLayout, addDue, time.Local)
+		}
+		template.Estimate, _ = tasktracker.ParseEstimate(addEstimate)
 
-		wg := sync.WaitGroup{}
-		wg.Add(len(args))
+		if readsStdin(args) {
+			return addLines(cmd, template)
+		}
+		result, err := runEach(cmd, args, addEach, func(batch *tasktracker.Batch, arg string) error {
+			task := template
+			task.Title = arg
+			_, err := batch.Add(task)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		return result.report("added", "Added tasks")
+	},
+}
 
-		for _, arg := range args {
-			go func(arg string) {
-				defer wg.Done()
-				taskService.AddTask(arg)
-			}(arg)
+func init() {
+	AddCmd.Flags().StringVarP(&addDescription, "description", "d", "", "Task description")
+	AddCmd.Flags().StringArrayVarP(&addNotes, "note", "n", nil, "Add a note to the task (can be repeated)")
+	AddCmd.Flags().StringSliceVarP(&addTags, "tag", "t", nil, "Tag the task (comma-separated or repeated)")
+	AddCmd.Flags().StringVarP(&addPriority, "priority", "p", "", "Task priority: low, medium, high or critical")
+	AddCmd.Flags().StringVar(&addDue, "due", "", "Due date in YYYY-MM-DD format")
+	AddCmd.Flags().StringVar(&addRecurrence, "recur", "", `Recurrence rule: daily, weekly, monthly, yearly, "every N weeks" or an RRULE such as "FREQ=WEEKLY;BYDAY=MO"`)
+	AddCmd.Flags().StringVarP(&addEstimate, "estimate", "e", "", "Estimated effort as a duration (90m, 1.5h, 2d) or story points (5pt)")
+	AddCmd.Flags().BoolVar(&addStdin, "stdin", false, `Read tasks from standard input, one per line (same as "add -")`)
+	AddCmd.Flags().BoolVar(&addJSONL, "jsonl", false, "Read standard input as JSON Lines with the fields of each task")
+	addEach.addFlags(AddCmd)
+}
+
+// readsStdin reports whether add reads its tasks from standard input.
+func readsStdin(args []string) bool {
+	return addStdin || slices.Contains(args, "-")
+}
+
+// addLine is a task read from a line of standard input.
+type addLine struct {
+	// label names the line in the results: its number and title.
+	label string
+	task  tasktracker.Task
+	err   error
+}
+
+// addJSONLine holds the fields of a task given as a line of JSON.
+type addJSONLine struct {
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	Notes       []string `json:"notes"`
+	Tags        []string `json:"tags"`
+	Priority    string   `json:"priority"`
+	Due         string   `json:"due"`
+	Recurrence  string   `json:"recurrence"`
+	Estimate    string   `json:"estimate"`
+}
+
+// addLines adds the tasks read from standard input in the order of the lines
+// and reports the outcome of each line.
+func addLines(cmd *cobra.Command, template tasktracker.Task) error {
+	lines, err := readAddLines(cmd.InOrStdin(), template, addJSONL)
+	if err != nil {
+		return err
+	}
+	if len(lines) == 0 {
+		slog.Info("No tasks read from standard input")
+		return nil
+	}
+
+	labels := make([]string, len(lines))
+	byLabel := make(map[string]addLine, len(lines))
+	for i, line := range lines {
+		labels[i] = line.label
+		byLabel[line.label] = line
+	}
+	opts := addEach
+	opts.ordered = true
+	result, err := runEach(cmd, labels, opts, func(batch *tasktracker.Batch, label string) error {
+		line := byLabel[label]
+		if line.err != nil {
+			return line.err
 		}
-		wg.Wait()
+		_, err := batch.Add(line.task)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return result.report("added", "Added tasks")
+}
 
-		taskService.SaveTasks()
-		slog.Info("Added tasks", "count", len(args))
-	},
+// readAddLines reads a task from every non-blank line of r, starting from
+// template. A line that cannot be parsed keeps its error, so that the other
+// lines can still be added.
+func readAddLines(r io.Reader, template tasktracker.Task, jsonl bool) ([]addLine, error) {
+	var lines []addLine
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for n := 1; scanner.Scan(); n++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		task := template
+		task.Notes, task.Tags = slices.Clone(template.Notes), slices.Clone(template.Tags)
+		task.Title = text
+		var err error
+		if jsonl {
+			err = parseJSONLine(text, &task)
+		}
+		lines = append(lines, addLine{label: fmt.Sprintf("line %d: %s", n, task.Title), task: task, err: err})
+	}
+	return lines, scanner.Err()
+}
+
+// parseJSONLine sets the fields of task given in a line of JSON.
+func parseJSONLine(text string, task *tasktracker.Task) error {
+	decoder := json.NewDecoder(strings.NewReader(text))
+	decoder.DisallowUnknownFields()
+	var fields addJSONLine
+	if err := decoder.Decode(&fields); err != nil {
+		return fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	task.Title = fields.Title
+	if fields.Description != "" {
+		task.Description = fields.Description
+	}
+	if fields.Notes != nil {
+		task.Notes = fields.Notes
+	}
+	if fields.Tags != nil {
+		task.Tags = fields.Tags
+	}
+	if fields.Priority != "" {
+		task.Priority = tasktracker.TaskPriority(fields.Priority)
+	}
+	if fields.Due != "" {
+		due, err := time.ParseInLocation(services.DateLayout, fields.Due, time.Local)
+		if err != nil {
+			return errors.New("due date must be in YYYY-MM-DD format")
+		}
+		task.Due = due
+	}
+	if fields.Recurrence != "" {
+		task.Recurrence = fields.Recurrence
+	}
+	if fields.Estimate != "" {
+		estimate, err := tasktracker.ParseEstimate(fields.Estimate)
+		if err != nil {
+			return err
+		}
+		task.Estimate = estimate
+	}
+	return nil
 }
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"bytes"
+	"errors"
 	"log/slog"
 	"os"
 	"strings"
@@ -30,6 +31,16 @@ func createTempTaskFile(t *testing.T) func() {
 	}
 }
 
+// loadService loads the task store, failing the test if it cannot be loaded.
+func loadService(t *testing.T) *services.TaskService {
+	t.Helper()
+	service, err := services.NewTaskService()
+	if err != nil {
+		t.Fatalf("Failed to load tasks: %v", err)
+	}
+	return service
+}
+
 func TestAddCmd_Args(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -109,7 +120,7 @@ func TestAddCmd_Run(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			service := services.NewTaskService()
+			service := loadService(t)
 			for k := range service.Tasks {
 				delete(service.Tasks, k)
 			}
@@ -117,9 +128,9 @@ func TestAddCmd_Run(t *testing.T) {
 
 			cmd := &cobra.Command{}
 
-			AddCmd.Run(cmd, tt.args)
+			AddCmd.RunE(cmd, tt.args)
 
-			service = services.NewTaskService() // Reload from file
+			service = loadService(t) // Reload from file
 			count := 0
 			for _, task := range service.Tasks {
 				for _, arg := range tt.args {
@@ -164,7 +175,7 @@ func TestAddCmd_Integration(t *testing.T) {
 
 	w.Close()
 
-	service := services.NewTaskService()
+	service := loadService(t)
 	found := false
 	for _, task := range service.Tasks {
 		if task.Title == "Integration Test Task" {
@@ -180,3 +191,152 @@ func TestAddCmd_Integration(t *testing.T) {
 		t.Error("Task was not added correctly")
 	}
 }
+
+func TestAddCmd_ArgsFlags(t *testing.T) {
+	tests := []struct {
+		name     string
+		priority string
+		due      string
+		wantErr  bool
+	}{
+		{name: "Valid priority", priority: "high", wantErr: false},
+		{name: "Invalid priority", priority: "urgent", wantErr: true},
+		{name: "Valid due date", due: "2026-11-01", wantErr: false},
+		{name: "Invalid due date", due: "01/11/2026", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addPriority, addDue = tt.priority, tt.due
+			defer func() { addPriority, addDue = "", "" }()
+
+			err := AddCmd.Args(&cobra.Command{}, []string{"Task"})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Args() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAddCmd_RunWithDetails(t *testing.T) {
+	var logBuf bytes.Buffer
+	oldLogger := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&logBuf, nil)))
+	defer slog.SetDefault(oldLogger)
+
+	cleanup := createTempTaskFile(t)
+	defer cleanup()
+
+	addTags, addPriority, addDue = []string{"backend"}, "high", "2026-11-01"
+	defer func() { addTags, addPriority, addDue = nil, "", "" }()
+
+	AddCmd.RunE(&cobra.Command{}, []string{"Detailed task"})
+
+	task, err := loadService(t).GetTask("Detailed task")
+	if err != nil {
+		t.Fatalf("Task was not added: %v", err)
+	}
+	if !task.HasTag("backend") || task.Priority != services.TaskPriorityHigh {
+		t.Errorf("Expected tag and priority to be set, got %+v", task)
+	}
+	if task.Due.Format(services.DateLayout) != "2026-11-01" {
+		t.Errorf("Expected due date 2026-11-01, got %v", task.Due)
+	}
+}
+
+func TestAddCmd_StdinArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		stdin   bool
+		jsonl   bool
+		wantErr bool
+	}{
+		{name: "Dash", args: []string{"-"}},
+		{name: "Stdin flag", args: []string{}, stdin: true},
+		{name: "Dash with tasks", args: []string{"-", "Task1"}, wantErr: true},
+		{name: "Stdin flag with task", args: []string{"Task1"}, stdin: true, wantErr: true},
+		{name: "JSONL with dash", args: []string{"-"}, jsonl: true},
+		{name: "JSONL without stdin", args: []string{"Task1"}, jsonl: true, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addStdin, addJSONL = tt.stdin, tt.jsonl
+			defer func() { addStdin, addJSONL = false, false }()
+
+			err := AddCmd.Args(&cobra.Command{}, tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Args() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAddCmd_Stdin(t *testing.T) {
+	var logBuf bytes.Buffer
+	oldLogger := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&logBuf, nil)))
+	defer slog.SetDefault(oldLogger)
+
+	cleanup := createTempTaskFile(t)
+	defer cleanup()
+
+	addTags = []string{"inbox"}
+	defer func() { addTags = nil }()
+
+	input := strings.Join([]string{"third", "", "first", "third", "second"}, "\n")
+	cmd := &cobra.Command{}
+	cmd.SetIn(strings.NewReader(input))
+	err := AddCmd.RunE(cmd, []string{"-"})
+	if !errors.Is(err, services.ErrDuplicateTitle) {
+		t.Errorf("Expected the repeated line to fail with ErrDuplicateTitle, got %v", err)
+	}
+
+	tasks, _ := services.SortTasks(loadService(t).Tasks, "")
+	var titles []string
+	for _, task := range tasks {
+		titles = append(titles, task.Title)
+		if !task.HasTag("inbox") {
+			t.Errorf("Expected %q to get the tags of the flags", task.Title)
+		}
+	}
+	if strings.Join(titles, ",") != "third,first,second" {
+		t.Errorf("Expected tasks in line order, got %v", titles)
+	}
+	if !strings.Contains(logBuf.String(), "count=3") {
+		t.Errorf("Expected 3 tasks added, got: %s", logBuf.String())
+	}
+}
+
+func TestAddCmd_StdinJSONL(t *testing.T) {
+	cleanup := createTempTaskFile(t)
+	defer cleanup()
+
+	addJSONL, addPriority = true, "low"
+	defer func() { addJSONL, addPriority = false, "" }()
+
+	input := `{"title": "Ship release", "priority": "high", "due": "2026-11-01", "tags": ["release"], "estimate": "2h"}
+{"title": "Write notes"}
+{"title": "Bad due", "due": "tomorrow"}
+{"title": "Typo", "prio": "high"}
+`
+	cmd := &cobra.Command{}
+	cmd.SetIn(strings.NewReader(input))
+	err := AddCmd.RunE(cmd, []string{"-"})
+	if err == nil || !strings.Contains(err.Error(), "due date") || !strings.Contains(err.Error(), `unknown field "prio"`) {
+		t.Errorf("Expected errors for lines 3 and 4, got %v", err)
+	}
+
+	tasks := loadService(t).Tasks
+	if len(tasks) != 2 {
+		t.Fatalf("Expected 2 tasks added, got %d", len(tasks))
+	}
+	ship := tasks["Ship release"]
+	if ship.Priority != services.TaskPriorityHigh || !ship.HasTag("release") || ship.Due.Format(services.DateLayout) != "2026-11-01" || ship.Estimate.String() != "2h" {
+		t.Errorf("Expected the fields of the line, got %+v", ship)
+	}
+	if tasks["Write notes"].Priority != services.TaskPriorityLow {
+		t.Errorf("Expected the priority flag as default, got %q", tasks["Write notes"].Priority)
+	}
+}
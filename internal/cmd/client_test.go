@@ -0,0 +1,22 @@
+package cmd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+type contextKey struct{}
+
+func TestCommandContext(t *testing.T) {
+	if ctx := commandContext(&cobra.Command{}); ctx == nil {
+		t.Fatal("Expected a context for a command without one")
+	}
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.WithValue(context.Background(), contextKey{}, "value"))
+	if got := commandContext(cmd).Value(contextKey{}); got != "value" {
+		t.Errorf("Expected the command's context, got value %v", got)
+	}
+}
@@ -2,20 +2,33 @@ package cmd
 
 import (
 	"errors"
-	"log/slog"
 	"strings"
-	"sync"
 
-	"github.com/savabush/taskTracker/internal/services"
+	"github.com/savabush/taskTracker/pkg/tasktracker"
 	"github.com/spf13/cobra"
 )
 
+var (
+	deleteEach   eachOptions
+	deleteSelect selector
+)
+
 var DeleteCmd = &cobra.Command{
-	Use:   "delete [task]",
+	Use:   "delete [task...]",
 	Short: "Delete a task",
-	Long:  `delete is used to delete a task from the task list`,
+	Long: `delete is used to delete tasks from the task list, one per argument.
+
+If a task cannot be deleted, for example because it does not exist, the other
+tasks are still deleted and the result for each task is printed. With
+--fail-fast the tasks are deleted in order until the first failure; with
+--all-or-nothing no task is deleted unless all of them can be.
+
+With --filter, --status, --older-than or --ids the selected tasks are deleted
+after showing them and asking for confirmation, for example:
+
+  taskTracker delete --status completed --older-than 90d`,
 	Args: func(cmd *cobra.Command, args []string) error {
-		if len(args) < 1 {
+		if len(args) < 1 && !deleteSelect.active() {
 			return errors.New("requires a task description")
 		}
 		for _, arg := range args {
@@ -26,20 +39,19 @@ var DeleteCmd = &cobra.Command{
 
 		return nil
 	},
-	Run: func(cmd *cobra.Command, args []string) {
-		taskService := services.NewTaskService()
-
-		wg := sync.WaitGroup{}
-		wg.Add(len(args))
-
-		for _, arg := range args {
-			go func(arg string) {
-				defer wg.Done()
-				taskService.DeleteTask(arg)
-			}(arg)
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if deleteSelect.active() {
+			return deleteSelect.run(cmd, args, deleteEach, "Delete", "deleted", "Deleted tasks", nil, (*tasktracker.Batch).Delete)
+		}
+		result, err := runEach(cmd, args, deleteEach, (*tasktracker.Batch).Delete)
+		if err != nil {
+			return err
 		}
-		wg.Wait()
-		taskService.SaveTasks()
-		slog.Info("Deleted tasks", "count", len(args))
+		return result.report("deleted", "Deleted tasks")
 	},
 }
+
+func init() {
+	deleteEach.addFlags(DeleteCmd)
+	deleteSelect.addFlags(DeleteCmd)
+}
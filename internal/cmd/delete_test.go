@@ -5,8 +5,8 @@ import (
 	"log/slog"
 	"strings"
 	"testing"
+	"time"
 
-	"github.com/savabush/taskTracker/internal/services"
 	"github.com/spf13/cobra"
 )
 
@@ -84,7 +84,7 @@ func TestDeleteCmd_Run(t *testing.T) {
 			setupTasks:     []string{"Task1", "Task3"},
 			taskToDelete:   "NonExistentTask",
 			expectedErr:    true,
-			expectedOutput: "Deleted tasks", // The command actually doesn't log an error for this case
+			expectedOutput: "",
 		},
 	}
 
@@ -94,7 +94,7 @@ func TestDeleteCmd_Run(t *testing.T) {
 			logBuf.Reset()
 
 			// Setup tasks
-			service := services.NewTaskService()
+			service := loadService(t)
 			// Clear existing tasks
 			for k := range service.Tasks {
 				delete(service.Tasks, k)
@@ -112,10 +112,13 @@ func TestDeleteCmd_Run(t *testing.T) {
 			cmd := &cobra.Command{}
 
 			// Execute delete command
-			DeleteCmd.Run(cmd, []string{tt.taskToDelete})
+			err := DeleteCmd.RunE(cmd, []string{tt.taskToDelete})
+			if (err != nil) != tt.expectedErr {
+				t.Errorf("RunE() error = %v, expectedErr %v", err, tt.expectedErr)
+			}
 
 			// Reload service to see changes
-			service = services.NewTaskService()
+			service = loadService(t)
 
 			// Check task count
 			if !tt.expectedErr {
@@ -141,6 +144,38 @@ func TestDeleteCmd_Run(t *testing.T) {
 			if !strings.Contains(logOutput, tt.expectedOutput) {
 				t.Errorf("Expected log message '%s', but got: %s", tt.expectedOutput, logOutput)
 			}
+			if tt.expectedErr && strings.Contains(logOutput, "Deleted tasks") {
+				t.Errorf("Expected no 'Deleted tasks' log when nothing was deleted, got: %s", logOutput)
+			}
 		})
 	}
 }
+
+func TestDeleteCmd_Selector(t *testing.T) {
+	cleanup := createTempTaskFile(t)
+	defer cleanup()
+
+	seedSelectorTasks(t, time.Now())
+
+	deleteSelect = selector{status: "completed", olderThan: "90d"}
+	defer func() { deleteSelect = selector{} }()
+	if err := DeleteCmd.Args(&cobra.Command{}, []string{}); err != nil {
+		t.Errorf("Args() error = %v with selector flags", err)
+	}
+
+	cmd := &cobra.Command{}
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetIn(strings.NewReader("y\n"))
+	if err := DeleteCmd.RunE(cmd, []string{}); err != nil {
+		t.Fatalf("RunE() error = %v", err)
+	}
+	if !strings.Contains(out.String(), "Delete 1 task? [y/N]") {
+		t.Errorf("Expected a confirmation prompt, got %q", out.String())
+	}
+
+	tasks := loadService(t).Tasks
+	if _, ok := tasks["a"]; ok || len(tasks) != 3 {
+		t.Errorf("Expected only a to be deleted, got %d tasks", len(tasks))
+	}
+}
@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"sync"
+
+	"github.com/fatih/color"
+	"github.com/savabush/taskTracker/internal/utils"
+	"github.com/savabush/taskTracker/pkg/tasktracker"
+	"github.com/spf13/cobra"
+)
+
+// eachOptions controls how commands taking several tasks handle failures.
+type eachOptions struct {
+	failFast     bool
+	allOrNothing bool
+	// ordered processes the arguments one after another in order, without
+	// stopping at failures, so that the outcome does not depend on timing.
+	ordered bool
+}
+
+func (o *eachOptions) addFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&o.failFast, "fail-fast", false, "Process tasks in order and stop at the first failure")
+	cmd.Flags().BoolVar(&o.allOrNothing, "all-or-nothing", false, "Save nothing if any task fails")
+}
+
+var (
+	// errSkipped marks arguments left alone after a failure with --fail-fast.
+	errSkipped = errors.New("skipped after an earlier failure")
+	// errRolledBack discards the batch of an --all-or-nothing run.
+	errRolledBack = errors.New("rolled back")
+)
+
+var (
+	eachDoneColor    = color.New(color.FgGreen)
+	eachFailedColor  = color.New(color.FgRed)
+	eachSkippedColor = color.New(color.Faint)
+)
+
+// eachResult is the outcome of a command for each of its arguments.
+type eachResult struct {
+	args []string
+	// errs holds the error for each argument: nil when it succeeded and
+	// errSkipped when it was not processed.
+	errs []error
+	// rolledBack is set when nothing was saved because of --all-or-nothing.
+	rolledBack bool
+}
+
+// succeeded returns the number of arguments that succeeded.
+func (r eachResult) succeeded() int {
+	n := 0
+	for _, err := range r.errs {
+		if err == nil {
+			n++
+		}
+	}
+	return n
+}
+
+// err joins the failures in argument order.
+func (r eachResult) err() error {
+	var failures []error
+	for _, err := range r.errs {
+		if err != nil && err != errSkipped {
+			failures = append(failures, err)
+		}
+	}
+	return errors.Join(failures...)
+}
+
+// runEach calls op for every argument in one batch of changes. The arguments
+// are processed concurrently, in order when ordered is set, or in order until
+// the first failure with --fail-fast. The changes of the arguments that succeeded are saved unless
+// --all-or-nothing is set and any failed.
+func runEach(cmd *cobra.Command, args []string, opts eachOptions, op func(batch *tasktracker.Batch, arg string) error) (eachResult, error) {
+	result := eachResult{args: args, errs: make([]error, len(args))}
+	client, err := tasktracker.New()
+	if err != nil {
+		return result, err
+	}
+
+	err = client.Batch(commandContext(cmd), func(batch *tasktracker.Batch) error {
+		if opts.failFast || opts.ordered {
+			for i, arg := range args {
+				if result.errs[i] = op(batch, arg); result.errs[i] != nil && opts.failFast {
+					for j := i + 1; j < len(args); j++ {
+						result.errs[j] = errSkipped
+					}
+					break
+				}
+			}
+		} else {
+			wg := sync.WaitGroup{}
+			wg.Add(len(args))
+			for i, arg := range args {
+				go func(i int, arg string) {
+					defer wg.Done()
+					result.errs[i] = op(batch, arg)
+				}(i, arg)
+			}
+			wg.Wait()
+		}
+
+		if opts.allOrNothing && result.succeeded() < len(args) {
+			result.rolledBack = true
+			return errRolledBack
+		}
+		return nil
+	})
+	if errors.Is(err, errRolledBack) {
+		err = nil
+	}
+	return result, err
+}
+
+// render writes the outcome of each argument, labelling the ones that
+// succeeded with done.
+func (r eachResult) render(w io.Writer, done string) error {
+	table := utils.NewTable(w,
+		utils.Column{Header: "RESULT"},
+		utils.Column{Header: "TASK", Flexible: true},
+		utils.Column{Header: "ERROR", Flexible: true},
+	)
+	for i, arg := range r.args {
+		status, message := utils.Cell{Text: done, Color: eachDoneColor}, ""
+		switch err := r.errs[i]; {
+		case err == errSkipped:
+			status = utils.Cell{Text: "skipped", Color: eachSkippedColor}
+		case err != nil:
+			status, message = utils.Cell{Text: "failed", Color: eachFailedColor}, err.Error()
+		case r.rolledBack:
+			status = utils.Cell{Text: "rolled back", Color: eachSkippedColor}
+		}
+		table.AddRow(status, utils.Cell{Text: arg}, utils.Cell{Text: message})
+	}
+	return table.Render()
+}
+
+// report logs message with the number of arguments whose changes were saved.
+// If any argument failed, the outcome of each is printed and the failures are
+// returned.
+func (r eachResult) report(done, message string) error {
+	if saved := r.succeeded(); saved > 0 && !r.rolledBack {
+		slog.Info(message, "count", saved)
+	}
+	err := r.err()
+	if err == nil {
+		return nil
+	}
+	if renderErr := r.render(os.Stdout, done); renderErr != nil {
+		return errors.Join(err, renderErr)
+	}
+	return err
+}
@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/savabush/taskTracker/internal/services"
+	"github.com/savabush/taskTracker/pkg/tasktracker"
+	"github.com/spf13/cobra"
+)
+
+func TestRunEach(t *testing.T) {
+	cleanup := createTempTaskFile(t)
+	defer cleanup()
+
+	deleteTask := (*tasktracker.Batch).Delete
+	tests := []struct {
+		name          string
+		opts          eachOptions
+		wantErrs      []error
+		wantRemaining []string
+		wantRollback  bool
+	}{
+		{
+			name:          "Default",
+			wantErrs:      []error{nil, services.ErrTaskNotFound, nil},
+			wantRemaining: []string{"c"},
+		},
+		{
+			name:          "Fail fast",
+			opts:          eachOptions{failFast: true},
+			wantErrs:      []error{nil, services.ErrTaskNotFound, errSkipped},
+			wantRemaining: []string{"b", "c"},
+		},
+		{
+			name:          "Ordered",
+			opts:          eachOptions{ordered: true},
+			wantErrs:      []error{nil, services.ErrTaskNotFound, nil},
+			wantRemaining: []string{"c"},
+		},
+		{
+			name:          "All or nothing",
+			opts:          eachOptions{allOrNothing: true},
+			wantErrs:      []error{nil, services.ErrTaskNotFound, nil},
+			wantRemaining: []string{"a", "b", "c"},
+			wantRollback:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := loadService(t)
+			clear(service.Tasks)
+			for _, title := range []string{"a", "b", "c"} {
+				service.AddTask(title)
+			}
+			service.SaveTasks()
+
+			result, err := runEach(&cobra.Command{}, []string{"a", "missing", "b"}, tt.opts, deleteTask)
+			if err != nil {
+				t.Fatalf("runEach() error = %v", err)
+			}
+			for i, want := range tt.wantErrs {
+				if got := result.errs[i]; !errors.Is(got, want) {
+					t.Errorf("Argument %d: error = %v, want %v", i, got, want)
+				}
+			}
+			if result.rolledBack != tt.wantRollback {
+				t.Errorf("rolledBack = %v, want %v", result.rolledBack, tt.wantRollback)
+			}
+			if !errors.Is(result.err(), services.ErrTaskNotFound) || errors.Is(result.err(), errSkipped) {
+				t.Errorf("Expected only the failure in err(), got %v", result.err())
+			}
+
+			var remaining []string
+			for title := range loadService(t).Tasks {
+				remaining = append(remaining, title)
+			}
+			if len(remaining) != len(tt.wantRemaining) {
+				t.Errorf("Expected remaining tasks %v, got %v", tt.wantRemaining, remaining)
+			}
+			for _, title := range tt.wantRemaining {
+				if _, ok := loadService(t).Tasks[title]; !ok {
+					t.Errorf("Expected task %q to remain", title)
+				}
+			}
+		})
+	}
+}
+
+func TestEachResult_Report(t *testing.T) {
+	result := eachResult{
+		args: []string{"a", "b", "c", "d"},
+		errs: []error{nil, errors.New("task already exists"), errors.New("invalid title"), errSkipped},
+	}
+	if got := result.succeeded(); got != 1 {
+		t.Errorf("succeeded() = %d, want 1", got)
+	}
+	joined := result.err()
+	if joined == nil || strings.Count(joined.Error(), "\n") != 1 {
+		t.Errorf("Expected two joined failures, got %q", joined)
+	}
+
+	var buf bytes.Buffer
+	if err := result.render(&buf, "added"); err != nil {
+		t.Fatalf("render() error = %v", err)
+	}
+	output := buf.String()
+	for _, want := range []string{"added   a", "failed  b  task already exists", "failed  c  invalid title", "skipped d"} {
+		if !strings.Contains(strings.Join(strings.Fields(output), " "), strings.Join(strings.Fields(want), " ")) {
+			t.Errorf("Expected %q in output:\n%s", want, output)
+		}
+	}
+
+	result.rolledBack = true
+	buf.Reset()
+	result.render(&buf, "added")
+	if !strings.Contains(buf.String(), "rolled back") {
+		t.Errorf("Expected rolled back arguments in output:\n%s", buf.String())
+	}
+}
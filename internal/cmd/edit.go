@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/savabush/taskTracker/internal/services"
+	"github.com/savabush/taskTracker/pkg/tasktracker"
+	"github.com/spf13/cobra"
+)
+
+var (
+	editTitle       string
+	editDescription string
+	editTags        []string
+	editPriority    string
+	editDue         string
+	editRecurrence  string
+	editEstimate    string
+)
+
+var EditCmd = &cobra.Command{
+	Use:   "edit [task]",
+	Short: "Edit a task",
+	Long:  `edit is used to change the fields of a task. Only the given flags are changed; pass an empty value (e.g. --due "") to clear a field.`,
+
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return errors.New("requires exactly one task")
+		}
+		if cmd.Flags().Changed("title") && len(strings.TrimSpace(editTitle)) == 0 {
+			return errors.New("task title cannot be empty")
+		}
+		if editPriority != "" && !services.TaskPriority(editPriority).IsValid() {
+			return errors.New("priority must be one of: low, medium, high, critical")
+		}
+		if editDue != "" {
+			if _, err := time.ParseInLocation(services.DateLayout, editDue, time.Local); err != nil {
+				return errors.New("due date must be in YYYY-MM-DD format")
+			}
+		}
+		if editRecurrence != "" {
+			if _, err := services.ParseRecurrence(editRecurrence); err != nil {
+				return err
+			}
+		}
+		if _, err := services.ParseEstimate(editEstimate); err != nil {
+			return err
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		flags := cmd.Flags()
+		client, err := tasktracker.New()
+		if err != nil {
+			return err
+		}
+
+		task, err := client.Update(commandContext(cmd), args[0], func(task *tasktracker.Task) {
+			if flags.Changed("title") {
+				task.Title = editTitle
+			}
+			if flags.Changed("description") {
+				task.Description = editDescription
+			}
+			if flags.Changed("tag") {
+				task.Tags = append([]string(nil), editTags...)
+			}
+			if flags.Changed("priority") {
+				task.Priority = tasktracker.TaskPriority(editPriority)
+			}
+			if flags.Changed("due") {
+				task.Due, _ = time.ParseInLocation(services.DateLayout, editDue, time.Local)
+			}
+			if flags.Changed("recur") {
+				task.Recurrence = editRecurrence
+			}
+			if flags.Changed("estimate") {
+				task.Estimate, _ = tasktracker.ParseEstimate(editEstimate)
+			}
+		})
+		if err != nil {
+			return err
+		}
+		slog.Info("Updated task", "task", task.Title)
+		return nil
+	},
+}
+
+func init() {
+	EditCmd.Flags().StringVar(&editTitle, "title", "", "New task title")
+	EditCmd.Flags().StringVarP(&editDescription, "description", "d", "", "Task description")
+	EditCmd.Flags().StringSliceVarP(&editTags, "tag", "t", nil, "Replace the task's tags (comma-separated or repeated)")
+	EditCmd.Flags().StringVarP(&editPriority, "priority", "p", "", "Task priority: low, medium, high or critical")
+	EditCmd.Flags().StringVar(&editDue, "due", "", "Due date in YYYY-MM-DD format")
+	EditCmd.Flags().StringVar(&editRecurrence, "recur", "", "Recurrence rule")
+	EditCmd.Flags().StringVarP(&editEstimate, "estimate", "e", "", "Estimated effort as a duration (90m, 1.5h, 2d) or story points (5pt)")
+}
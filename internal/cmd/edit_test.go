@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/savabush/taskTracker/internal/services"
+	"github.com/spf13/cobra"
+)
+
+func TestEditCmd_Args(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		estimate string
+		priority string
+		wantErr  bool
+	}{
+		{name: "No args", args: []string{}, wantErr: true},
+		{name: "Valid task", args: []string{"Task1"}, wantErr: false},
+		{name: "Too many args", args: []string{"Task1", "Task2"}, wantErr: true},
+		{name: "Valid estimate", args: []string{"Task1"}, estimate: "3h", wantErr: false},
+		{name: "Invalid estimate", args: []string{"Task1"}, estimate: "a while", wantErr: true},
+		{name: "Invalid priority", args: []string{"Task1"}, priority: "urgent", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			editEstimate, editPriority = tt.estimate, tt.priority
+			defer func() { editEstimate, editPriority = "", "" }()
+
+			err := EditCmd.Args(&cobra.Command{}, tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Args() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEditCmd_Run(t *testing.T) {
+	var logBuf bytes.Buffer
+	oldLogger := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&logBuf, nil)))
+	defer slog.SetDefault(oldLogger)
+
+	cleanup := createTempTaskFile(t)
+	defer cleanup()
+
+	service := loadService(t)
+	service.AddTask("Task1")
+	service.UpdateTask("Task1", func(task *services.Task) {
+		task.Description = "Keep me"
+		task.Priority = services.TaskPriorityLow
+	})
+	service.SaveTasks()
+
+	// Only flags that were set are applied
+	changed := map[string]string{"estimate": "5pt", "priority": "high", "title": "Renamed"}
+	for name, value := range changed {
+		EditCmd.Flags().Set(name, value)
+	}
+	defer func() {
+		editEstimate, editPriority, editTitle = "", "", ""
+		for name := range changed {
+			EditCmd.Flags().Lookup(name).Changed = false
+		}
+	}()
+
+	EditCmd.RunE(EditCmd, []string{"Task1"})
+	if !strings.Contains(logBuf.String(), "Updated task") {
+		t.Errorf("Expected log 'Updated task', got: %s", logBuf.String())
+	}
+
+	service = loadService(t)
+	task, err := service.GetTask("Renamed")
+	if err != nil {
+		t.Fatalf("Expected task to be renamed: %v", err)
+	}
+	if task.Estimate != (services.Estimate{Points: 5}) || task.Priority != services.TaskPriorityHigh {
+		t.Errorf("Expected estimate and priority to be updated, got %+v", task)
+	}
+	if task.Description != "Keep me" {
+		t.Errorf("Expected description to be kept, got %q", task.Description)
+	}
+
+	if err := EditCmd.RunE(&cobra.Command{}, []string{"Missing"}); !errors.Is(err, services.ErrTaskNotFound) {
+		t.Errorf("Expected ErrTaskNotFound, got: %v", err)
+	}
+}
+
+func TestFormatEffort(t *testing.T) {
+	effort := services.Effort{
+		Tasks:       3,
+		Unestimated: 1,
+		Estimated:   6 * time.Hour,
+		Points:      5,
+		Logged:      2 * time.Hour,
+		Remaining:   4 * time.Hour,
+	}
+	want := "Estimated: 6h 0m + 5pt  Logged: 2h 0m  Remaining: 4h 0m  (1 of 3 tasks unestimated)"
+	if got := formatEffort(effort); got != want {
+		t.Errorf("formatEffort() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/savabush/taskTracker/internal/services"
+	"github.com/spf13/cobra"
+)
+
+// Exit codes of taskTracker, documented in the README.
+const (
+	ExitOK                = 0
+	ExitFailure           = 1 // any other error, such as an unreadable task store
+	ExitUsage             = 2 // invalid arguments or flags, an ambiguous ID prefix, or an unknown command
+	ExitNotFound          = 3 // no task or view with the given name
+	ExitDuplicate         = 4 // another task already has the title
+	ExitInvalidTransition = 5 // the task already has the requested status
+)
+
+// usageError marks an error in the arguments or flags of a command.
+type usageError struct {
+	err error
+}
+
+func (e *usageError) Error() string {
+	return e.err.Error()
+}
+
+func (e *usageError) Unwrap() error {
+	return e.err
+}
+
+// ExitCode returns the exit code for an error returned by a command.
+func ExitCode(err error) int {
+	var usage *usageError
+	switch {
+	case err == nil:
+		return ExitOK
+	case errors.As(err, &usage), errors.Is(err, services.ErrAmbiguousID):
+		return ExitUsage
+	case errors.Is(err, services.ErrTaskNotFound), errors.Is(err, services.ErrViewNotFound):
+		return ExitNotFound
+	case errors.Is(err, services.ErrDuplicateTitle):
+		return ExitDuplicate
+	case errors.Is(err, services.ErrInvalidTransition):
+		return ExitInvalidTransition
+	}
+	return ExitFailure
+}
+
+// Execute runs root, prints the error a command failed with, if any, to
+// stderr and returns the exit code for it.
+func Execute(root *cobra.Command) int {
+	root.SilenceErrors = true
+	root.SilenceUsage = true
+	root.SetFlagErrorFunc(func(c *cobra.Command, err error) error {
+		return &usageError{err}
+	})
+	markUsageErrors(root)
+
+	c, err := root.ExecuteC()
+	if err == nil {
+		return ExitOK
+	}
+	// Commands without Run only fail on unknown subcommands
+	if !c.Runnable() {
+		err = &usageError{err}
+	}
+	stderr := root.ErrOrStderr()
+	fmt.Fprintln(stderr, "Error:", err)
+	code := ExitCode(err)
+	if code == ExitUsage {
+		fmt.Fprintf(stderr, "Run '%s --help' for usage.\n", c.CommandPath())
+	}
+	return code
+}
+
+// markUsageErrors wraps the argument validation of c and its subcommands so
+// that the errors it returns are usage errors.
+func markUsageErrors(c *cobra.Command) {
+	if validate := c.Args; validate != nil {
+		c.Args = func(cmd *cobra.Command, args []string) error {
+			if err := validate(cmd, args); err != nil {
+				return &usageError{err}
+			}
+			return nil
+		}
+	}
+	for _, sub := range c.Commands() {
+		markUsageErrors(sub)
+	}
+}
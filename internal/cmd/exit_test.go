@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/savabush/taskTracker/internal/services"
+	"github.com/spf13/cobra"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "Success", err: nil, want: ExitOK},
+		{name: "Other error", err: errors.New("disk full"), want: ExitFailure},
+		{name: "Usage", err: &usageError{errors.New("bad flag")}, want: ExitUsage},
+		{name: "Task not found", err: fmt.Errorf("%w: %q", services.ErrTaskNotFound, "x"), want: ExitNotFound},
+		{name: "View not found", err: services.ErrViewNotFound, want: ExitNotFound},
+		{name: "Duplicate title", err: services.ErrDuplicateTitle, want: ExitDuplicate},
+		{name: "Invalid transition", err: services.ErrInvalidTransition, want: ExitInvalidTransition},
+		{name: "Ambiguous ID", err: fmt.Errorf("%w: %q", services.ErrAmbiguousID, "3f2c"), want: ExitUsage},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExitCode(tt.err); got != tt.want {
+				t.Errorf("ExitCode(%v) = %d, want %d", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExecute(t *testing.T) {
+	newRoot := func() *cobra.Command {
+		root := &cobra.Command{Use: "root"}
+		var flag int
+		child := &cobra.Command{
+			Use:  "child",
+			Args: cobra.ExactArgs(1),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				if args[0] == "missing" {
+					return fmt.Errorf("%w: %q", services.ErrTaskNotFound, args[0])
+				}
+				return nil
+			},
+		}
+		child.Flags().IntVar(&flag, "count", 0, "")
+		root.AddCommand(child)
+		return root
+	}
+
+	tests := []struct {
+		name       string
+		args       []string
+		want       int
+		wantStderr string
+	}{
+		{name: "Success", args: []string{"child", "task"}, want: ExitOK},
+		{name: "Command error", args: []string{"child", "missing"}, want: ExitNotFound, wantStderr: "Error: task not found: \"missing\"\n"},
+		{name: "Invalid arguments", args: []string{"child"}, want: ExitUsage, wantStderr: "Run 'root child --help' for usage."},
+		{name: "Invalid flag", args: []string{"child", "task", "--count", "x"}, want: ExitUsage, wantStderr: "Run 'root child --help' for usage."},
+		{name: "Unknown command", args: []string{"unknown"}, want: ExitUsage, wantStderr: "Error: unknown command"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := newRoot()
+			var stderr bytes.Buffer
+			root.SetErr(&stderr)
+			root.SetOut(&bytes.Buffer{})
+			root.SetArgs(tt.args)
+
+			if got := Execute(root); got != tt.want {
+				t.Errorf("Execute() = %d, want %d (stderr %q)", got, tt.want, stderr.String())
+			}
+			if !strings.Contains(stderr.String(), tt.wantStderr) {
+				t.Errorf("Expected stderr to contain %q, got %q", tt.wantStderr, stderr.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/savabush/taskTracker/internal/services"
+	"github.com/savabush/taskTracker/pkg/tasktracker"
+	"github.com/spf13/cobra"
+)
+
+var (
+	exportOutput string
+	exportFile   string
+	exportSort   string
+)
+
+// exportExtensions maps file extensions to the export format they imply.
+var exportExtensions = map[string]string{
+	".csv":  services.FormatCSV,
+	".json": services.FormatJSON,
+	".txt":  services.FormatTodoTxt,
+	".md":   services.FormatMarkdown,
+	".ics":  services.FormatICS,
+}
+
+var ExportCmd = &cobra.Command{
+	Use:   "export [filter | @view]",
+	Short: "Export tasks to another format",
+	Long: `export is used to write tasks as CSV, JSON, todo.txt, a Markdown checklist or an iCalendar file of to-dos (VTODO) that calendar apps can subscribe to. Filters and saved views work as in list.
+
+The format is taken from --output, or from the extension of --file, and
+defaults to JSON. Without --file the export is written to standard output.`,
+
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) > 1 {
+			return errors.New("filter must be a single argument; quote expressions containing spaces")
+		}
+		if len(args) == 1 && !strings.HasPrefix(args[0], "@") {
+			if _, err := parseFilter(args[0]); err != nil {
+				return err
+			}
+		}
+		if err := services.ValidateSortOrder(exportSort); err != nil {
+			return err
+		}
+		_, err := resolveExportFormat(exportFile, exportOutput)
+		return err
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, err := resolveExportFormat(exportFile, exportOutput)
+		if err != nil {
+			return &usageError{err}
+		}
+		client, err := tasktracker.New()
+		if err != nil {
+			return err
+		}
+		ctx := commandContext(cmd)
+
+		filter, sortOrder := "", exportSort
+		if len(args) == 1 {
+			filter = args[0]
+			if name, ok := strings.CutPrefix(filter, "@"); ok {
+				view, err := client.View(ctx, name)
+				if err != nil {
+					return err
+				}
+				filter = view.Query
+				if !cmd.Flags().Changed("sort") {
+					sortOrder = view.Sort
+				}
+			}
+		}
+		// Saved views are only checked when they are used
+		if _, err := parseFilter(filter); err != nil {
+			return &usageError{err}
+		}
+		if err := services.ValidateSortOrder(sortOrder); err != nil {
+			return &usageError{fmt.Errorf("invalid sort order: %w", err)}
+		}
+		tasks, err := client.List(ctx, tasktracker.ListOptions{Filter: filter, Sort: sortOrder})
+		if err != nil {
+			return err
+		}
+
+		var w io.Writer = os.Stdout
+		if exportFile != "" {
+			file, err := os.Create(exportFile)
+			if err != nil {
+				return fmt.Errorf("failed to create export file: %w", err)
+			}
+			defer file.Close()
+			w = file
+		}
+		if err := services.ExportTasks(w, tasks, format, time.Now()); err != nil {
+			return fmt.Errorf("failed to export tasks: %w", err)
+		}
+		if exportFile != "" {
+			slog.Info("Exported tasks", "count", len(tasks), "file", exportFile)
+		}
+		return nil
+	},
+}
+
+func init() {
+	ExportCmd.Flags().StringVarP(&exportOutput, "output", "o", "", "Output format: "+strings.Join(services.ExportFormats, ", "))
+	ExportCmd.Flags().StringVar(&exportFile, "file", "", "Write the export to this file instead of standard output")
+	ExportCmd.Flags().StringVar(&exportSort, "sort", "", "Sort by comma-separated fields ("+strings.Join(services.SortFields, ", ")+"); prefix with - for descending")
+}
+
+// resolveExportFormat returns the explicit format if set, the format implied
+// by the file's extension, or JSON.
+func resolveExportFormat(path, format string) (string, error) {
+	if format != "" {
+		if !slices.Contains(services.ExportFormats, format) {
+			return "", errors.New("output must be one of: " + strings.Join(services.ExportFormats, ", "))
+		}
+		return format, nil
+	}
+	if path == "" {
+		return services.FormatJSON, nil
+	}
+	if format, ok := exportExtensions[strings.ToLower(filepath.Ext(path))]; ok {
+		return format, nil
+	}
+	return "", errors.New("cannot detect the format of " + path + "; use --output")
+}
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/savabush/taskTracker/internal/services"
+	"github.com/spf13/cobra"
+)
+
+func TestExportCmd_Args(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		output  string
+		file    string
+		wantErr bool
+	}{
+		{name: "No args", args: []string{}, wantErr: false},
+		{name: "Filter", args: []string{"tag:work"}, wantErr: false},
+		{name: "View", args: []string{"@mine"}, wantErr: false},
+		{name: "Invalid filter", args: []string{"due<someday"}, wantErr: true},
+		{name: "Too many args", args: []string{"a", "b"}, wantErr: true},
+		{name: "Explicit format", args: []string{}, output: "ics", wantErr: false},
+		{name: "Invalid format", args: []string{}, output: "xml", wantErr: true},
+		{name: "Format from file", args: []string{}, file: "tasks.ICS", wantErr: false},
+		{name: "Unknown extension", args: []string{}, file: "tasks.xml", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exportOutput, exportFile = tt.output, tt.file
+			defer func() { exportOutput, exportFile = "", "" }()
+
+			err := ExportCmd.Args(&cobra.Command{}, tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Args() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestExportCmd_Run(t *testing.T) {
+	var logBuf bytes.Buffer
+	oldLogger := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&logBuf, nil)))
+	defer slog.SetDefault(oldLogger)
+
+	cleanup := createTempTaskFile(t)
+	defer cleanup()
+
+	service := loadService(t)
+	service.AddTask("Pay rent")
+	service.AddTask("Write docs")
+	service.UpdateTask("Write docs", func(task *services.Task) { task.Tags = []string{"work"} })
+	service.SaveView(services.View{Name: "work", Query: "tag:work"})
+	service.SaveTasks()
+	defer func() { exportOutput, exportFile = "", "" }()
+
+	exportOutput = "todotxt"
+	output := captureStdout(t, func() {
+		ExportCmd.RunE(ExportCmd, []string{"tag:work"})
+	})
+	if !strings.Contains(output, "Write docs +work") || strings.Contains(output, "Pay rent") {
+		t.Errorf("Expected filtered todo.txt export, got: %q", output)
+	}
+
+	exportOutput = ""
+	exportFile = filepath.Join(t.TempDir(), "tasks.ics")
+	ExportCmd.RunE(ExportCmd, []string{"@work"})
+	data, err := os.ReadFile(exportFile)
+	if err != nil {
+		t.Fatalf("Expected export file to be written: %v", err)
+	}
+	if !strings.Contains(string(data), "SUMMARY:Write docs") || strings.Contains(string(data), "Pay rent") {
+		t.Errorf("Expected view-filtered ICS export, got: %s", data)
+	}
+	if !strings.Contains(logBuf.String(), "Exported tasks") {
+		t.Errorf("Expected log 'Exported tasks', got: %s", logBuf.String())
+	}
+
+	if err := ExportCmd.RunE(ExportCmd, []string{"@missing"}); !errors.Is(err, services.ErrViewNotFound) {
+		t.Errorf("Expected ErrViewNotFound, got: %v", err)
+	}
+}
@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/savabush/taskTracker/internal/services"
+	"github.com/savabush/taskTracker/internal/utils"
+	"github.com/savabush/taskTracker/pkg/tasktracker"
+	"github.com/spf13/cobra"
+)
+
+var (
+	importFormat  string
+	importMapping map[string]string
+	importDryRun  bool
+)
+
+// importExtensions maps file extensions to the import format they imply.
+var importExtensions = map[string]string{
+	".csv":    services.FormatCSV,
+	".json":   services.FormatJSON,
+	".jsonl":  services.FormatJSONL,
+	".ndjson": services.FormatJSONL,
+	".txt":    services.FormatTodoTxt,
+	".md":     services.FormatMarkdown,
+}
+
+var ImportCmd = &cobra.Command{
+	Use:   "import [file]",
+	Short: "Import tasks from another tool",
+	Long: `import is used to add tasks from a CSV, JSON, JSON Lines, todo.txt or Markdown checklist file. Use - to read from standard input.
+
+The format is detected from the file extension unless --format is given. CSV
+columns named like a task field (title, description, notes, tags, priority,
+due, status, estimate, recurrence) are imported as that field; map other
+headers with --map, e.g. --map Name=title --map Labels=tags.
+
+Tasks whose title matches an existing task, ignoring case and repeated
+whitespace, are skipped as duplicates. Use --dry-run to preview the import.`,
+
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return errors.New("requires exactly one file; use - to read from standard input")
+		}
+		if _, err := resolveImportFormat(args[0], importFormat); err != nil {
+			return err
+		}
+		return services.ValidateImportMapping(importMapping)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, err := resolveImportFormat(args[0], importFormat)
+		if err != nil {
+			return &usageError{err}
+		}
+
+		var r io.Reader = os.Stdin
+		if args[0] != "-" {
+			file, err := os.Open(args[0])
+			if err != nil {
+				return fmt.Errorf("failed to open import file: %w", err)
+			}
+			defer file.Close()
+			r = file
+		}
+
+		tasks, err := services.ParseImport(r, format, importMapping)
+		if err != nil {
+			return fmt.Errorf("failed to parse %s import file: %w", format, err)
+		}
+		slog.Debug("Parsed import file", "format", format, "tasks", len(tasks))
+
+		client, err := tasktracker.New()
+		if err != nil {
+			return err
+		}
+		return client.Batch(commandContext(cmd), func(b *tasktracker.Batch) error {
+			existing, err := b.List(tasktracker.ListOptions{})
+			if err != nil {
+				return err
+			}
+			plan := services.PlanImport(existing, tasks)
+			if importDryRun {
+				return renderImportPlan(os.Stdout, plan)
+			}
+
+			for _, task := range plan.Duplicates {
+				slog.Info("Skipped duplicate task", "task", task.Title)
+			}
+			for _, task := range plan.New {
+				if _, err := b.Import(task); err != nil {
+					return err
+				}
+			}
+			slog.Info("Imported tasks", "added", len(plan.New), "duplicates", len(plan.Duplicates))
+			return nil
+		})
+	},
+}
+
+func init() {
+	ImportCmd.Flags().StringVarP(&importFormat, "format", "f", "", "Input format: "+strings.Join(services.ImportFormats, ", ")+" (default: from the file extension)")
+	ImportCmd.Flags().StringToStringVar(&importMapping, "map", nil, "Map a CSV column to a task field, e.g. Name=title (repeatable)")
+	ImportCmd.Flags().BoolVar(&importDryRun, "dry-run", false, "Show what would be imported without changing anything")
+}
+
+// resolveImportFormat returns the explicit format if set, or the format
+// implied by the file's extension.
+func resolveImportFormat(path, format string) (string, error) {
+	if format != "" {
+		if !slices.Contains(services.ImportFormats, format) {
+			return "", errors.New("format must be one of: " + strings.Join(services.ImportFormats, ", "))
+		}
+		return format, nil
+	}
+	if format, ok := importExtensions[strings.ToLower(filepath.Ext(path))]; ok {
+		return format, nil
+	}
+	return "", errors.New("cannot detect the format of " + path + "; use --format")
+}
+
+// renderImportPlan previews the tasks an import would add and skip.
+func renderImportPlan(w io.Writer, plan services.ImportPlan) error {
+	if _, err := fmt.Fprintf(w, "Would import %d task(s):\n", len(plan.New)); err != nil {
+		return err
+	}
+	table := utils.NewTable(w,
+		utils.Column{Header: "STATUS"},
+		utils.Column{Header: "PRIORITY"},
+		utils.Column{Header: "DUE"},
+		utils.Column{Header: "EST"},
+		utils.Column{Header: "TITLE", Flexible: true},
+		utils.Column{Header: "TAGS", Flexible: true},
+	)
+	for _, task := range plan.New {
+		due := ""
+		if !task.Due.IsZero() {
+			due = task.Due.Format(services.DateLayout)
+		}
+		table.AddRow(
+			utils.Cell{Text: string(task.Status), Color: statusColors[task.Status]},
+			utils.Cell{Text: string(task.Priority), Color: priorityColors[task.Priority]},
+			utils.Cell{Text: due},
+			utils.Cell{Text: task.Estimate.String()},
+			utils.Cell{Text: task.Title},
+			utils.Cell{Text: strings.Join(task.Tags, ",")},
+		)
+	}
+	if err := table.Render(); err != nil {
+		return err
+	}
+
+	if len(plan.Duplicates) == 0 {
+		return nil
+	}
+	if _, err := fmt.Fprintf(w, "\nWould skip %d duplicate(s):\n", len(plan.Duplicates)); err != nil {
+		return err
+	}
+	for _, task := range plan.Duplicates {
+		if _, err := fmt.Fprintf(w, "  %s\n", task.Title); err != nil {
+			return err
+		}
+	}
+	return nil
+}
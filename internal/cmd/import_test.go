@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"bytes"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestImportCmd_Args(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		format  string
+		mapping map[string]string
+		wantErr bool
+	}{
+		{name: "No args", args: []string{}, wantErr: true},
+		{name: "Too many args", args: []string{"a.csv", "b.csv"}, wantErr: true},
+		{name: "Detected format", args: []string{"tasks.CSV"}, wantErr: false},
+		{name: "Markdown", args: []string{"TODO.md"}, wantErr: false},
+		{name: "Unknown extension", args: []string{"tasks.xml"}, wantErr: true},
+		{name: "Stdin without format", args: []string{"-"}, wantErr: true},
+		{name: "Stdin with format", args: []string{"-"}, format: "jsonl", wantErr: false},
+		{name: "Invalid format", args: []string{"tasks.csv"}, format: "xml", wantErr: true},
+		{name: "Valid mapping", args: []string{"tasks.csv"}, mapping: map[string]string{"Name": "title"}, wantErr: false},
+		{name: "Invalid mapping", args: []string{"tasks.csv"}, mapping: map[string]string{"Name": "summary"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			importFormat, importMapping = tt.format, tt.mapping
+			defer func() { importFormat, importMapping = "", nil }()
+
+			err := ImportCmd.Args(&cobra.Command{}, tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Args() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestImportCmd_Run(t *testing.T) {
+	var logBuf bytes.Buffer
+	oldLogger := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&logBuf, nil)))
+	defer slog.SetDefault(oldLogger)
+
+	cleanup := createTempTaskFile(t)
+	defer cleanup()
+
+	service := loadService(t)
+	service.AddTask("Pay rent")
+	service.SaveTasks()
+
+	file := filepath.Join(t.TempDir(), "export.csv")
+	os.WriteFile(file, []byte("Name,Labels\nPay Rent,home\nWrite docs,work\n"), 0644)
+	importMapping = map[string]string{"Name": "title", "Labels": "tags"}
+	defer func() { importMapping, importDryRun = nil, false }()
+
+	// A dry run previews without saving
+	importDryRun = true
+	output := captureStdout(t, func() {
+		ImportCmd.RunE(&cobra.Command{}, []string{file})
+	})
+	if !strings.Contains(output, "Would import 1 task(s)") || !strings.Contains(output, "Write docs") {
+		t.Errorf("Expected preview of new task, got: %q", output)
+	}
+	if !strings.Contains(output, "Would skip 1 duplicate(s)") || !strings.Contains(output, "Pay Rent") {
+		t.Errorf("Expected preview of duplicate, got: %q", output)
+	}
+	if len(loadService(t).Tasks) != 1 {
+		t.Errorf("Dry run must not save tasks")
+	}
+
+	importDryRun = false
+	ImportCmd.RunE(&cobra.Command{}, []string{file})
+	if !strings.Contains(logBuf.String(), "Imported tasks") || !strings.Contains(logBuf.String(), "added=1 duplicates=1") {
+		t.Errorf("Expected import summary, got: %s", logBuf.String())
+	}
+	task, err := loadService(t).GetTask("Write docs")
+	if err != nil || !task.HasTag("work") {
+		t.Errorf("Expected imported task with tag, got %+v (%v)", task, err)
+	}
+
+	err = ImportCmd.RunE(&cobra.Command{}, []string{filepath.Join(t.TempDir(), "missing.csv")})
+	if err == nil || !strings.Contains(err.Error(), "failed to open import file") {
+		t.Errorf("Expected open error, got: %v", err)
+	}
+}
@@ -1,52 +1,243 @@
 package cmd
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
+	"os"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/fatih/color"
 	"github.com/savabush/taskTracker/internal/services"
+	"github.com/savabush/taskTracker/internal/utils"
+	"github.com/savabush/taskTracker/pkg/tasktracker"
 	"github.com/spf13/cobra"
 )
 
+var statusColors = map[services.TaskStatus]*color.Color{
+	services.TaskStatusPending:    color.New(color.FgYellow),
+	services.TaskStatusInProgress: color.New(color.FgCyan),
+	services.TaskStatusCompleted:  color.New(color.FgGreen),
+}
+
+var priorityColors = map[services.TaskPriority]*color.Color{
+	services.TaskPriorityLow:      color.New(color.Faint),
+	services.TaskPriorityMedium:   color.New(color.FgYellow),
+	services.TaskPriorityHigh:     color.New(color.FgRed),
+	services.TaskPriorityCritical: color.New(color.FgRed, color.Bold),
+}
+
+var overdueColor = color.New(color.FgRed, color.Bold)
+
+// recurringMarker prefixes the titles of recurring tasks in tables.
+const recurringMarker = "↻ "
+
+// Output formats supported by list.
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+	outputPlain = "plain"
+)
+
+var outputFormats = []string{outputTable, outputJSON, outputPlain}
+
+var (
+	listSort   string
+	listOutput string
+)
+
 var ListCmd = &cobra.Command{
-	Use:   "list [filter]",
+	Use:   "list [filter | @view]",
 	Short: "List all tasks",
-	Long:  `list is used to list all tasks. If a filter is provided, it will filter the tasks by the status. The filter must be one of: pending, in progress, completed`,
+	Long: `list is used to list all tasks. If a filter is provided, only tasks matching it are shown.
+
+A filter is either a status (pending, inProgress, completed) or an expression
+combining field comparisons with and, or, not and parentheses:
+
+  list 'status:pending and (tag:backend or priority>=high) and due<2026-11-01'
+
+Fields: status, tag, priority, due, created, updated, title, description, note.
+Operators: : = != < <= > >=. Dates are YYYY-MM-DD, today, tomorrow or yesterday.
+
+A filter of the form @name runs a view saved with "view save". Explicit --sort
+and --output flags override the view's settings.`,
 
 	Args: func(cmd *cobra.Command, args []string) error {
 		slog.Debug("Validating list command arguments", "args", args)
 		if len(args) > 1 {
 			slog.Debug("Too many arguments provided")
-			return errors.New("filter must be one of: pending, in progress, completed")
+			return errors.New("filter must be a single argument; quote expressions containing spaces")
 		}
 
-		if len(args) == 1 {
+		if len(args) == 1 && !strings.HasPrefix(args[0], "@") {
 			slog.Debug("Checking filter value", "filter", args[0])
-			if args[0] != string(services.TaskStatusPending) && args[0] != string(services.TaskStatusInProgress) && args[0] != string(services.TaskStatusCompleted) {
+			if _, err := parseFilter(args[0]); err != nil {
 				slog.Debug("Invalid filter value")
-				return errors.New("filter must be one of: pending, in progress, completed")
+				return err
 			}
 		}
-		return nil
+		return validateListFlags(listSort, listOutput)
 	},
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		slog.Debug("Running list command")
-		taskService := services.NewTaskService()
+		client, err := tasktracker.New()
+		if err != nil {
+			return err
+		}
+		ctx := commandContext(cmd)
 
-		var filter services.TaskStatus
+		view := tasktracker.View{Sort: listSort, Output: listOutput}
 		if len(args) == 1 {
-			filter = services.TaskStatus(args[0])
-			slog.Debug("Filtering tasks", "filter", filter)
+			if name, ok := strings.CutPrefix(args[0], "@"); ok {
+				saved, err := client.View(ctx, name)
+				if err != nil {
+					return err
+				}
+				slog.Debug("Using saved view", "view", name, "query", saved.Query)
+				view.Query = saved.Query
+				if !cmd.Flags().Changed("sort") {
+					view.Sort = saved.Sort
+				}
+				if !cmd.Flags().Changed("output") && saved.Output != "" {
+					view.Output = saved.Output
+				}
+			} else {
+				view.Query = args[0]
+			}
+		}
+
+		if view.Query != "" {
+			slog.Debug("Filtering tasks", "filter", view.Query)
 		} else {
 			slog.Debug("No filter provided, showing all tasks")
 		}
 
-		tasks := taskService.GetTasks(filter)
+		// Saved views are only checked when they are used
+		if _, err := parseFilter(view.Query); err != nil {
+			return &usageError{err}
+		}
+		if err := services.ValidateSortOrder(view.Sort); err != nil {
+			return &usageError{fmt.Errorf("invalid sort order: %w", err)}
+		}
+
+		tasks, err := client.List(ctx, tasktracker.ListOptions{Filter: view.Query, Sort: view.Sort})
+		if err != nil {
+			return err
+		}
 		slog.Debug("Retrieved tasks from service", "count", len(tasks))
 
+		return renderTasks(os.Stdout, tasks, view.Output)
+	},
+}
+
+func init() {
+	ListCmd.Flags().StringVar(&listSort, "sort", "", "Sort by comma-separated fields ("+strings.Join(services.SortFields, ", ")+"); prefix with - for descending")
+	ListCmd.Flags().StringVarP(&listOutput, "output", "o", outputTable, "Output format: "+strings.Join(outputFormats, ", "))
+}
+
+func validateListFlags(sortOrder, output string) error {
+	if err := services.ValidateSortOrder(sortOrder); err != nil {
+		return err
+	}
+	if output != "" && !slices.Contains(outputFormats, output) {
+		return errors.New("output must be one of: " + strings.Join(outputFormats, ", "))
+	}
+	return nil
+}
+
+// parseFilter parses a list filter, pointing at the offending column in the
+// error message when the expression is malformed.
+func parseFilter(filter string) (services.Query, error) {
+	query, err := services.ParseQuery(filter)
+	var parseErr *services.ParseError
+	if errors.As(err, &parseErr) {
+		return nil, fmt.Errorf("invalid filter at %w\n  %s", parseErr, strings.ReplaceAll(parseErr.Pointer(), "\n", "\n  "))
+	}
+	return query, err
+}
+
+// renderTasks writes tasks in the given output format.
+func renderTasks(w io.Writer, tasks []services.Task, output string) error {
+	switch output {
+	case outputJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(tasks)
+	case outputPlain:
 		for _, task := range tasks {
-			fmt.Printf("%s %s - %s\n", task.UpdatedAt.Format("2006-01-02 15:04:05"), task.Title, task.Status)
+			if _, err := fmt.Fprintf(w, "%s %s - %s\n", task.UpdatedAt.Format("2006-01-02 15:04:05"), task.Title, task.Status); err != nil {
+				return err
+			}
 		}
-	},
+		return nil
+	}
+
+	now := time.Now()
+	table := utils.NewTable(w,
+		utils.Column{Header: "ID"},
+		utils.Column{Header: "UPDATED"},
+		utils.Column{Header: "STATUS"},
+		utils.Column{Header: "PRIORITY"},
+		utils.Column{Header: "DUE"},
+		utils.Column{Header: "EST"},
+		utils.Column{Header: "TITLE", Flexible: true},
+		utils.Column{Header: "TAGS", Flexible: true},
+	)
+	for _, task := range tasks {
+		due := utils.Cell{}
+		if !task.Due.IsZero() {
+			due.Text = task.Due.Format(services.DateLayout)
+			if task.IsOverdue(now) {
+				due.Color = overdueColor
+			}
+		}
+		title := task.Title
+		if task.Recurrence != "" {
+			title = recurringMarker + title
+		}
+		table.AddRow(
+			utils.Cell{Text: task.ShortID()},
+			utils.Cell{Text: task.UpdatedAt.Format("2006-01-02 15:04:05")},
+			utils.Cell{Text: string(task.Status), Color: statusColors[task.Status]},
+			utils.Cell{Text: string(task.Priority), Color: priorityColors[task.Priority]},
+			due,
+			utils.Cell{Text: task.Estimate.String()},
+			utils.Cell{Text: title},
+			utils.Cell{Text: strings.Join(task.Tags, ",")},
+		)
+	}
+	if err := table.Render(); err != nil {
+		return err
+	}
+
+	if effort := services.SummarizeEffort(tasks, now); effort.HasData() {
+		_, err := fmt.Fprintf(w, "\n%s\n", formatEffort(effort))
+		return err
+	}
+	return nil
+}
+
+// formatEffort summarizes estimated, logged and remaining effort in one line.
+func formatEffort(effort services.Effort) string {
+	var estimated []string
+	if effort.Estimated > 0 {
+		estimated = append(estimated, formatDuration(effort.Estimated))
+	}
+	if effort.Points > 0 {
+		estimated = append(estimated, services.Estimate{Points: effort.Points}.String())
+	}
+	if len(estimated) == 0 {
+		estimated = append(estimated, "-")
+	}
+
+	line := fmt.Sprintf("Estimated: %s  Logged: %s  Remaining: %s",
+		strings.Join(estimated, " + "), formatDuration(effort.Logged), formatDuration(effort.Remaining))
+	if effort.Unestimated > 0 {
+		line += fmt.Sprintf("  (%d of %d tasks unestimated)", effort.Unestimated, effort.Tasks)
+	}
+	return line
 }
@@ -8,7 +8,6 @@ import (
 	"strings"
 	"testing"
 
-	"github.com/savabush/taskTracker/internal/services"
 	"github.com/spf13/cobra"
 )
 
@@ -48,6 +47,16 @@ func TestListCmd_Args(t *testing.T) {
 			args:    []string{"pending", "extra"},
 			wantErr: true,
 		},
+		{
+			name:    "Valid expression",
+			args:    []string{"status:pending and (tag:backend or priority>=high)"},
+			wantErr: false,
+		},
+		{
+			name:    "Malformed expression",
+			args:    []string{"status:pending and priority>="},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -83,7 +92,7 @@ func TestListCmd_Run(t *testing.T) {
 	defer cleanup()
 
 	// Prepare test data with different statuses
-	service := services.NewTaskService()
+	service := loadService(t)
 
 	// Add pending task
 	service.AddTask("Pending Task")
@@ -138,7 +147,7 @@ func TestListCmd_Run(t *testing.T) {
 			logBuf.Reset()
 
 			cmd := &cobra.Command{}
-			ListCmd.Run(cmd, tt.args)
+			ListCmd.RunE(cmd, tt.args)
 
 			// Close the pipe to capture output
 			w.Close()
@@ -167,3 +176,17 @@ func TestListCmd_Run(t *testing.T) {
 		})
 	}
 }
+
+func TestParseFilterError(t *testing.T) {
+	_, err := parseFilter("pending and due<tomorow")
+	if err == nil {
+		t.Fatal("Expected error for invalid date")
+	}
+	want := "invalid filter at column 17: invalid date 'tomorow'"
+	if !strings.HasPrefix(err.Error(), want) {
+		t.Errorf("Error = %q, want prefix %q", err.Error(), want)
+	}
+	if !strings.Contains(err.Error(), "\n  pending and due<tomorow\n                  ^") {
+		t.Errorf("Expected caret under offending column, got: %q", err.Error())
+	}
+}
@@ -1,17 +1,26 @@
 package cmd
 
 import (
+	"context"
 	"errors"
 	"log/slog"
 
-	"github.com/savabush/taskTracker/internal/services"
+	"github.com/savabush/taskTracker/pkg/tasktracker"
 	"github.com/spf13/cobra"
 )
 
+var (
+	markCompletedSelect  selector
+	markInProgressSelect selector
+)
+
 var MarkCompletedCmd = &cobra.Command{
 	Use:   "mark-completed [task]",
 	Short: "Mark a task as completed",
-	Long:  `mark-completed is used to mark a task as completed.`,
+	Long: `mark-completed is used to mark a task as completed.
+
+With --filter, --status, --older-than or --ids it marks every selected task
+that is not completed yet, after showing them and asking for confirmation.`,
 
 	Args: func(cmd *cobra.Command, args []string) error {
 		if len(args) > 1 {
@@ -19,23 +28,25 @@ var MarkCompletedCmd = &cobra.Command{
 		}
 		return nil
 	},
-	Run: func(cmd *cobra.Command, args []string) {
-		taskService := services.NewTaskService()
-		_, err := taskService.GetTask(args[0])
-		if err != nil {
-			slog.Error("Task not found", "error", err)
-			return
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if markCompletedSelect.active() {
+			return markCompletedSelect.run(cmd, args, eachOptions{}, "Complete", "completed", "Marked tasks as completed",
+				hasStatus(tasktracker.StatusCompleted, false), discardTask((*tasktracker.Batch).Complete))
+		}
+		if len(args) == 0 {
+			return &usageError{errors.New("requires a task or a selector flag")}
 		}
-		taskService.CompleteTask(args[0])
-		taskService.SaveTasks()
-		slog.Info("Marked task as completed", "task", args[0])
+		return markTask(cmd, args[0], (*tasktracker.Client).Complete, "Marked task as completed")
 	},
 }
 
 var MarkInProgressCmd = &cobra.Command{
 	Use:   "mark-in-progress [task]",
 	Short: "Mark a task as in progress",
-	Long:  `mark-in-progress is used to mark a task as in progress.`,
+	Long: `mark-in-progress is used to mark a task as in progress.
+
+With --filter, --status, --older-than or --ids it marks every selected task
+that is not in progress yet, after showing them and asking for confirmation.`,
 
 	Args: func(cmd *cobra.Command, args []string) error {
 		if len(args) > 1 {
@@ -43,15 +54,48 @@ var MarkInProgressCmd = &cobra.Command{
 		}
 		return nil
 	},
-	Run: func(cmd *cobra.Command, args []string) {
-		taskService := services.NewTaskService()
-		_, err := taskService.GetTask(args[0])
-		if err != nil {
-			slog.Error("Task not found", "error", err)
-			return
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if markInProgressSelect.active() {
+			return markInProgressSelect.run(cmd, args, eachOptions{}, "Start", "started", "Marked tasks as in progress",
+				hasStatus(tasktracker.StatusInProgress, false), discardTask((*tasktracker.Batch).Start))
+		}
+		if len(args) == 0 {
+			return &usageError{errors.New("requires a task or a selector flag")}
 		}
-		taskService.InProgressTask(args[0])
-		taskService.SaveTasks()
-		slog.Info("Marked task as in progress", "task", args[0])
+		return markTask(cmd, args[0], (*tasktracker.Client).Start, "Marked task as in progress")
 	},
 }
+
+func init() {
+	markCompletedSelect.addFlags(MarkCompletedCmd)
+	markInProgressSelect.addFlags(MarkInProgressCmd)
+}
+
+// markTask changes the status of a task with mark and logs message.
+func markTask(cmd *cobra.Command, key string, mark func(*tasktracker.Client, context.Context, string) (tasktracker.Task, error), message string) error {
+	client, err := tasktracker.New()
+	if err != nil {
+		return err
+	}
+	if _, err := mark(client, commandContext(cmd), key); err != nil {
+		return err
+	}
+	slog.Info(message, "task", key)
+	return nil
+}
+
+// hasStatus returns a function reporting whether a task has status, or
+// whether it does not when want is false.
+func hasStatus(status tasktracker.TaskStatus, want bool) func(task tasktracker.Task) bool {
+	return func(task tasktracker.Task) bool {
+		return (task.Status == status) == want
+	}
+}
+
+// discardTask adapts a batch method returning the changed task to runEach.
+func discardTask(op func(*tasktracker.Batch, string) (tasktracker.Task, error)) func(*tasktracker.Batch, string) error {
+	return func(batch *tasktracker.Batch, key string) error {
+		_, err := op(batch, key)
+		return err
+	}
+}
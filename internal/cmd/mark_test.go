@@ -2,9 +2,11 @@ package cmd
 
 import (
 	"bytes"
+	"errors"
 	"log/slog"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/savabush/taskTracker/internal/services"
 	"github.com/spf13/cobra"
@@ -98,6 +100,7 @@ func TestMarkCompletedCmd_Run(t *testing.T) {
 		taskName     string
 		taskExists   bool
 		expectedLog  string
+		expectedErr  error
 		expectedTask bool
 	}{
 		{
@@ -111,7 +114,7 @@ func TestMarkCompletedCmd_Run(t *testing.T) {
 			name:         "Mark non-existent task",
 			taskName:     "NonExistentTask",
 			taskExists:   false,
-			expectedLog:  "Task not found",
+			expectedErr:  services.ErrTaskNotFound,
 			expectedTask: false,
 		},
 	}
@@ -122,7 +125,7 @@ func TestMarkCompletedCmd_Run(t *testing.T) {
 			logBuf.Reset()
 
 			// Setup task
-			service := services.NewTaskService()
+			service := loadService(t)
 			// Clear existing tasks
 			for k := range service.Tasks {
 				delete(service.Tasks, k)
@@ -137,10 +140,13 @@ func TestMarkCompletedCmd_Run(t *testing.T) {
 
 			// Execute command
 			cmd := &cobra.Command{}
-			MarkCompletedCmd.Run(cmd, []string{tt.taskName})
+			err := MarkCompletedCmd.RunE(cmd, []string{tt.taskName})
+			if !errors.Is(err, tt.expectedErr) {
+				t.Errorf("Expected error %v, got %v", tt.expectedErr, err)
+			}
 
 			// Reload service to see changes
-			service = services.NewTaskService()
+			service = loadService(t)
 
 			// Check task status
 			if tt.expectedTask {
@@ -179,6 +185,7 @@ func TestMarkInProgressCmd_Run(t *testing.T) {
 		taskName     string
 		taskExists   bool
 		expectedLog  string
+		expectedErr  error
 		expectedTask bool
 	}{
 		{
@@ -192,7 +199,7 @@ func TestMarkInProgressCmd_Run(t *testing.T) {
 			name:         "Mark non-existent task",
 			taskName:     "NonExistentTask",
 			taskExists:   false,
-			expectedLog:  "Task not found",
+			expectedErr:  services.ErrTaskNotFound,
 			expectedTask: false,
 		},
 	}
@@ -203,7 +210,7 @@ func TestMarkInProgressCmd_Run(t *testing.T) {
 			logBuf.Reset()
 
 			// Setup task
-			service := services.NewTaskService()
+			service := loadService(t)
 			// Clear existing tasks
 			for k := range service.Tasks {
 				delete(service.Tasks, k)
@@ -218,10 +225,13 @@ func TestMarkInProgressCmd_Run(t *testing.T) {
 
 			// Execute command
 			cmd := &cobra.Command{}
-			MarkInProgressCmd.Run(cmd, []string{tt.taskName})
+			err := MarkInProgressCmd.RunE(cmd, []string{tt.taskName})
+			if !errors.Is(err, tt.expectedErr) {
+				t.Errorf("Expected error %v, got %v", tt.expectedErr, err)
+			}
 
 			// Reload service to see changes
-			service = services.NewTaskService()
+			service = loadService(t)
 
 			// Check task status
 			if tt.expectedTask {
@@ -241,3 +251,39 @@ func TestMarkInProgressCmd_Run(t *testing.T) {
 		})
 	}
 }
+
+func TestMarkCompletedCmd_Selector(t *testing.T) {
+	cleanup := createTempTaskFile(t)
+	defer cleanup()
+
+	var logBuf bytes.Buffer
+	oldLogger := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&logBuf, &slog.HandlerOptions{Level: slog.LevelInfo})))
+	t.Cleanup(func() { slog.SetDefault(oldLogger) })
+
+	seedSelectorTasks(t, time.Now())
+
+	markCompletedSelect = selector{filter: "tag:sprint", dryRun: true}
+	err := MarkCompletedCmd.RunE(&cobra.Command{}, []string{})
+	if err != nil || loadService(t).Tasks["c"].Status != services.TaskStatusPending {
+		t.Errorf("Expected a dry run to change nothing, got error %v", err)
+	}
+
+	markCompletedSelect = selector{filter: "tag:sprint", yes: true}
+	err = MarkCompletedCmd.RunE(&cobra.Command{}, []string{})
+	markCompletedSelect = selector{}
+	if err != nil {
+		t.Fatalf("RunE() error = %v", err)
+	}
+	if status := loadService(t).Tasks["c"].Status; status != services.TaskStatusCompleted {
+		t.Errorf("Expected c to be completed, got %s", status)
+	}
+	// a was already completed and is left out rather than failing
+	if !strings.Contains(logBuf.String(), "Marked tasks as completed") || !strings.Contains(logBuf.String(), "count=1") {
+		t.Errorf("Expected one task marked, got: %s", logBuf.String())
+	}
+
+	if err := MarkCompletedCmd.RunE(&cobra.Command{}, []string{}); ExitCode(err) != ExitUsage {
+		t.Errorf("Expected a usage error without a task or selector, got %v", err)
+	}
+}
@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/savabush/taskTracker/internal/services"
+	"github.com/savabush/taskTracker/pkg/tasktracker"
+	"github.com/spf13/cobra"
+)
+
+var (
+	remindWithin   string
+	remindCommand  string
+	remindWebhook  string
+	remindTerminal bool
+	remindForce    bool
+)
+
+var RemindCmd = &cobra.Command{
+	Use:   "remind [filter]",
+	Short: "Send reminders for tasks that are due soon",
+	Long:  `remind is used to send reminders for open tasks that are overdue or due within a window, to the terminal, a desktop notification command and/or a webhook. Sent reminders are remembered so that running remind from cron does not repeat them; a reminder is sent again when a task's due date changes. An optional filter uses the same syntax as list.`,
+
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) > 1 {
+			return errors.New("filter must be a single argument; quote expressions containing spaces")
+		}
+		if len(args) == 1 {
+			if _, err := parseFilter(args[0]); err != nil {
+				return err
+			}
+		}
+		if _, err := parseWindow(remindWithin); err != nil {
+			return err
+		}
+		if remindWebhook != "" {
+			if err := services.ValidateWebhookURL(remindWebhook); err != nil {
+				return err
+			}
+		}
+		if !remindTerminal && remindCommand == "" && remindWebhook == "" {
+			return errors.New("no reminder sink enabled; use --terminal, --command or --webhook")
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		filter := ""
+		if len(args) == 1 {
+			filter = args[0]
+		}
+		if _, err := parseFilter(filter); err != nil {
+			return &usageError{err}
+		}
+		within, err := parseWindow(remindWithin)
+		if err != nil {
+			return &usageError{err}
+		}
+
+		client, err := tasktracker.New()
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		notifiers := reminderNotifiers()
+		sent, failed := 0, 0
+		err = client.Batch(commandContext(cmd), func(b *tasktracker.Batch) error {
+			tasks, err := b.List(tasktracker.ListOptions{Filter: filter})
+			if err != nil {
+				return err
+			}
+			reminders := services.DueReminders(tasks, within, now)
+			slog.Debug("Found due tasks", "count", len(reminders), "within", within)
+
+			// Each sink is sent the reminders it has not had yet, so that
+			// a failed sink is retried on the next run
+			for _, reminder := range reminders {
+				for _, notifier := range notifiers {
+					if !remindForce && b.Reminded(reminder.Task, notifier.Sink()) {
+						continue
+					}
+					if err := notifier.Notify(reminder, now); err != nil {
+						slog.Error("Failed to send reminder", "task", reminder.Task.Title, "sink", notifier.Sink(), "error", err)
+						failed++
+						continue
+					}
+					b.MarkReminded(reminder.Task, notifier.Sink(), now)
+					sent++
+				}
+			}
+			slog.Debug("Sent reminders", "sent", sent, "failed", failed)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		if failed > 0 {
+			return fmt.Errorf("failed to send %d reminders", failed)
+		}
+		return nil
+	},
+}
+
+func init() {
+	RemindCmd.Flags().StringVar(&remindWithin, "within", "24h", "Remind about tasks due within this window, e.g. 12h or 2d")
+	RemindCmd.Flags().StringVar(&remindCommand, "command", "", "Shell command to run per reminder, e.g. 'notify-send \"$TASK_TITLE\" \"$TASK_MESSAGE\"'")
+	RemindCmd.Flags().StringVar(&remindWebhook, "webhook", "", "URL to POST each reminder to as JSON")
+	RemindCmd.Flags().BoolVar(&remindTerminal, "terminal", true, "Print reminders to the terminal")
+	RemindCmd.Flags().BoolVar(&remindForce, "force", false, "Send reminders again even if they were already sent")
+}
+
+// parseWindow parses a Go duration, additionally accepting whole days such
+// as "2d".
+func parseWindow(value string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err == nil && n >= 0 {
+			return time.Duration(n) * 24 * time.Hour, nil
+		}
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		return 0, errors.New("within must be a duration like 30m, 12h or 2d")
+	}
+	return d, nil
+}
+
+func reminderNotifiers() []services.Notifier {
+	var notifiers []services.Notifier
+	if remindTerminal {
+		notifiers = append(notifiers, services.TerminalNotifier{W: os.Stdout})
+	}
+	if remindCommand != "" {
+		notifiers = append(notifiers, services.CommandNotifier{Command: remindCommand})
+	}
+	if remindWebhook != "" {
+		notifiers = append(notifiers, services.WebhookNotifier{URL: remindWebhook})
+	}
+	return notifiers
+}
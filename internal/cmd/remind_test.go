@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/savabush/taskTracker/internal/services"
+	"github.com/spf13/cobra"
+)
+
+func TestRemindCmd_Args(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		within   string
+		webhook  string
+		terminal bool
+		wantErr  bool
+	}{
+		{name: "No args", args: []string{}, within: "24h", terminal: true, wantErr: false},
+		{name: "Filter", args: []string{"tag:work"}, within: "24h", terminal: true, wantErr: false},
+		{name: "Too many args", args: []string{"a", "b"}, within: "24h", terminal: true, wantErr: true},
+		{name: "Days window", args: []string{}, within: "2d", terminal: true, wantErr: false},
+		{name: "Invalid window", args: []string{}, within: "soon", terminal: true, wantErr: true},
+		{name: "Valid webhook", args: []string{}, within: "24h", webhook: "http://localhost:9000/hook", wantErr: false},
+		{name: "Invalid webhook", args: []string{}, within: "24h", webhook: "localhost", terminal: true, wantErr: true},
+		{name: "No sinks", args: []string{}, within: "24h", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			remindWithin, remindWebhook, remindTerminal = tt.within, tt.webhook, tt.terminal
+			defer func() { remindWithin, remindWebhook, remindTerminal = "24h", "", true }()
+
+			err := RemindCmd.Args(&cobra.Command{}, tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Args() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseWindow(t *testing.T) {
+	tests := map[string]time.Duration{
+		"30m": 30 * time.Minute,
+		"12h": 12 * time.Hour,
+		"2d":  48 * time.Hour,
+		"0d":  0,
+	}
+	for value, want := range tests {
+		got, err := parseWindow(value)
+		if err != nil || got != want {
+			t.Errorf("parseWindow(%q) = %v, %v, want %v", value, got, err, want)
+		}
+	}
+	for _, value := range []string{"", "d", "-1h", "tomorrow"} {
+		if _, err := parseWindow(value); err == nil {
+			t.Errorf("parseWindow(%q) expected error", value)
+		}
+	}
+}
+
+func TestRemindCmd_Run(t *testing.T) {
+	var logBuf bytes.Buffer
+	oldLogger := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&logBuf, nil)))
+	defer slog.SetDefault(oldLogger)
+
+	cleanup := createTempTaskFile(t)
+	defer cleanup()
+
+	now := time.Now()
+	service := loadService(t)
+	service.AddTask("Pay rent")
+	service.UpdateTask("Pay rent", func(task *services.Task) {
+		task.Due = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	})
+	service.AddTask("Someday")
+	service.SaveTasks()
+
+	output := captureStdout(t, func() {
+		RemindCmd.RunE(&cobra.Command{}, nil)
+	})
+	if !strings.Contains(output, "Reminder: Pay rent is due today") {
+		t.Errorf("Expected reminder in output, got: %q", output)
+	}
+	if strings.Contains(output, "Someday") {
+		t.Errorf("Expected undated task not to be reminded, got: %q", output)
+	}
+
+	// A second run does not repeat the reminder
+	output = captureStdout(t, func() {
+		RemindCmd.RunE(&cobra.Command{}, nil)
+	})
+	if output != "" {
+		t.Errorf("Expected no reminders on second run, got: %q", output)
+	}
+}
+
+func TestRemindCmd_FailedSink(t *testing.T) {
+	var logBuf bytes.Buffer
+	oldLogger := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&logBuf, nil)))
+	defer slog.SetDefault(oldLogger)
+
+	cleanup := createTempTaskFile(t)
+	defer cleanup()
+
+	now := time.Now()
+	service := loadService(t)
+	service.AddTask("Pay rent")
+	service.UpdateTask("Pay rent", func(task *services.Task) {
+		task.Due = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	})
+	service.SaveTasks()
+
+	remindCommand = "exit 3"
+	defer func() { remindCommand = "" }()
+
+	var err error
+	output := captureStdout(t, func() {
+		err = RemindCmd.RunE(&cobra.Command{}, nil)
+	})
+	if err == nil {
+		t.Fatal("Expected an error when a sink fails")
+	}
+	if !strings.Contains(output, "Reminder: Pay rent is due today") {
+		t.Errorf("Expected reminder in output, got: %q", output)
+	}
+
+	// The terminal is not repeated but the failed command is retried
+	output = captureStdout(t, func() {
+		err = RemindCmd.RunE(&cobra.Command{}, nil)
+	})
+	if err == nil {
+		t.Error("Expected the failed sink to be retried")
+	}
+	if output != "" {
+		t.Errorf("Expected the terminal reminder not to be repeated, got: %q", output)
+	}
+	if !strings.Contains(logBuf.String(), "sink=command") {
+		t.Errorf("Expected the failed sink to be logged, got: %q", logBuf.String())
+	}
+}
@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/savabush/taskTracker/internal/services"
+	"github.com/spf13/cobra"
+)
+
+var ReplicateCmd = &cobra.Command{
+	Use:   "replicate [file | dir]...",
+	Short: "Merge operation logs from other machines",
+	Long: `replicate is used with the oplog storage to merge the operations recorded on other machines, read from a .jsonl log file or a directory of them such as another machine's log directory. Operations that are already known are skipped, so replicating the same log again, or logs in any order, gives the same tasks.
+
+Each field of a task keeps the value written last according to the log's logical clock; a task deleted on one machine and edited on another is kept.`,
+
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		storage, ok := services.GetStorage().(*services.OpLogStorage)
+		if !ok {
+			return &usageError{errors.New("replicate requires --storage oplog")}
+		}
+		for _, path := range args {
+			added, err := storage.Replicate(path)
+			if err != nil {
+				return fmt.Errorf("failed to replicate %s: %w", path, err)
+			}
+			slog.Info("Replicated operations", "path", path, "new", added)
+		}
+		return nil
+	},
+}
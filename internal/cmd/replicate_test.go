@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"bytes"
+	"log/slog"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/savabush/taskTracker/internal/services"
+)
+
+func TestReplicateCmd_Args(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr bool
+	}{
+		{name: "No args", args: []string{}, wantErr: true},
+		{name: "One path", args: []string{"laptop.jsonl"}, wantErr: false},
+		{name: "Several paths", args: []string{"laptop", "phone.jsonl"}, wantErr: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ReplicateCmd.Args(ReplicateCmd, tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Args() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestReplicateCmd_Run(t *testing.T) {
+	var logBuf bytes.Buffer
+	oldLogger := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&logBuf, nil)))
+	defer slog.SetDefault(oldLogger)
+	original := services.GetStorage()
+	defer services.SetStorage(original)
+
+	// Other backends have no operation log
+	if err := ReplicateCmd.RunE(ReplicateCmd, []string{"laptop"}); err == nil || !strings.Contains(err.Error(), "replicate requires --storage oplog") {
+		t.Errorf("Expected error for JSON storage, got: %v", err)
+	}
+
+	dir := t.TempDir()
+	laptop, _ := services.NewOpLogStorage(filepath.Join(dir, "laptop"), "laptop")
+	services.SetStorage(laptop)
+	service := loadService(t)
+	service.AddTask("From laptop")
+	service.SaveTasks()
+
+	phone, _ := services.NewOpLogStorage(filepath.Join(dir, "phone"), "phone")
+	services.SetStorage(phone)
+	logBuf.Reset()
+	ReplicateCmd.RunE(ReplicateCmd, []string{laptop.Dir})
+	if !strings.Contains(logBuf.String(), "Replicated operations") || strings.Contains(logBuf.String(), "new=0") {
+		t.Errorf("Expected new operations, got: %s", logBuf.String())
+	}
+	if _, err := loadService(t).GetTask("From laptop"); err != nil {
+		t.Errorf("Expected replicated task: %v", err)
+	}
+
+	logBuf.Reset()
+	ReplicateCmd.RunE(ReplicateCmd, []string{filepath.Join(dir, "missing", "file.jsonl"), laptop.Dir})
+	if strings.Count(logBuf.String(), "new=0") != 2 {
+		t.Errorf("Expected nothing new from missing or known logs, got: %s", logBuf.String())
+	}
+}
@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"io"
+	"log/slog"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/savabush/taskTracker/internal/services"
+	"github.com/savabush/taskTracker/internal/utils"
+	"github.com/savabush/taskTracker/pkg/tasktracker"
+	"github.com/spf13/cobra"
+)
+
+var (
+	scanCompleteMissing bool
+	scanDryRun          bool
+)
+
+var scanActionColors = map[string]*color.Color{
+	"add":      color.New(color.FgGreen),
+	"move":     color.New(color.FgYellow),
+	"complete": color.New(color.FgBlue),
+}
+
+var ScanCmd = &cobra.Command{
+	Use:   "scan [dir]",
+	Short: "Create tasks from TODO comments in source code",
+	Long: `scan is used to walk a directory tree, the current directory by default, and
+create a task for every TODO, FIXME and XXX comment in its source files.
+Files ignored by the .gitignore files of the tree are skipped.
+
+Each task is titled after the comment, described by its file and line, and
+tagged with "scan", the file type and the kind of comment. Comments are
+matched to their tasks by a fingerprint of the file, kind and text, so
+running scan again only updates the line of moved comments and adds new
+ones. With --complete-missing the open tasks of comments that are gone are
+marked as completed. Locations are relative to the current directory, so run
+scan from the same directory every time.`,
+
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		root := "."
+		if len(args) == 1 {
+			root = args[0]
+		}
+		comments, err := services.ScanComments(root)
+		if err != nil {
+			return err
+		}
+		slog.Debug("Scanned source files", "root", root, "comments", len(comments))
+
+		client, err := tasktracker.New()
+		if err != nil {
+			return err
+		}
+		var plan services.ScanPlan
+		err = client.Batch(commandContext(cmd), func(b *tasktracker.Batch) error {
+			tasks, err := b.List(tasktracker.ListOptions{})
+			if err != nil {
+				return err
+			}
+			plan = services.PlanScan(tasks, comments, root)
+			if !scanCompleteMissing {
+				plan.Missing = nil
+			}
+			if scanDryRun {
+				return nil
+			}
+			return applyScan(b, plan)
+		})
+		if err != nil {
+			return err
+		}
+
+		if scanDryRun {
+			return renderScanPlan(os.Stdout, plan)
+		}
+		slog.Info("Scanned comments", "found", len(comments), "added", len(plan.New), "moved", len(plan.Moved), "completed", len(plan.Missing))
+		return nil
+	},
+}
+
+func init() {
+	ScanCmd.Flags().BoolVar(&scanCompleteMissing, "complete-missing", false, "Complete the tasks of comments that are no longer found")
+	ScanCmd.Flags().BoolVar(&scanDryRun, "dry-run", false, "Show what would change without changing anything")
+}
+
+// applyScan adds, moves and completes the tasks of a scan plan.
+func applyScan(b *tasktracker.Batch, plan services.ScanPlan) error {
+	for _, task := range plan.New {
+		if _, err := b.Add(tasktracker.Task{Title: task.Title, Description: task.Description, Notes: task.Notes, Tags: task.Tags}); err != nil {
+			return err
+		}
+	}
+	for _, moved := range plan.Moved {
+		if _, err := b.Update(moved.ID, func(task *tasktracker.Task) { task.Description = moved.Description }); err != nil {
+			return err
+		}
+	}
+	for _, task := range plan.Missing {
+		if _, err := b.Complete(task.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// renderScanPlan previews the changes of a scan.
+func renderScanPlan(w io.Writer, plan services.ScanPlan) error {
+	table := utils.NewTable(w,
+		utils.Column{Header: "ACTION"},
+		utils.Column{Header: "LOCATION"},
+		utils.Column{Header: "TASK", Flexible: true},
+	)
+	add := func(action string, tasks []services.Task) {
+		for _, task := range tasks {
+			table.AddRow(
+				utils.Cell{Text: action, Color: scanActionColors[action]},
+				utils.Cell{Text: task.Description},
+				utils.Cell{Text: task.Title},
+			)
+		}
+	}
+	add("add", plan.New)
+	add("move", plan.Moved)
+	add("complete", plan.Missing)
+	return table.Render()
+}
@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/savabush/taskTracker/internal/services"
+	"github.com/spf13/cobra"
+)
+
+func TestScanCmd_Args(t *testing.T) {
+	if err := ScanCmd.Args(ScanCmd, []string{"a", "b"}); err == nil {
+		t.Errorf("Expected an error for two directories")
+	}
+	if err := ScanCmd.Args(ScanCmd, []string{}); err != nil {
+		t.Errorf("Args() error = %v without a directory", err)
+	}
+}
+
+func TestScanCmd_Run(t *testing.T) {
+	cleanup := createTempTaskFile(t)
+	defer cleanup()
+
+	dir := t.TempDir()
+	source := filepath.Join(dir, "main.go")
+	os.WriteFile(source, []byte("package main\n\n// TODO: handle errors\n// FIXME: slow\n"), 0644)
+
+	scan := func() map[string]services.Task {
+		t.Helper()
+		if err := ScanCmd.RunE(&cobra.Command{}, []string{dir}); err != nil {
+			t.Fatalf("RunE() error = %v", err)
+		}
+		return loadService(t).Tasks
+	}
+
+	tasks := scan()
+	if len(tasks) != 2 || !tasks["TODO: handle errors"].HasTag("go") {
+		t.Fatalf("Expected two tagged tasks, got %v", tasks)
+	}
+	if again := scan(); len(again) != 2 {
+		t.Errorf("Expected a second scan to add nothing, got %d tasks", len(again))
+	}
+
+	os.WriteFile(source, []byte("package main\n\n\n// TODO: handle errors\n"), 0644)
+	scanCompleteMissing = true
+	defer func() { scanCompleteMissing = false }()
+	tasks = scan()
+	if want := filepath.ToSlash(source) + ":4"; tasks["TODO: handle errors"].Description != want {
+		t.Errorf("Expected the task to move to %s, got %s", want, tasks["TODO: handle errors"].Description)
+	}
+	if tasks["FIXME: slow"].Status != services.TaskStatusCompleted {
+		t.Errorf("Expected the task of the removed comment to be completed, got %s", tasks["FIXME: slow"].Status)
+	}
+}
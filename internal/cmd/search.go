@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/savabush/taskTracker/internal/services"
+	"github.com/savabush/taskTracker/internal/utils"
+	"github.com/savabush/taskTracker/pkg/tasktracker"
+	"github.com/spf13/cobra"
+)
+
+var (
+	searchRegex  bool
+	searchFuzzy  bool
+	searchStatus string
+)
+
+var highlightColor = color.New(color.FgHiYellow, color.Bold)
+
+var SearchCmd = &cobra.Command{
+	Use:   "search [query]",
+	Short: "Search tasks",
+	Long:  `search is used to find tasks whose title, description or notes match the query. Matching is case-insensitive; use --regex for regular expressions or --fuzzy for subsequence matching.`,
+
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return errors.New("requires exactly one search query")
+		}
+		if len(strings.TrimSpace(args[0])) == 0 {
+			return errors.New("search query cannot be empty")
+		}
+		if searchStatus != "" && !services.TaskStatus(searchStatus).IsValid() {
+			return errors.New("status must be one of: pending, inProgress, completed")
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		mode := services.SearchSubstring
+		if searchRegex {
+			mode = services.SearchRegex
+		} else if searchFuzzy {
+			mode = services.SearchFuzzy
+		}
+		slog.Debug("Running search command", "query", args[0], "mode", mode, "status", searchStatus)
+
+		client, err := tasktracker.New()
+		if err != nil {
+			return err
+		}
+		tasks, err := client.List(commandContext(cmd), tasktracker.ListOptions{Filter: searchStatus})
+		if err != nil {
+			return err
+		}
+		results, err := services.SearchTasks(tasks, args[0], mode)
+		if err != nil {
+			return &usageError{err}
+		}
+		slog.Debug("Search finished", "count", len(results))
+
+		renderSearchResults(os.Stdout, results, utils.ColorEnabled(os.Stdout))
+		return nil
+	},
+}
+
+func init() {
+	SearchCmd.Flags().BoolVar(&searchRegex, "regex", false, "Treat the query as a regular expression")
+	SearchCmd.Flags().BoolVar(&searchFuzzy, "fuzzy", false, "Match the query characters in order, allowing gaps")
+	SearchCmd.Flags().StringVarP(&searchStatus, "status", "s", "", "Only search tasks with this status")
+	SearchCmd.MarkFlagsMutuallyExclusive("regex", "fuzzy")
+}
+
+func renderSearchResults(w io.Writer, results []services.SearchResult, useColor bool) {
+	for _, result := range results {
+		title := result.Task.Title
+		for _, match := range result.Matches {
+			if match.Field == services.FieldTitle {
+				title = highlight(match.Text, match.Spans, useColor)
+			}
+		}
+		status := string(result.Task.Status)
+		if useColor && statusColors[result.Task.Status] != nil {
+			status = utils.Colorize(statusColors[result.Task.Status], status)
+		}
+		fmt.Fprintf(w, "%s [%s]\n", title, status)
+
+		for _, match := range result.Matches {
+			switch match.Field {
+			case services.FieldDescription:
+				fmt.Fprintf(w, "    description: %s\n", highlight(match.Text, match.Spans, useColor))
+			case services.FieldNote:
+				fmt.Fprintf(w, "    note %d: %s\n", match.Index+1, highlight(match.Text, match.Spans, useColor))
+			}
+		}
+	}
+}
+
+// highlight colors the matched spans of text. Text is returned unchanged
+// when colors are disabled.
+func highlight(text string, spans []services.Span, useColor bool) string {
+	if !useColor {
+		return text
+	}
+	var b strings.Builder
+	last := 0
+	for _, span := range spans {
+		b.WriteString(text[last:span.Start])
+		b.WriteString(utils.Colorize(highlightColor, text[span.Start:span.End]))
+		last = span.End
+	}
+	b.WriteString(text[last:])
+	return b.String()
+}
@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/savabush/taskTracker/internal/services"
+	"github.com/spf13/cobra"
+)
+
+func TestSearchCmd_Args(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		status  string
+		wantErr bool
+	}{
+		{
+			name:    "No args",
+			args:    []string{},
+			wantErr: true,
+		},
+		{
+			name:    "Whitespace query",
+			args:    []string{"  "},
+			wantErr: true,
+		},
+		{
+			name:    "Valid query",
+			args:    []string{"report"},
+			wantErr: false,
+		},
+		{
+			name:    "Too many args",
+			args:    []string{"report", "extra"},
+			wantErr: true,
+		},
+		{
+			name:    "Valid status",
+			args:    []string{"report"},
+			status:  "completed",
+			wantErr: false,
+		},
+		{
+			name:    "Invalid status",
+			args:    []string{"report"},
+			status:  "done",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			searchStatus = tt.status
+			defer func() { searchStatus = "" }()
+
+			cmd := &cobra.Command{}
+			err := SearchCmd.Args(cmd, tt.args)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Args() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRenderSearchResults(t *testing.T) {
+	results := []services.SearchResult{
+		{
+			Task: services.Task{Title: "Write report", Status: services.TaskStatusPending},
+			Matches: []services.FieldMatch{
+				{Field: services.FieldTitle, Text: "Write report", Spans: []services.Span{{Start: 6, End: 12}}},
+				{Field: services.FieldNote, Index: 0, Text: "report due Friday", Spans: []services.Span{{Start: 0, End: 6}}},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	renderSearchResults(&buf, results, false)
+	output := buf.String()
+	if !strings.Contains(output, "Write report [pending]") {
+		t.Errorf("Expected plain title line, got: %q", output)
+	}
+	if !strings.Contains(output, "note 1: report due Friday") {
+		t.Errorf("Expected note line, got: %q", output)
+	}
+
+	buf.Reset()
+	renderSearchResults(&buf, results, true)
+	if !strings.Contains(buf.String(), "Write \x1b[") {
+		t.Errorf("Expected highlighted match in title, got: %q", buf.String())
+	}
+}
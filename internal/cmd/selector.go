@@ -0,0 +1,186 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/savabush/taskTracker/internal/services"
+	"github.com/savabush/taskTracker/pkg/tasktracker"
+	"github.com/spf13/cobra"
+)
+
+// selector picks the tasks of a bulk operation from flags, in addition to
+// the tasks named as arguments.
+type selector struct {
+	filter    string
+	status    string
+	olderThan string
+	ids       []string
+	yes       bool
+	dryRun    bool
+}
+
+func (s *selector) addFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&s.filter, "filter", "", "Select the tasks matching a filter, as accepted by list")
+	cmd.Flags().StringVar(&s.status, "status", "", "Select the tasks with a status: pending, inProgress or completed")
+	cmd.Flags().StringVar(&s.olderThan, "older-than", "", "Select the tasks not updated for a duration like 12h or 90d")
+	cmd.Flags().StringSliceVar(&s.ids, "ids", nil, "Select tasks by ID, ID prefix as shown by list, or title (comma-separated or repeatable)")
+	cmd.Flags().BoolVarP(&s.yes, "yes", "y", false, "Change the selected tasks without asking")
+	cmd.Flags().BoolVar(&s.dryRun, "dry-run", false, "Show the selected tasks without changing them")
+}
+
+// active reports whether the command runs as a bulk operation: tasks are
+// selected by flags, or the selection is only to be shown.
+func (s *selector) active() bool {
+	return s.filter != "" || s.status != "" || s.olderThan != "" || len(s.ids) > 0 || s.dryRun
+}
+
+// validate checks the selector flags.
+func (s *selector) validate() error {
+	if _, err := parseFilter(s.filter); err != nil {
+		return err
+	}
+	if s.status != "" && !services.TaskStatus(s.status).IsValid() {
+		return errors.New("status must be one of: pending, inProgress, completed")
+	}
+	if s.olderThan != "" {
+		if _, err := parseWindow(s.olderThan); err != nil {
+			return errors.New("older-than must be a duration like 12h or 90d")
+		}
+	}
+	return nil
+}
+
+// selectTasks returns the tasks named by keys or --ids that match the other
+// selector flags, or all tasks matching them when no task is named.
+func (s *selector) selectTasks(ctx context.Context, client *tasktracker.Client, keys []string, now time.Time) ([]tasktracker.Task, error) {
+	keys = append(slices.Clone(keys), s.ids...)
+	var cutoff time.Time
+	if s.olderThan != "" {
+		age, _ := parseWindow(s.olderThan)
+		cutoff = now.Add(-age)
+	}
+
+	var tasks []tasktracker.Task
+	err := client.Batch(ctx, func(b *tasktracker.Batch) error {
+		matching, err := b.List(tasktracker.ListOptions{Filter: s.filter})
+		if err != nil {
+			return err
+		}
+		if len(keys) > 0 {
+			named := make([]tasktracker.Task, 0, len(keys))
+			for _, key := range keys {
+				task, err := b.Get(key)
+				if err != nil {
+					return err
+				}
+				if !slices.ContainsFunc(named, func(t tasktracker.Task) bool { return t.ID == task.ID }) &&
+					slices.ContainsFunc(matching, func(t tasktracker.Task) bool { return t.ID == task.ID }) {
+					named = append(named, task)
+				}
+			}
+			matching = named
+		}
+		for _, task := range matching {
+			if s.status != "" && task.Status != tasktracker.TaskStatus(s.status) {
+				continue
+			}
+			if !cutoff.IsZero() && !task.UpdatedAt.Before(cutoff) {
+				continue
+			}
+			tasks = append(tasks, task)
+		}
+		return nil
+	})
+	return tasks, err
+}
+
+// confirm shows the selected tasks and asks whether to go on with action,
+// unless --yes is set. With --dry-run it only shows them.
+func (s *selector) confirm(cmd *cobra.Command, tasks []tasktracker.Task, action string) (bool, error) {
+	if len(tasks) == 0 {
+		slog.Info("No tasks selected")
+		return false, nil
+	}
+	out := cmd.OutOrStdout()
+	if err := renderTasks(out, tasks, outputTable); err != nil {
+		return false, err
+	}
+	if s.dryRun {
+		slog.Info("Dry run, no tasks changed", "count", len(tasks))
+		return false, nil
+	}
+	if s.yes {
+		return true, nil
+	}
+
+	noun := "tasks"
+	if len(tasks) == 1 {
+		noun = "task"
+	}
+	fmt.Fprintf(out, "%s %d %s? [y/N] ", action, len(tasks), noun)
+	ok, err := readYes(cmd.InOrStdin())
+	if err != nil {
+		return false, err
+	}
+	if !ok {
+		slog.Info("Cancelled, no tasks changed")
+	}
+	return ok, nil
+}
+
+// readYes reads an answer to a yes/no question. Anything but y or yes,
+// including the end of input, is no.
+func readYes(in io.Reader) (bool, error) {
+	line, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return false, err
+	}
+	answer := strings.ToLower(strings.TrimSpace(line))
+	return answer == "y" || answer == "yes", nil
+}
+
+// run applies op to the selected tasks that keep accepts, after confirming
+// action, and reports the outcome like runEach. A nil keep accepts all tasks.
+func (s *selector) run(cmd *cobra.Command, keys []string, opts eachOptions, action, done, message string, keep func(task tasktracker.Task) bool, op func(batch *tasktracker.Batch, title string) error) error {
+	if err := s.validate(); err != nil {
+		return &usageError{err}
+	}
+	client, err := tasktracker.New()
+	if err != nil {
+		return err
+	}
+	tasks, err := s.selectTasks(commandContext(cmd), client, keys, time.Now())
+	if err != nil {
+		return err
+	}
+	if keep != nil {
+		tasks = slices.DeleteFunc(tasks, func(task tasktracker.Task) bool { return !keep(task) })
+	}
+
+	ok, err := s.confirm(cmd, tasks, action)
+	if err != nil || !ok {
+		return err
+	}
+	result, err := runEach(cmd, titles(tasks), opts, op)
+	if err != nil {
+		return err
+	}
+	return result.report(done, message)
+}
+
+// titles returns the titles of tasks.
+func titles(tasks []tasktracker.Task) []string {
+	result := make([]string, len(tasks))
+	for i, task := range tasks {
+		result[i] = task.Title
+	}
+	return result
+}
@@ -0,0 +1,143 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/savabush/taskTracker/internal/services"
+	"github.com/savabush/taskTracker/pkg/tasktracker"
+	"github.com/spf13/cobra"
+)
+
+// seedSelectorTasks stores tasks a to d: a and b completed, a and c tagged
+// sprint, and a not updated for 100 days.
+func seedSelectorTasks(t *testing.T, now time.Time) {
+	t.Helper()
+	service := loadService(t)
+	clear(service.Tasks)
+	for _, title := range []string{"a", "b", "c", "d"} {
+		service.AddTask(title)
+	}
+	service.CompleteTask("a")
+	service.CompleteTask("b")
+	service.UpdateTask("a", func(task *services.Task) { task.Tags = []string{"sprint"} })
+	service.UpdateTask("c", func(task *services.Task) { task.Tags = []string{"sprint"} })
+	a := service.Tasks["a"]
+	a.UpdatedAt = now.AddDate(0, 0, -100)
+	service.Tasks["a"] = a
+	if err := service.SaveTasks(); err != nil {
+		t.Fatalf("SaveTasks() error = %v", err)
+	}
+}
+
+func TestSelector_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		sel     selector
+		wantErr bool
+	}{
+		{name: "Empty", sel: selector{}},
+		{name: "All flags", sel: selector{filter: "tag:work", status: "completed", olderThan: "90d"}},
+		{name: "Bad filter", sel: selector{filter: "due<soon"}, wantErr: true},
+		{name: "Bad status", sel: selector{status: "done"}, wantErr: true},
+		{name: "Bad age", sel: selector{olderThan: "ninety days"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.sel.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSelector_SelectTasks(t *testing.T) {
+	cleanup := createTempTaskFile(t)
+	defer cleanup()
+
+	now := time.Now()
+	seedSelectorTasks(t, now)
+	client, err := tasktracker.New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		sel  selector
+		keys []string
+		want []string
+	}{
+		{name: "Filter", sel: selector{filter: "tag:sprint"}, want: []string{"a", "c"}},
+		{name: "Status and age", sel: selector{status: "completed", olderThan: "90d"}, want: []string{"a"}},
+		{name: "Status", sel: selector{status: "completed"}, want: []string{"a", "b"}},
+		{name: "Named tasks", keys: []string{"d", "b", "d"}, want: []string{"d", "b"}},
+		{name: "Named and filtered", sel: selector{filter: "tag:sprint"}, keys: []string{"a", "b"}, want: []string{"a"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tasks, err := tt.sel.selectTasks(t.Context(), client, tt.keys, now)
+			if err != nil {
+				t.Fatalf("selectTasks() error = %v", err)
+			}
+			got := titles(tasks)
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("selectTasks() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("Unknown ID", func(t *testing.T) {
+		sel := selector{ids: []string{"missing"}}
+		if _, err := sel.selectTasks(t.Context(), client, nil, now); !errors.Is(err, services.ErrTaskNotFound) {
+			t.Errorf("selectTasks() error = %v, want ErrTaskNotFound", err)
+		}
+	})
+}
+
+func TestSelector_Confirm(t *testing.T) {
+	tasks := []tasktracker.Task{{Title: "a", Status: tasktracker.StatusPending}}
+	tests := []struct {
+		name       string
+		sel        selector
+		tasks      []tasktracker.Task
+		input      string
+		want       bool
+		wantPrompt bool
+	}{
+		{name: "No tasks", tasks: nil},
+		{name: "Yes flag", sel: selector{yes: true}, tasks: tasks, want: true},
+		{name: "Dry run", sel: selector{dryRun: true, yes: true}, tasks: tasks},
+		{name: "Answer yes", tasks: tasks, input: "Y\n", want: true, wantPrompt: true},
+		{name: "Answer no", tasks: tasks, input: "n\n", wantPrompt: true},
+		{name: "No answer", tasks: tasks, wantPrompt: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			cmd := &cobra.Command{}
+			cmd.SetOut(&out)
+			cmd.SetIn(strings.NewReader(tt.input))
+
+			got, err := tt.sel.confirm(cmd, tt.tasks, "Delete")
+			if err != nil {
+				t.Fatalf("confirm() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("confirm() = %v, want %v", got, tt.want)
+			}
+			if prompted := strings.Contains(out.String(), "Delete 1 task? [y/N]"); prompted != tt.wantPrompt {
+				t.Errorf("Prompted = %v, want %v, output %q", prompted, tt.wantPrompt, out.String())
+			}
+			if len(tt.tasks) > 0 && !strings.Contains(out.String(), "a") {
+				t.Errorf("Expected the selected tasks to be shown, got %q", out.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/savabush/taskTracker/internal/api"
+	"github.com/savabush/taskTracker/internal/grpcserver"
+	pb "github.com/savabush/taskTracker/pkg/tasktrackerpb"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+)
+
+var (
+	serveAddr       string
+	serveGRPCAddr   string
+	serveAllowHosts []string
+)
+
+var ServeCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve tasks over a JSON REST API",
+	Long: `serve is used to expose tasks over a JSON REST API so that dashboards and bots can use the same data as the CLI. It serves until interrupted.
+
+  GET    /api/tasks?filter=...&sort=...  list tasks, filtered and sorted as in list
+  POST   /api/tasks                      create a task
+  GET    /api/tasks/{task}               get a task by ID or title
+  PATCH  /api/tasks/{task}               change the given fields
+  DELETE /api/tasks/{task}               delete a task
+  POST   /api/tasks/{task}/start         mark a task as in progress
+  POST   /api/tasks/{task}/complete      mark a task as completed
+  POST   /api/tasks/{task}/reopen        mark a task as pending again
+
+Tasks are created and updated with JSON objects of title, description, notes, tags, priority, due (YYYY-MM-DD), recurrence and estimate. Errors are returned as {"error": "..."} with a 400, 404 or 409 status.
+
+A Kanban board for the same tasks is served at /, without any external assets.
+
+Requests from other web sites are refused with a 403 status: the Host header must be localhost, an IP address, the host of --addr or a name given with --allow-host, and an Origin header must match it. Request bodies must be sent with Content-Type: application/json.
+
+With --grpc-addr the gRPC API of proto/tasktracker/v1/tasktracker.proto is served as well, including WatchTasks for change notifications. Go programs can use it through the pkg/taskclient package.`,
+
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) > 0 {
+			return errors.New("serve takes no arguments")
+		}
+		if _, _, err := net.SplitHostPort(serveAddr); err != nil {
+			return errors.New("address must be host:port, e.g. localhost:8080")
+		}
+		if serveGRPCAddr != "" {
+			if _, _, err := net.SplitHostPort(serveGRPCAddr); err != nil {
+				return errors.New("gRPC address must be host:port, e.g. localhost:9090")
+			}
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		listener, err := net.Listen("tcp", serveAddr)
+		if err != nil {
+			return err
+		}
+		handler := api.NewServer()
+		handler.AllowedHosts = serveAllowHosts
+		if host, _, _ := net.SplitHostPort(serveAddr); host != "" {
+			handler.AllowedHosts = append(handler.AllowedHosts, host)
+		}
+		server := &http.Server{Handler: handler, ReadHeaderTimeout: 10 * time.Second}
+
+		var grpcServer *grpc.Server
+		if serveGRPCAddr != "" {
+			grpcListener, err := net.Listen("tcp", serveGRPCAddr)
+			if err != nil {
+				listener.Close()
+				return err
+			}
+			// Both servers load and save the same task store
+			service := grpcserver.NewServer()
+			service.Locker = handler
+			grpcServer = grpc.NewServer()
+			pb.RegisterTaskTrackerServer(grpcServer, service)
+			go func() {
+				if err := grpcServer.Serve(grpcListener); err != nil {
+					slog.Error("gRPC server failed", "error", err)
+				}
+			}()
+			slog.Info("Serving gRPC API", "addr", grpcListener.Addr().String())
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		go func() {
+			<-ctx.Done()
+			shutdown, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if grpcServer != nil {
+				stopGRPC(shutdown, grpcServer)
+			}
+			server.Shutdown(shutdown)
+		}()
+
+		slog.Info("Serving API", "addr", "http://"+listener.Addr().String())
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		slog.Info("Server stopped")
+		return nil
+	},
+}
+
+// stopGRPC waits for pending requests until ctx is done and then closes the
+// remaining streams, such as WatchTasks.
+func stopGRPC(ctx context.Context, server *grpc.Server) {
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		server.Stop()
+	}
+}
+
+func init() {
+	ServeCmd.Flags().StringVar(&serveAddr, "addr", "localhost:8080", "Address to listen on")
+	ServeCmd.Flags().StringArrayVar(&serveAllowHosts, "allow-host", nil, "Host name the server may be reached under, besides localhost and IP addresses (repeatable)")
+	ServeCmd.Flags().StringVar(&serveGRPCAddr, "grpc-addr", "", "Address to serve the gRPC API on, e.g. localhost:9090 (disabled by default)")
+}
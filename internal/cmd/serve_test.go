@@ -0,0 +1,32 @@
+package cmd
+
+import "testing"
+
+func TestServeCmd_Args(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		addr     string
+		grpcAddr string
+		wantErr  bool
+	}{
+		{name: "Default address", args: []string{}, addr: "localhost:8080", wantErr: false},
+		{name: "All interfaces", args: []string{}, addr: ":9000", wantErr: false},
+		{name: "Missing port", args: []string{}, addr: "localhost", wantErr: true},
+		{name: "Extra args", args: []string{"now"}, addr: "localhost:8080", wantErr: true},
+		{name: "gRPC address", args: []string{}, addr: "localhost:8080", grpcAddr: "localhost:9090", wantErr: false},
+		{name: "Invalid gRPC address", args: []string{}, addr: "localhost:8080", grpcAddr: "9090", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serveAddr, serveGRPCAddr = tt.addr, tt.grpcAddr
+			defer func() { serveAddr, serveGRPCAddr = "localhost:8080", "" }()
+
+			err := ServeCmd.Args(ServeCmd, tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Args() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
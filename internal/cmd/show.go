@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/savabush/taskTracker/internal/services"
+	"github.com/savabush/taskTracker/internal/utils"
+	"github.com/savabush/taskTracker/pkg/tasktracker"
+	"github.com/spf13/cobra"
+)
+
+// showOccurrences is the number of upcoming occurrences shown for recurring
+// tasks.
+const showOccurrences = 5
+
+var ShowCmd = &cobra.Command{
+	Use:   "show [task]",
+	Short: "Show task details",
+	Long:  `show is used to display all details of a task. For recurring tasks the next few occurrences are listed as well.`,
+
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return errors.New("requires exactly one task")
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := tasktracker.New()
+		if err != nil {
+			return err
+		}
+		task, err := client.Get(commandContext(cmd), args[0])
+		if err != nil {
+			return err
+		}
+		renderTask(os.Stdout, task, utils.ColorEnabled(os.Stdout), time.Now())
+		return nil
+	},
+}
+
+func renderTask(w io.Writer, task services.Task, useColor bool, now time.Time) {
+	field := func(name, value string) {
+		if value != "" {
+			fmt.Fprintf(w, "%-13s %s\n", name+":", value)
+		}
+	}
+	date := func(t time.Time, layout string) string {
+		if t.IsZero() {
+			return ""
+		}
+		return t.Format(layout)
+	}
+
+	status := string(task.Status)
+	if useColor && statusColors[task.Status] != nil {
+		status = utils.Colorize(statusColors[task.Status], status)
+	}
+	due := date(task.Due, services.DateLayout)
+	if due != "" && task.IsOverdue(now) {
+		due += " (overdue)"
+	}
+
+	field("Title", task.Title)
+	field("ID", task.ID)
+	field("Status", status)
+	field("Priority", string(task.Priority))
+	field("Due", due)
+	field("Tags", strings.Join(task.Tags, ", "))
+	field("Recurrence", task.Recurrence)
+	field("Estimate", task.Estimate.String())
+	if len(task.TimeEntries) > 0 {
+		field("Logged", formatDuration(task.LoggedTime(now)))
+	}
+	field("Created", date(task.CreatedAt, "2006-01-02 15:04:05"))
+	field("Updated", date(task.UpdatedAt, "2006-01-02 15:04:05"))
+	field("Started", date(task.StartedAt, "2006-01-02 15:04:05"))
+	field("Completed", date(task.CompletedAt, "2006-01-02 15:04:05"))
+	field("Description", task.Description)
+	for i, note := range task.Notes {
+		field(fmt.Sprintf("Note %d", i+1), note)
+	}
+
+	if task.Recurrence == "" {
+		return
+	}
+	rule, err := services.ParseRecurrence(task.Recurrence)
+	if err != nil {
+		fmt.Fprintf(w, "Invalid recurrence: %v\n", err)
+		return
+	}
+	from := task.Due
+	if from.IsZero() {
+		from = now
+	}
+	occurrences := rule.Occurrences(from, showOccurrences)
+	if len(occurrences) == 0 {
+		return
+	}
+	fmt.Fprintln(w, "Next occurrences:")
+	for _, occurrence := range occurrences {
+		fmt.Fprintf(w, "  %s\n", occurrence.Format("Mon 2006-01-02"))
+	}
+}
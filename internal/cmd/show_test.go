@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/savabush/taskTracker/internal/services"
+	"github.com/spf13/cobra"
+)
+
+func TestShowCmd_Args(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr bool
+	}{
+		{name: "No args", args: []string{}, wantErr: true},
+		{name: "Valid task", args: []string{"Task1"}, wantErr: false},
+		{name: "Too many args", args: []string{"Task1", "Task2"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ShowCmd.Args(&cobra.Command{}, tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Args() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRenderTask(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.Local)
+	task := services.Task{
+		Title:      "Release notes",
+		Status:     services.TaskStatusPending,
+		Tags:       []string{"chore", "docs"},
+		Due:        time.Date(2026, 10, 16, 0, 0, 0, 0, time.Local),
+		Recurrence: "FREQ=WEEKLY;BYDAY=FR",
+		Notes:      []string{"Include changelog"},
+	}
+
+	var buf bytes.Buffer
+	renderTask(&buf, task, false, now)
+	output := buf.String()
+
+	for _, want := range []string{
+		"Title:        Release notes",
+		"Due:          2026-10-16 (overdue)",
+		"Tags:         chore, docs",
+		"Note 1:       Include changelog",
+		"Next occurrences:\n  Fri 2026-10-23\n  Fri 2026-10-30",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+	if strings.Contains(output, "Completed:") {
+		t.Errorf("Expected empty fields to be omitted, got:\n%s", output)
+	}
+}
+
+func TestShowCmd_Run(t *testing.T) {
+	var logBuf bytes.Buffer
+	oldLogger := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&logBuf, nil)))
+	defer slog.SetDefault(oldLogger)
+
+	cleanup := createTempTaskFile(t)
+	defer cleanup()
+
+	service := loadService(t)
+	service.AddTask("Task1")
+	service.SaveTasks()
+
+	output := captureStdout(t, func() {
+		ShowCmd.RunE(&cobra.Command{}, []string{"Task1"})
+	})
+	if !strings.Contains(output, "Task1") || !strings.Contains(output, "pending") {
+		t.Errorf("Expected task details, got: %s", output)
+	}
+
+	if err := ShowCmd.RunE(&cobra.Command{}, []string{"Missing"}); !errors.Is(err, services.ErrTaskNotFound) {
+		t.Errorf("Expected ErrTaskNotFound, got: %v", err)
+	}
+}
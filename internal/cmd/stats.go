@@ -0,0 +1,207 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/savabush/taskTracker/internal/services"
+	"github.com/savabush/taskTracker/internal/utils"
+	"github.com/savabush/taskTracker/pkg/tasktracker"
+	"github.com/spf13/cobra"
+)
+
+var (
+	statsSince  string
+	statsUntil  string
+	statsPeriod string
+	statsOldest int
+	statsOutput string
+)
+
+var StatsCmd = &cobra.Command{
+	Use:   "stats [filter]",
+	Short: "Show task statistics",
+	Long:  `stats is used to report task counts per status and tag, tasks created and completed per day or week, average lead time (created to completed), average cycle time (in progress to completed) and the oldest open tasks. An optional filter uses the same syntax as list.`,
+
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) > 1 {
+			return errors.New("filter must be a single argument; quote expressions containing spaces")
+		}
+		if len(args) == 1 {
+			if _, err := parseFilter(args[0]); err != nil {
+				return err
+			}
+		}
+		if _, err := parseStatsOptions(); err != nil {
+			return err
+		}
+		if statsOutput != outputTable && statsOutput != outputJSON {
+			return errors.New("output must be one of: table, json")
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		slog.Debug("Running stats command", "since", statsSince, "until", statsUntil, "period", statsPeriod)
+		filter := ""
+		if len(args) == 1 {
+			filter = args[0]
+		}
+		if _, err := parseFilter(filter); err != nil {
+			return &usageError{err}
+		}
+		opts, err := parseStatsOptions()
+		if err != nil {
+			return &usageError{err}
+		}
+
+		client, err := tasktracker.New()
+		if err != nil {
+			return err
+		}
+		tasks, err := client.List(commandContext(cmd), tasktracker.ListOptions{Filter: filter})
+		if err != nil {
+			return err
+		}
+		stats, err := services.ComputeStats(tasks, opts)
+		if err != nil {
+			return &usageError{err}
+		}
+
+		if statsOutput == outputJSON {
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			return encoder.Encode(stats)
+		}
+		return renderStats(os.Stdout, stats)
+	},
+}
+
+func init() {
+	StatsCmd.Flags().StringVar(&statsSince, "since", "", "Only include activity on or after this date (YYYY-MM-DD)")
+	StatsCmd.Flags().StringVar(&statsUntil, "until", "", "Only include activity on or before this date (YYYY-MM-DD)")
+	StatsCmd.Flags().StringVar(&statsPeriod, "period", services.PeriodDay, "Bucket created/completed counts per day or week")
+	StatsCmd.Flags().IntVar(&statsOldest, "oldest", 5, "Number of oldest open tasks to show")
+	StatsCmd.Flags().StringVarP(&statsOutput, "output", "o", outputTable, "Output format: table, json")
+}
+
+func parseStatsOptions() (services.StatsOptions, error) {
+	opts := services.StatsOptions{Period: statsPeriod, Oldest: statsOldest}
+	now := time.Now()
+	if statsSince != "" {
+		since, err := services.ParseDate(statsSince, now)
+		if err != nil {
+			return opts, errors.New("since must be a date in YYYY-MM-DD format")
+		}
+		opts.Since = since
+	}
+	if statsUntil != "" {
+		until, err := services.ParseDate(statsUntil, now)
+		if err != nil {
+			return opts, errors.New("until must be a date in YYYY-MM-DD format")
+		}
+		// The until date is inclusive
+		opts.Until = until.AddDate(0, 0, 1)
+	}
+	if opts.Period != services.PeriodDay && opts.Period != services.PeriodWeek {
+		return opts, errors.New("period must be one of: day, week")
+	}
+	if opts.Oldest < 0 {
+		return opts, errors.New("oldest must not be negative")
+	}
+	return opts, nil
+}
+
+func renderStats(w io.Writer, stats services.Stats) error {
+	fmt.Fprintf(w, "Tasks: %d\n", stats.Total)
+	fmt.Fprintf(w, "Average lead time: %s\n", formatDuration(stats.AverageLeadTime))
+	fmt.Fprintf(w, "Average cycle time: %s\n", formatDuration(stats.AverageCycleTime))
+	if stats.Effort.HasData() {
+		fmt.Fprintln(w, formatEffort(stats.Effort))
+	}
+	fmt.Fprintln(w)
+
+	statuses := utils.NewTable(w, utils.Column{Header: "STATUS"}, utils.Column{Header: "COUNT"})
+	for _, status := range []services.TaskStatus{services.TaskStatusPending, services.TaskStatusInProgress, services.TaskStatusCompleted} {
+		statuses.AddRow(
+			utils.Cell{Text: string(status), Color: statusColors[status]},
+			utils.Cell{Text: strconv.Itoa(stats.ByStatus[status])},
+		)
+	}
+	if err := statuses.Render(); err != nil {
+		return err
+	}
+
+	if len(stats.ByTag) > 0 {
+		fmt.Fprintln(w)
+		tags := make([]string, 0, len(stats.ByTag))
+		for tag := range stats.ByTag {
+			tags = append(tags, tag)
+		}
+		sort.Strings(tags)
+		table := utils.NewTable(w, utils.Column{Header: "TAG"}, utils.Column{Header: "COUNT"})
+		for _, tag := range tags {
+			table.AddRow(utils.Cell{Text: tag}, utils.Cell{Text: strconv.Itoa(stats.ByTag[tag])})
+		}
+		if err := table.Render(); err != nil {
+			return err
+		}
+	}
+
+	if len(stats.Throughput) > 0 {
+		fmt.Fprintln(w)
+		table := utils.NewTable(w, utils.Column{Header: "PERIOD"}, utils.Column{Header: "CREATED"}, utils.Column{Header: "COMPLETED"})
+		for _, count := range stats.Throughput {
+			table.AddRow(
+				utils.Cell{Text: count.Period},
+				utils.Cell{Text: strconv.Itoa(count.Created)},
+				utils.Cell{Text: strconv.Itoa(count.Completed)},
+			)
+		}
+		if err := table.Render(); err != nil {
+			return err
+		}
+	}
+
+	if len(stats.OldestOpen) > 0 {
+		fmt.Fprintln(w)
+		now := time.Now()
+		table := utils.NewTable(w, utils.Column{Header: "AGE"}, utils.Column{Header: "STATUS"}, utils.Column{Header: "OLDEST OPEN", Flexible: true})
+		for _, task := range stats.OldestOpen {
+			table.AddRow(
+				utils.Cell{Text: formatDuration(now.Sub(task.CreatedAt))},
+				utils.Cell{Text: string(task.Status), Color: statusColors[task.Status]},
+				utils.Cell{Text: task.Title},
+			)
+		}
+		if err := table.Render(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// formatDuration renders d with its two most significant units, e.g. "3d 4h".
+func formatDuration(d time.Duration) string {
+	if d <= 0 {
+		return "-"
+	}
+	days := int(d / (24 * time.Hour))
+	hours := int(d % (24 * time.Hour) / time.Hour)
+	minutes := int(d % time.Hour / time.Minute)
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%dd %dh", days, hours)
+	case hours > 0:
+		return fmt.Sprintf("%dh %dm", hours, minutes)
+	case minutes > 0:
+		return fmt.Sprintf("%dm", minutes)
+	}
+	return "<1m"
+}
@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/savabush/taskTracker/internal/services"
+	"github.com/spf13/cobra"
+)
+
+func TestStatsCmd_Args(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		since   string
+		period  string
+		output  string
+		wantErr bool
+	}{
+		{name: "No args", args: []string{}, wantErr: false},
+		{name: "Filter", args: []string{"tag:backend"}, wantErr: false},
+		{name: "Invalid filter", args: []string{"tag:"}, wantErr: true},
+		{name: "Valid since", args: []string{}, since: "2026-10-01", wantErr: false},
+		{name: "Invalid since", args: []string{}, since: "October", wantErr: true},
+		{name: "Weekly", args: []string{}, period: "week", wantErr: false},
+		{name: "Invalid period", args: []string{}, period: "month", wantErr: true},
+		{name: "JSON output", args: []string{}, output: "json", wantErr: false},
+		{name: "Invalid output", args: []string{}, output: "csv", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statsSince, statsPeriod, statsOutput = tt.since, tt.period, tt.output
+			if statsPeriod == "" {
+				statsPeriod = services.PeriodDay
+			}
+			if statsOutput == "" {
+				statsOutput = outputTable
+			}
+			defer func() { statsSince, statsPeriod, statsOutput = "", services.PeriodDay, outputTable }()
+
+			err := StatsCmd.Args(&cobra.Command{}, tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Args() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestStatsCmd_RunJSON(t *testing.T) {
+	var logBuf bytes.Buffer
+	oldLogger := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&logBuf, nil)))
+	defer slog.SetDefault(oldLogger)
+
+	cleanup := createTempTaskFile(t)
+	defer cleanup()
+
+	service := loadService(t)
+	service.AddTask("Open")
+	service.AddTask("Done")
+	service.CompleteTask("Done")
+	service.SaveTasks()
+
+	statsOutput = outputJSON
+	defer func() { statsOutput = outputTable }()
+
+	output := captureStdout(t, func() {
+		StatsCmd.RunE(&cobra.Command{}, nil)
+	})
+
+	var stats services.Stats
+	if err := json.Unmarshal([]byte(output), &stats); err != nil {
+		t.Fatalf("Expected JSON output, got %q: %v", output, err)
+	}
+	if stats.Total != 2 || stats.ByStatus[services.TaskStatusCompleted] != 1 {
+		t.Errorf("Unexpected stats: %+v", stats)
+	}
+	if len(stats.OldestOpen) != 1 || stats.OldestOpen[0].Title != "Open" {
+		t.Errorf("Expected 'Open' as oldest open task, got %+v", stats.OldestOpen)
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{d: 0, want: "-"},
+		{d: 30 * time.Second, want: "<1m"},
+		{d: 45 * time.Minute, want: "45m"},
+		{d: 5*time.Hour + 12*time.Minute, want: "5h 12m"},
+		{d: 76 * time.Hour, want: "3d 4h"},
+	}
+
+	for _, tt := range tests {
+		if got := formatDuration(tt.d); got != tt.want {
+			t.Errorf("formatDuration(%v) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
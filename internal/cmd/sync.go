@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"github.com/savabush/taskTracker/internal/services"
+	"github.com/spf13/cobra"
+)
+
+var SyncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Sync tasks through a git repository",
+	Long: `sync is used to keep the directory holding the task store in a git repository and exchange it with a remote, such as a bare repository on a shared drive.
+
+Once initialized, every command that changes tasks commits the files of the task store, and nothing else, with a message describing it. Concurrent changes to tasks.json are merged per task, keeping the most recently updated version, instead of producing conflicts.`,
+}
+
+var SyncInitCmd = &cobra.Command{
+	Use:   "init [remote]",
+	Short: "Start syncing the task store with git",
+	Long:  `init turns the directory holding the task store into a git repository and commits the current tasks. The optional remote is a URL or path set as origin; a local path that does not exist yet is created as a bare repository. Running init again changes the remote.`,
+
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		remote := ""
+		if len(args) == 1 {
+			remote = args[0]
+		}
+		gitSync := services.NewGitSync(services.GetStorage())
+		if err := gitSync.Init(remote); err != nil {
+			return fmt.Errorf("failed to initialize sync: %w", err)
+		}
+		slog.Info("Initialized sync", "dir", gitSync.Dir, "remote", remote)
+		return nil
+	},
+}
+
+var SyncPullCmd = &cobra.Command{
+	Use:   "pull",
+	Short: "Merge task changes from the remote",
+	Long:  `pull commits local changes, fetches the remote and merges its changes into the task store.`,
+
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		gitSync, err := enabledGitSync()
+		if err != nil {
+			return err
+		}
+		changed, err := gitSync.Pull(services.SyncRemote)
+		if err != nil {
+			return fmt.Errorf("failed to pull tasks: %w", err)
+		}
+		if !changed {
+			slog.Info("Tasks are up to date")
+			return nil
+		}
+		slog.Info("Pulled tasks", "remote", services.SyncRemote)
+		return nil
+	},
+}
+
+var SyncPushCmd = &cobra.Command{
+	Use:   "push",
+	Short: "Send task changes to the remote",
+	Long:  `push commits local changes and sends them to the remote. Pull first if the remote has changes that are not merged yet.`,
+
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		gitSync, err := enabledGitSync()
+		if err != nil {
+			return err
+		}
+		if _, err := gitSync.Commit("Record local changes before push"); err != nil {
+			return fmt.Errorf("failed to commit tasks: %w", err)
+		}
+		if err := gitSync.Push(services.SyncRemote); err != nil {
+			return fmt.Errorf("failed to push tasks: %w", err)
+		}
+		slog.Info("Pushed tasks", "remote", services.SyncRemote)
+		return nil
+	},
+}
+
+func init() {
+	SyncCmd.AddCommand(SyncInitCmd, SyncPullCmd, SyncPushCmd)
+}
+
+func enabledGitSync() (services.GitSync, error) {
+	gitSync := services.NewGitSync(services.GetStorage())
+	if !gitSync.Enabled() {
+		return gitSync, errors.New("sync is not initialized; run sync init")
+	}
+	return gitSync, nil
+}
+
+// AutoCommit commits the changes made by a command when the task store is
+// synced with git. The sync commands manage their own commits.
+func AutoCommit(cmd *cobra.Command, args []string) {
+	if cmd == SyncCmd || cmd.Parent() == SyncCmd {
+		return
+	}
+	gitSync := services.NewGitSync(services.GetStorage())
+	if !gitSync.Enabled() {
+		return
+	}
+	committed, err := gitSync.Commit(commitMessage(cmd, args))
+	if err != nil {
+		slog.Error("Failed to commit tasks", "error", err)
+		return
+	}
+	if committed {
+		slog.Debug("Committed tasks", "message", commitMessage(cmd, args))
+	}
+}
+
+// commitMessage describes a command line, e.g. `mark-completed "Buy milk"`.
+func commitMessage(cmd *cobra.Command, args []string) string {
+	words := []string{strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")}
+	for _, arg := range args {
+		if strings.ContainsAny(arg, " \t\n\"'") || arg == "" {
+			arg = strconv.Quote(arg)
+		}
+		words = append(words, arg)
+	}
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"bytes"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/savabush/taskTracker/internal/services"
+	"github.com/spf13/cobra"
+)
+
+func TestSyncCmd_Args(t *testing.T) {
+	tests := []struct {
+		name    string
+		cmd     *cobra.Command
+		args    []string
+		wantErr bool
+	}{
+		{name: "Init without remote", cmd: SyncInitCmd, args: []string{}, wantErr: false},
+		{name: "Init with remote", cmd: SyncInitCmd, args: []string{"../tasks.git"}, wantErr: false},
+		{name: "Init with two remotes", cmd: SyncInitCmd, args: []string{"a", "b"}, wantErr: true},
+		{name: "Pull", cmd: SyncPullCmd, args: []string{}, wantErr: false},
+		{name: "Pull with args", cmd: SyncPullCmd, args: []string{"origin"}, wantErr: true},
+		{name: "Push with args", cmd: SyncPushCmd, args: []string{"origin"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cmd.Args(tt.cmd, tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Args() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCommitMessage(t *testing.T) {
+	root := &cobra.Command{Use: "taskTracker"}
+	view := &cobra.Command{Use: "view"}
+	save := &cobra.Command{Use: "save"}
+	add := &cobra.Command{Use: "add"}
+	root.AddCommand(view, add)
+	view.AddCommand(save)
+
+	if got := commitMessage(add, []string{"Buy milk", "Call"}); got != `add "Buy milk" Call` {
+		t.Errorf("commitMessage(add) = %q", got)
+	}
+	if got := commitMessage(save, []string{"work", "tag:work"}); got != "view save work tag:work" {
+		t.Errorf("commitMessage(view save) = %q", got)
+	}
+}
+
+func TestAutoCommit(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	var logBuf bytes.Buffer
+	oldLogger := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&logBuf, nil)))
+	defer slog.SetDefault(oldLogger)
+
+	dir := t.TempDir()
+	origFileName := services.GetTasksFileName()
+	services.SetTasksFileName(filepath.Join(dir, "tasks.json"))
+	defer services.SetTasksFileName(origFileName)
+
+	// Nothing is committed before sync is initialized
+	AddCmd.RunE(AddCmd, []string{"Before sync"})
+	AutoCommit(AddCmd, []string{"Before sync"})
+	if _, err := os.Stat(filepath.Join(dir, ".git")); !os.IsNotExist(err) {
+		t.Fatalf("Expected no repository before sync init")
+	}
+
+	// A repository that was not set up with sync init is left alone
+	exec.Command("git", "-C", dir, "init", "-q").Run()
+	os.WriteFile(filepath.Join(dir, ".env"), []byte("SECRET=1\n"), 0644)
+	AutoCommit(ListCmd, []string{})
+	if err := exec.Command("git", "-C", dir, "rev-parse", "--verify", "-q", "HEAD").Run(); err == nil {
+		t.Fatalf("Expected no commit in a repository without sync init")
+	}
+
+	SyncInitCmd.RunE(SyncInitCmd, []string{})
+	AddCmd.RunE(AddCmd, []string{"Buy milk"})
+	AutoCommit(AddCmd, []string{"Buy milk"})
+	// Commands that change nothing do not commit
+	AutoCommit(ListCmd, []string{})
+
+	out, err := exec.Command("git", "-C", dir, "log", "--format=%s").Output()
+	if err != nil {
+		t.Fatalf("git log failed: %v", err)
+	}
+	if got := strings.TrimSpace(string(out)); got != "add \"Buy milk\"\nInitialize task sync" {
+		t.Errorf("Unexpected history:\n%s", got)
+	}
+	if tracked, _ := exec.Command("git", "-C", dir, "ls-files", ".env").Output(); len(tracked) != 0 {
+		t.Errorf("Expected .env not to be committed")
+	}
+	if strings.Contains(logBuf.String(), "level=ERROR") {
+		t.Errorf("Unexpected errors:\n%s", logBuf.String())
+	}
+}
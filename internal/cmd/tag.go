@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/savabush/taskTracker/pkg/tasktracker"
+	"github.com/spf13/cobra"
+)
+
+var (
+	tagAddSelect    selector
+	tagRemoveSelect selector
+)
+
+var TagCmd = &cobra.Command{
+	Use:   "tag",
+	Short: "Add or remove a tag on tasks",
+	Long: `tag is used to add a tag to tasks or remove it from them, either the tasks
+named as arguments or the ones selected with --filter, --status, --older-than
+or --ids, for example with the IDs shown by list:
+
+  taskTracker tag add urgent --ids 3f2c9a1e,7b04d5c2`,
+}
+
+var TagAddCmd = &cobra.Command{
+	Use:   "add [tag] [task...]",
+	Short: "Add a tag to tasks",
+	Long: `add is used to add a tag to tasks. Tasks selected with flags are shown and
+must be confirmed first; the ones already tagged are left alone.`,
+
+	Args: tagArgs(&tagAddSelect),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		tag := args[0]
+		return tagTasks(cmd, args[1:], &tagAddSelect, "Tag", "tagged", "Tagged tasks",
+			func(task tasktracker.Task) bool { return !task.HasTag(tag) },
+			func(task *tasktracker.Task) {
+				if !task.HasTag(tag) {
+					task.Tags = append(task.Tags, tag)
+				}
+			})
+	},
+}
+
+var TagRemoveCmd = &cobra.Command{
+	Use:   "remove [tag] [task...]",
+	Short: "Remove a tag from tasks",
+	Long: `remove is used to remove a tag from tasks. Tasks selected with flags are
+shown and must be confirmed first; the ones without the tag are left alone.`,
+
+	Args: tagArgs(&tagRemoveSelect),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		tag := args[0]
+		return tagTasks(cmd, args[1:], &tagRemoveSelect, "Untag", "untagged", "Untagged tasks",
+			func(task tasktracker.Task) bool { return task.HasTag(tag) },
+			func(task *tasktracker.Task) {
+				task.Tags = slices.DeleteFunc(task.Tags, func(existing string) bool { return strings.EqualFold(existing, tag) })
+			})
+	},
+}
+
+// tagArgs requires a tag and either tasks or selector flags.
+func tagArgs(s *selector) cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		if len(args) < 1 {
+			return errors.New("requires a tag")
+		}
+		if tag := args[0]; strings.TrimSpace(tag) == "" || strings.ContainsAny(tag, " \t,") {
+			return errors.New("tag cannot be empty or contain spaces or commas")
+		}
+		if len(args) < 2 && !s.active() {
+			return errors.New("requires a task or a selector flag")
+		}
+		return nil
+	}
+}
+
+// tagTasks applies change to the tasks named by keys, or to the selected
+// tasks that need it after confirmation when selector flags are used.
+func tagTasks(cmd *cobra.Command, keys []string, s *selector, action, done, message string, needed func(task tasktracker.Task) bool, change func(task *tasktracker.Task)) error {
+	op := func(batch *tasktracker.Batch, key string) error {
+		_, err := batch.Update(key, change)
+		return err
+	}
+	if s.active() {
+		return s.run(cmd, keys, eachOptions{}, action, done, message, needed, op)
+	}
+	result, err := runEach(cmd, keys, eachOptions{}, op)
+	if err != nil {
+		return err
+	}
+	return result.report(done, message)
+}
+
+func init() {
+	tagAddSelect.addFlags(TagAddCmd)
+	tagRemoveSelect.addFlags(TagRemoveCmd)
+	TagCmd.AddCommand(TagAddCmd, TagRemoveCmd)
+}
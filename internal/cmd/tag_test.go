@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/savabush/taskTracker/internal/services"
+	"github.com/spf13/cobra"
+)
+
+func TestTagAddCmd_Args(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		sel     selector
+		wantErr bool
+	}{
+		{name: "No args", args: []string{}, wantErr: true},
+		{name: "Tag only", args: []string{"urgent"}, wantErr: true},
+		{name: "Tag with spaces", args: []string{"very urgent", "a"}, wantErr: true},
+		{name: "Tag and task", args: []string{"urgent", "a"}},
+		{name: "Tag and selector", args: []string{"urgent"}, sel: selector{ids: []string{"3"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tagAddSelect = tt.sel
+			defer func() { tagAddSelect = selector{} }()
+
+			err := TagAddCmd.Args(&cobra.Command{}, tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Args() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTagCmd_Run(t *testing.T) {
+	cleanup := createTempTaskFile(t)
+	defer cleanup()
+
+	service := loadService(t)
+	clear(service.Tasks)
+	for _, title := range []string{"a", "b", "c"} {
+		service.AddTask(title)
+	}
+	service.CompleteTask("c")
+	service.SaveTasks()
+
+	if err := TagAddCmd.RunE(&cobra.Command{}, []string{"urgent", "a", "a"}); err != nil {
+		t.Fatalf("tag add error = %v", err)
+	}
+
+	tagAddSelect = selector{status: "pending", yes: true}
+	err := TagAddCmd.RunE(&cobra.Command{}, []string{"urgent"})
+	tagAddSelect = selector{}
+	if err != nil {
+		t.Fatalf("tag add with selector error = %v", err)
+	}
+
+	tasks := loadService(t).Tasks
+	if !slices.Equal(tasks["a"].Tags, []string{"urgent"}) || !slices.Equal(tasks["b"].Tags, []string{"urgent"}) || tasks["c"].HasTag("urgent") {
+		t.Errorf("Unexpected tags a=%v b=%v c=%v", tasks["a"].Tags, tasks["b"].Tags, tasks["c"].Tags)
+	}
+
+	if err := TagAddCmd.Flags().Parse([]string{"--ids", tasks["a"].ShortID() + ",c", "--yes"}); err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	err = TagAddCmd.RunE(&cobra.Command{}, []string{"later"})
+	tagAddSelect = selector{}
+	if err != nil {
+		t.Fatalf("tag add by ID error = %v", err)
+	}
+
+	tasks = loadService(t).Tasks
+	if !tasks["a"].HasTag("later") || tasks["b"].HasTag("later") || !tasks["c"].HasTag("later") {
+		t.Errorf("Expected --ids to select a by short ID and c by title, got a=%v b=%v c=%v", tasks["a"].Tags, tasks["b"].Tags, tasks["c"].Tags)
+	}
+
+	cmd := &cobra.Command{}
+	cmd.SetIn(strings.NewReader("no\n"))
+	cmd.SetOut(&strings.Builder{})
+	tagRemoveSelect = selector{filter: "tag:urgent"}
+	err = TagRemoveCmd.RunE(cmd, []string{"urgent"})
+	tagRemoveSelect = selector{}
+	if err != nil {
+		t.Fatalf("tag remove cancelled error = %v", err)
+	}
+	if !loadService(t).Tasks["a"].HasTag("urgent") {
+		t.Errorf("Expected a cancelled removal to keep the tag")
+	}
+
+	if err := TagRemoveCmd.RunE(&cobra.Command{}, []string{"urgent", "a"}); err != nil {
+		t.Fatalf("tag remove error = %v", err)
+	}
+	if loadService(t).Tasks["a"].HasTag("urgent") {
+		t.Errorf("Expected the tag to be removed from a")
+	}
+	if err := TagRemoveCmd.RunE(&cobra.Command{}, []string{"URGENT", "b"}); err != nil {
+		t.Fatalf("tag remove in other case error = %v", err)
+	}
+	if tags := loadService(t).Tasks["b"].Tags; len(tags) != 0 {
+		t.Errorf("Expected the tag to be removed from b ignoring case, got %v", tags)
+	}
+
+	if err := TagRemoveCmd.RunE(&cobra.Command{}, []string{"urgent", "missing"}); !errors.Is(err, services.ErrTaskNotFound) {
+		t.Errorf("Expected ErrTaskNotFound, got %v", err)
+	}
+}
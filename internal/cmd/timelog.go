@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/savabush/taskTracker/internal/services"
+	"github.com/savabush/taskTracker/internal/utils"
+	"github.com/savabush/taskTracker/pkg/tasktracker"
+	"github.com/spf13/cobra"
+)
+
+const outputCSV = "csv"
+
+var (
+	timelogSince  string
+	timelogUntil  string
+	timelogOutput string
+)
+
+var TimelogCmd = &cobra.Command{
+	Use:   "timelog [filter]",
+	Short: "Report tracked time",
+	Long:  `timelog is used to sum tracked time per task, tag and day. Time entries are attributed to the day they started on. An optional filter uses the same syntax as list.`,
+
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) > 1 {
+			return errors.New("filter must be a single argument; quote expressions containing spaces")
+		}
+		if len(args) == 1 {
+			if _, err := parseFilter(args[0]); err != nil {
+				return err
+			}
+		}
+		if _, _, err := parseTimelogRange(); err != nil {
+			return err
+		}
+		if timelogOutput != outputTable && timelogOutput != outputJSON && timelogOutput != outputCSV {
+			return errors.New("output must be one of: table, json, csv")
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		filter := ""
+		if len(args) == 1 {
+			filter = args[0]
+		}
+		if _, err := parseFilter(filter); err != nil {
+			return &usageError{err}
+		}
+		since, until, err := parseTimelogRange()
+		if err != nil {
+			return &usageError{err}
+		}
+
+		client, err := tasktracker.New()
+		if err != nil {
+			return err
+		}
+		tasks, err := client.List(commandContext(cmd), tasktracker.ListOptions{Filter: filter})
+		if err != nil {
+			return err
+		}
+		timeLog := services.SummarizeTimeLog(tasks, since, until, time.Now())
+
+		switch timelogOutput {
+		case outputJSON:
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			return encoder.Encode(timeLog)
+		case outputCSV:
+			return renderTimeLogCSV(os.Stdout, timeLog)
+		}
+		return renderTimeLog(os.Stdout, timeLog)
+	},
+}
+
+func init() {
+	TimelogCmd.Flags().StringVar(&timelogSince, "since", "", "Only include time entries started on or after this date (YYYY-MM-DD)")
+	TimelogCmd.Flags().StringVar(&timelogUntil, "until", "", "Only include time entries started on or before this date (YYYY-MM-DD)")
+	TimelogCmd.Flags().StringVarP(&timelogOutput, "output", "o", outputTable, "Output format: table, json, csv")
+}
+
+func parseTimelogRange() (since, until time.Time, err error) {
+	now := time.Now()
+	if timelogSince != "" {
+		if since, err = services.ParseDate(timelogSince, now); err != nil {
+			return since, until, errors.New("since must be a date in YYYY-MM-DD format")
+		}
+	}
+	if timelogUntil != "" {
+		if until, err = services.ParseDate(timelogUntil, now); err != nil {
+			return since, until, errors.New("until must be a date in YYYY-MM-DD format")
+		}
+		// The until date is inclusive
+		until = until.AddDate(0, 0, 1)
+	}
+	return since, until, nil
+}
+
+func renderTimeLog(w io.Writer, timeLog services.TimeLog) error {
+	fmt.Fprintf(w, "Total: %s\n", formatDuration(timeLog.Total))
+	sections := []struct {
+		header  string
+		totals  []services.TimeTotal
+		flexKey bool
+	}{
+		{header: "TASK", totals: timeLog.ByTask, flexKey: true},
+		{header: "TAG", totals: timeLog.ByTag},
+		{header: "DAY", totals: timeLog.ByDay},
+	}
+	for _, section := range sections {
+		if len(section.totals) == 0 {
+			continue
+		}
+		fmt.Fprintln(w)
+		table := utils.NewTable(w, utils.Column{Header: "TIME"}, utils.Column{Header: section.header, Flexible: section.flexKey})
+		for _, total := range section.totals {
+			table.AddRow(utils.Cell{Text: formatDuration(total.Duration)}, utils.Cell{Text: total.Key})
+		}
+		if err := table.Render(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// renderTimeLogCSV writes one row per total with the grouping it belongs to.
+func renderTimeLogCSV(w io.Writer, timeLog services.TimeLog) error {
+	writer := csv.NewWriter(w)
+	writer.Write([]string{"group", "key", "hours"})
+	groups := []struct {
+		name   string
+		totals []services.TimeTotal
+	}{
+		{name: "task", totals: timeLog.ByTask},
+		{name: "tag", totals: timeLog.ByTag},
+		{name: "day", totals: timeLog.ByDay},
+	}
+	for _, group := range groups {
+		for _, total := range group.totals {
+			writer.Write([]string{group.name, total.Key, strconv.FormatFloat(total.Hours, 'f', 2, 64)})
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
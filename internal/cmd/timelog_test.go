@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/savabush/taskTracker/internal/services"
+	"github.com/spf13/cobra"
+)
+
+func TestTimelogCmd_Args(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		since   string
+		output  string
+		wantErr bool
+	}{
+		{name: "No args", args: []string{}, wantErr: false},
+		{name: "Filter", args: []string{"tag:backend"}, wantErr: false},
+		{name: "Invalid filter", args: []string{"tag>x"}, wantErr: true},
+		{name: "Valid since", args: []string{}, since: "2026-10-01", wantErr: false},
+		{name: "Invalid since", args: []string{}, since: "last week", wantErr: true},
+		{name: "CSV output", args: []string{}, output: "csv", wantErr: false},
+		{name: "Invalid output", args: []string{}, output: "xml", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			timelogSince, timelogOutput = tt.since, tt.output
+			if timelogOutput == "" {
+				timelogOutput = outputTable
+			}
+			defer func() { timelogSince, timelogOutput = "", outputTable }()
+
+			err := TimelogCmd.Args(&cobra.Command{}, tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Args() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRenderTimeLog(t *testing.T) {
+	timeLog := services.TimeLog{
+		Total:  3 * time.Hour,
+		ByTask: []services.TimeTotal{{Key: "api", Duration: 3 * time.Hour, Hours: 3}},
+		ByTag:  []services.TimeTotal{{Key: "backend", Duration: 3 * time.Hour, Hours: 3}},
+		ByDay:  []services.TimeTotal{{Key: "2026-10-19", Duration: 3 * time.Hour, Hours: 3}},
+	}
+
+	var buf bytes.Buffer
+	if err := renderTimeLogCSV(&buf, timeLog); err != nil {
+		t.Fatalf("renderTimeLogCSV returned unexpected error: %v", err)
+	}
+	want := "group,key,hours\ntask,api,3.00\ntag,backend,3.00\nday,2026-10-19,3.00\n"
+	if buf.String() != want {
+		t.Errorf("CSV output = %q, want %q", buf.String(), want)
+	}
+
+	buf.Reset()
+	if err := renderTimeLog(&buf, timeLog); err != nil {
+		t.Fatalf("renderTimeLog returned unexpected error: %v", err)
+	}
+	for _, want := range []string{"Total: 3h 0m", "TASK", "api", "backend", "2026-10-19"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, buf.String())
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/savabush/taskTracker/pkg/tasktracker"
+	"github.com/spf13/cobra"
+)
+
+var StartCmd = &cobra.Command{
+	Use:   "start [task]",
+	Short: "Start a timer on a task",
+	Long:  `start is used to start tracking time on a task and mark it as in progress. Only one timer can run at a time; use stop to end it.`,
+
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return errors.New("requires exactly one task")
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := tasktracker.New()
+		if err != nil {
+			return err
+		}
+		task, err := client.StartTimer(commandContext(cmd), args[0])
+		if err != nil {
+			return err
+		}
+		slog.Info("Started timer", "task", task.Title)
+		return nil
+	},
+}
+
+var StopCmd = &cobra.Command{
+	Use:   "stop",
+	Short: "Stop the running timer",
+	Long:  `stop is used to stop the running timer and record the time entry on its task.`,
+
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := tasktracker.New()
+		if err != nil {
+			return err
+		}
+		task, elapsed, err := client.StopTimer(commandContext(cmd))
+		if err != nil {
+			return err
+		}
+		slog.Info("Stopped timer", "task", task.Title, "elapsed", formatDuration(elapsed), "total", formatDuration(task.LoggedTime(time.Now())))
+		return nil
+	},
+}
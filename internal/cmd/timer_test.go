@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/savabush/taskTracker/internal/services"
+	"github.com/spf13/cobra"
+)
+
+func TestStartCmd_Args(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr bool
+	}{
+		{name: "No args", args: []string{}, wantErr: true},
+		{name: "Valid task", args: []string{"Task1"}, wantErr: false},
+		{name: "Too many args", args: []string{"Task1", "Task2"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := StartCmd.Args(&cobra.Command{}, tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Args() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestStartStopCmd_Run(t *testing.T) {
+	var logBuf bytes.Buffer
+	oldLogger := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&logBuf, nil)))
+	defer slog.SetDefault(oldLogger)
+
+	cleanup := createTempTaskFile(t)
+	defer cleanup()
+
+	service := loadService(t)
+	service.AddTask("Task1")
+	service.AddTask("Task2")
+	service.SaveTasks()
+
+	StartCmd.RunE(&cobra.Command{}, []string{"Task1"})
+	if !strings.Contains(logBuf.String(), "Started timer") {
+		t.Errorf("Expected log 'Started timer', got: %s", logBuf.String())
+	}
+
+	service = loadService(t)
+	task, _ := service.GetTask("Task1")
+	if task.Status != services.TaskStatusInProgress {
+		t.Errorf("Expected task to be in progress after start, got %s", task.Status)
+	}
+
+	if err := StartCmd.RunE(&cobra.Command{}, []string{"Task2"}); err == nil || !strings.Contains(err.Error(), "timer already running for task: Task1") {
+		t.Errorf("Expected error about running timer, got: %v", err)
+	}
+
+	logBuf.Reset()
+	StopCmd.RunE(&cobra.Command{}, nil)
+	if !strings.Contains(logBuf.String(), "Stopped timer") {
+		t.Errorf("Expected log 'Stopped timer', got: %s", logBuf.String())
+	}
+	if _, running := loadService(t).RunningTimer(); running {
+		t.Errorf("Expected no running timer after stop")
+	}
+
+	if err := StopCmd.RunE(&cobra.Command{}, nil); err == nil || !strings.Contains(err.Error(), "no timer is running") {
+		t.Errorf("Expected error about no running timer, got: %v", err)
+	}
+}
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/savabush/taskTracker/internal/tui"
+	"github.com/savabush/taskTracker/internal/utils"
+	"github.com/savabush/taskTracker/pkg/tasktracker"
+	"github.com/spf13/cobra"
+)
+
+var TuiCmd = &cobra.Command{
+	Use:   "tui",
+	Short: "Work with tasks in a full-screen terminal interface",
+	Long: `tui is used to browse and change tasks in a full-screen terminal interface with a column per status and a detail pane for the selected task. Every change is saved right away.
+
+  ←→ ↑↓ (or h l k j)   move between columns and tasks
+  a                    add a task
+  e, enter             edit the selected task
+  x, delete            delete the selected task
+  s / c / p            mark the selected task as in progress, completed or pending
+  /                    filter tasks as in list, e.g. tag:work and priority>=high
+  esc                  clear the filter
+  r                    reload tasks from storage
+  q, ctrl+c            quit`,
+
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) > 0 {
+			return errors.New("tui takes no arguments")
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if !utils.IsTerminal(os.Stdin) || !utils.IsTerminal(os.Stdout) {
+			return &usageError{errors.New("tui requires a terminal")}
+		}
+
+		client, err := tasktracker.New()
+		if err != nil {
+			return err
+		}
+		model, err := tui.NewModel(client, func(w io.Writer, task tasktracker.Task) {
+			renderTask(w, task, false, time.Now())
+		})
+		if err != nil {
+			return err
+		}
+		model.Color = utils.ColorEnabled(os.Stdout)
+
+		// Log records would be written over the interface
+		logger := slog.Default()
+		slog.SetDefault(slog.New(slog.DiscardHandler))
+		err = tui.Run(os.Stdin, os.Stdout, model)
+		slog.SetDefault(logger)
+		return err
+	},
+}
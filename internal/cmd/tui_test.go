@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTuiCmd_Args(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr bool
+	}{
+		{name: "No args", args: []string{}, wantErr: false},
+		{name: "Extra args", args: []string{"list"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := TuiCmd.Args(TuiCmd, tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Args() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTuiCmd_RequiresTerminal(t *testing.T) {
+	// Test binaries run with stdin and stdout redirected
+	err := TuiCmd.RunE(TuiCmd, []string{})
+	if err == nil || !strings.Contains(err.Error(), "requires a terminal") || ExitCode(err) != ExitUsage {
+		t.Errorf("Expected terminal usage error, got %v", err)
+	}
+}
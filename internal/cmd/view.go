@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"errors"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/savabush/taskTracker/internal/utils"
+	"github.com/savabush/taskTracker/pkg/tasktracker"
+	"github.com/spf13/cobra"
+)
+
+var (
+	viewSort   string
+	viewOutput string
+)
+
+var ViewCmd = &cobra.Command{
+	Use:   "view",
+	Short: "Manage saved list views",
+	Long:  `view is used to save, list and delete named list filters. Run a saved view with "list @name".`,
+}
+
+var ViewSaveCmd = &cobra.Command{
+	Use:   "save [name] [filter]",
+	Short: "Save a named view",
+	Long:  `save stores a list filter together with its sort order and output format under a name. Saving an existing name replaces it.`,
+
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) < 1 || len(args) > 2 {
+			return errors.New("requires a view name and an optional filter")
+		}
+		if len(args) == 2 {
+			if _, err := parseFilter(args[1]); err != nil {
+				return err
+			}
+		}
+		return validateListFlags(viewSort, viewOutput)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		view := tasktracker.View{Name: strings.TrimPrefix(args[0], "@"), Sort: viewSort, Output: viewOutput}
+		if len(args) == 2 {
+			view.Query = args[1]
+		}
+
+		client, err := tasktracker.New()
+		if err != nil {
+			return err
+		}
+		err = client.Batch(commandContext(cmd), func(b *tasktracker.Batch) error {
+			if err := b.SaveView(view); err != nil {
+				return &usageError{err}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		slog.Info("Saved view", "view", view.Name)
+		return nil
+	},
+}
+
+var ViewListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List saved views",
+	Long:  `list is used to show all saved views.`,
+
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := tasktracker.New()
+		if err != nil {
+			return err
+		}
+		views, err := client.Views(commandContext(cmd))
+		if err != nil {
+			return err
+		}
+
+		table := utils.NewTable(os.Stdout,
+			utils.Column{Header: "NAME"},
+			utils.Column{Header: "SORT"},
+			utils.Column{Header: "OUTPUT"},
+			utils.Column{Header: "FILTER", Flexible: true},
+		)
+		for _, view := range views {
+			table.AddRow(
+				utils.Cell{Text: "@" + view.Name},
+				utils.Cell{Text: view.Sort},
+				utils.Cell{Text: view.Output},
+				utils.Cell{Text: view.Query},
+			)
+		}
+		return table.Render()
+	},
+}
+
+var ViewDeleteCmd = &cobra.Command{
+	Use:   "delete [name]",
+	Short: "Delete a saved view",
+	Long:  `delete is used to remove a saved view.`,
+
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return errors.New("requires a view name")
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := strings.TrimPrefix(args[0], "@")
+		client, err := tasktracker.New()
+		if err != nil {
+			return err
+		}
+		if err := client.DeleteView(commandContext(cmd), name); err != nil {
+			return err
+		}
+		slog.Info("Deleted view", "view", name)
+		return nil
+	},
+}
+
+func init() {
+	ViewSaveCmd.Flags().StringVar(&viewSort, "sort", "", "Sort order stored with the view")
+	ViewSaveCmd.Flags().StringVarP(&viewOutput, "output", "o", "", "Output format stored with the view: "+strings.Join(outputFormats, ", "))
+	ViewCmd.AddCommand(ViewSaveCmd, ViewListCmd, ViewDeleteCmd)
+}
@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/savabush/taskTracker/internal/services"
+	"github.com/spf13/cobra"
+)
+
+// captureStdout runs fn and returns everything it wrote to os.Stdout.
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+	oldStdout := os.Stdout
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("Failed to create pipe: %v", err)
+	}
+	os.Stdout = w
+	defer func() { os.Stdout = oldStdout }()
+
+	fn()
+
+	w.Close()
+	var buf bytes.Buffer
+	io.Copy(&buf, r)
+	return buf.String()
+}
+
+func TestViewSaveCmd_Args(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		sort    string
+		output  string
+		wantErr bool
+	}{
+		{name: "No args", args: []string{}, wantErr: true},
+		{name: "Name only", args: []string{"all"}, wantErr: false},
+		{name: "Name and filter", args: []string{"urgent", "priority>=high"}, wantErr: false},
+		{name: "Invalid filter", args: []string{"broken", "priority>="}, wantErr: true},
+		{name: "Too many args", args: []string{"a", "pending", "extra"}, wantErr: true},
+		{name: "Invalid sort", args: []string{"a"}, sort: "size", wantErr: true},
+		{name: "Invalid output", args: []string{"a"}, output: "xml", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viewSort, viewOutput = tt.sort, tt.output
+			defer func() { viewSort, viewOutput = "", "" }()
+
+			err := ViewSaveCmd.Args(&cobra.Command{}, tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Args() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestViewCommands_Run(t *testing.T) {
+	var logBuf bytes.Buffer
+	oldLogger := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&logBuf, nil)))
+	defer slog.SetDefault(oldLogger)
+
+	cleanup := createTempTaskFile(t)
+	defer cleanup()
+
+	service := loadService(t)
+	service.AddTask("Urgent bug")
+	service.UpdateTask("Urgent bug", func(task *services.Task) { task.Priority = services.TaskPriorityCritical })
+	service.AddTask("Someday")
+	service.SaveTasks()
+
+	viewOutput = outputJSON
+	ViewSaveCmd.RunE(&cobra.Command{}, []string{"urgent", "priority>=high"})
+	viewOutput = ""
+	if !strings.Contains(logBuf.String(), "Saved view") {
+		t.Errorf("Expected log 'Saved view', got: %s", logBuf.String())
+	}
+
+	output := captureStdout(t, func() {
+		ViewListCmd.RunE(&cobra.Command{}, nil)
+	})
+	if !strings.Contains(output, "@urgent") || !strings.Contains(output, "priority>=high") {
+		t.Errorf("Expected saved view in view list, got: %s", output)
+	}
+
+	// Running the view uses its stored filter and output format
+	output = captureStdout(t, func() {
+		ListCmd.RunE(&cobra.Command{}, []string{"@urgent"})
+	})
+	var tasks []services.Task
+	if err := json.Unmarshal([]byte(output), &tasks); err != nil {
+		t.Fatalf("Expected JSON output from view, got %q: %v", output, err)
+	}
+	if len(tasks) != 1 || tasks[0].Title != "Urgent bug" {
+		t.Errorf("Expected only 'Urgent bug', got %+v", tasks)
+	}
+
+	logBuf.Reset()
+	ViewDeleteCmd.RunE(&cobra.Command{}, []string{"@urgent"})
+	if !strings.Contains(logBuf.String(), "Deleted view") {
+		t.Errorf("Expected log 'Deleted view', got: %s", logBuf.String())
+	}
+
+	if err := ListCmd.RunE(&cobra.Command{}, []string{"@urgent"}); !errors.Is(err, services.ErrViewNotFound) {
+		t.Errorf("Expected ErrViewNotFound, got: %v", err)
+	}
+}
+
+func TestViewSaveCmd_LoadError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tasks.json")
+	truncated := `{"tasks":{"Write report":{"id":"1","title":"Wri`
+	os.WriteFile(path, []byte(truncated), 0644)
+	origFileName := services.GetTasksFileName()
+	services.SetTasksFileName(path)
+	defer services.SetTasksFileName(origFileName)
+
+	err := ViewSaveCmd.RunE(&cobra.Command{}, []string{"urgent", "priority>=high"})
+	if ExitCode(err) != ExitFailure {
+		t.Errorf("Expected exit code %d for an unreadable task store, got %d (%v)", ExitFailure, ExitCode(err), err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != truncated {
+		t.Errorf("Expected the unreadable task store to be left alone, got %q", data)
+	}
+}
@@ -0,0 +1,532 @@
+// Package grpcserver serves the task store over the gRPC API defined in
+// proto/tasktracker/v1/tasktracker.proto.
+package grpcserver
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/savabush/taskTracker/internal/services"
+	"github.com/savabush/taskTracker/pkg/tasktracker"
+	pb "github.com/savabush/taskTracker/pkg/tasktrackerpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// DefaultPollInterval is how often the server checks the task store for
+// changes made outside it while tasks are watched.
+const DefaultPollInterval = 2 * time.Second
+
+// watchBuffer is the number of events a watcher may fall behind by before
+// its stream is ended.
+const watchBuffer = 256
+
+// Server implements the TaskTracker service on the task store. Requests are
+// served one at a time, since each loads the task store, changes it and saves
+// it again.
+type Server struct {
+	pb.UnimplementedTaskTrackerServer
+
+	// PollInterval is how often the server checks the task store for changes
+	// made by the CLI and other clients while tasks are watched.
+	PollInterval time.Duration
+	// Locker serializes requests. NewServer gives the server a lock of its
+	// own; set it to share one with another server on the same task store.
+	Locker sync.Locker
+
+	// snapshot holds the tasks by ID as last published, or nil before the
+	// task store could be loaded.
+	snapshot map[string]services.Task
+	watchers map[*watcher]struct{}
+	// stopPolling stops the poller, which runs while there are watchers.
+	stopPolling chan struct{}
+}
+
+// watcher is a WatchTasks stream. events is closed when the watcher falls
+// behind.
+type watcher struct {
+	query  services.Query
+	events chan *pb.TaskEvent
+}
+
+func NewServer() *Server {
+	s := &Server{
+		PollInterval: DefaultPollInterval,
+		Locker:       &sync.Mutex{},
+		watchers:     make(map[*watcher]struct{}),
+	}
+	if tasks, err := loadTasks(context.Background()); err == nil {
+		s.snapshot = tasksByID(tasks)
+	}
+	return s
+}
+
+func tasksByID(tasks []services.Task) map[string]services.Task {
+	byID := make(map[string]services.Task, len(tasks))
+	for _, task := range tasks {
+		byID[task.ID] = task
+	}
+	return byID
+}
+
+// statusError converts an error of the task store to a gRPC status.
+func statusError(err error) error {
+	var invalidErr *tasktracker.InvalidTaskError
+	var parseErr *tasktracker.ParseError
+	switch {
+	case status.Code(err) != codes.Unknown:
+		return err
+	case errors.Is(err, tasktracker.ErrTaskNotFound):
+		return status.Error(codes.NotFound, "task not found")
+	case errors.Is(err, tasktracker.ErrDuplicateTitle):
+		return status.Error(codes.AlreadyExists, "task already exists")
+	case errors.Is(err, tasktracker.ErrInvalidTransition):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.As(err, &parseErr):
+		return status.Error(codes.InvalidArgument, "invalid filter: "+parseErr.Error())
+	case errors.Is(err, tasktracker.ErrAmbiguousID), errors.As(err, &invalidErr):
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+// loadTasks returns all tasks of the task store.
+func loadTasks(ctx context.Context) ([]services.Task, error) {
+	client, err := tasktracker.New()
+	if err != nil {
+		return nil, err
+	}
+	return client.List(ctx, tasktracker.ListOptions{})
+}
+
+// batch runs fn on the task store, then publishes the changes, including
+// those made outside the server since the last time.
+func (s *Server) batch(ctx context.Context, fn func(b *tasktracker.Batch) error) error {
+	s.Locker.Lock()
+	defer s.Locker.Unlock()
+
+	client, err := tasktracker.New()
+	if err != nil {
+		return statusError(err)
+	}
+	var tasks []services.Task
+	err = client.Batch(ctx, func(b *tasktracker.Batch) error {
+		if err := fn(b); err != nil {
+			return err
+		}
+		tasks, err = b.List(tasktracker.ListOptions{})
+		return err
+	})
+	if err != nil {
+		return statusError(err)
+	}
+	s.publish(tasks)
+	return nil
+}
+
+// publish compares tasks with the last snapshot and sends the differences to
+// the watchers. The first tasks loaded only become the snapshot. It must be
+// called with s.Locker held.
+func (s *Server) publish(tasks []services.Task) {
+	current := tasksByID(tasks)
+	if s.snapshot == nil {
+		s.snapshot = current
+		return
+	}
+	type change struct {
+		kind pb.TaskEventType
+		task services.Task
+	}
+	var changes []change
+	for id, task := range current {
+		old, ok := s.snapshot[id]
+		switch {
+		case !ok:
+			changes = append(changes, change{pb.TaskEventType_TASK_EVENT_TYPE_CREATED, task})
+		case !proto.Equal(toProto(old), toProto(task)):
+			changes = append(changes, change{pb.TaskEventType_TASK_EVENT_TYPE_UPDATED, task})
+		}
+	}
+	for id, old := range s.snapshot {
+		if _, ok := current[id]; !ok {
+			changes = append(changes, change{pb.TaskEventType_TASK_EVENT_TYPE_DELETED, old})
+		}
+	}
+	s.snapshot = current
+	slices.SortFunc(changes, func(a, b change) int {
+		return cmp.Or(cmp.Compare(a.kind, b.kind), strings.Compare(a.task.Title, b.task.Title))
+	})
+
+	for w := range s.watchers {
+		for _, change := range changes {
+			if w.query.Match(change.task) && !w.send(&pb.TaskEvent{Type: change.kind, Task: toProto(change.task)}) {
+				s.removeWatcher(w)
+				close(w.events)
+				break
+			}
+		}
+	}
+}
+
+// send queues event without blocking and reports whether there was room.
+func (w *watcher) send(event *pb.TaskEvent) bool {
+	select {
+	case w.events <- event:
+		return true
+	default:
+		return false
+	}
+}
+
+// addWatcher registers w, starting the poller for the first watcher. It must
+// be called with s.Locker held.
+func (s *Server) addWatcher(w *watcher) {
+	s.watchers[w] = struct{}{}
+	if s.stopPolling == nil {
+		s.stopPolling = make(chan struct{})
+		go s.pollEvery(cmp.Or(s.PollInterval, DefaultPollInterval), s.stopPolling)
+	}
+}
+
+// removeWatcher unregisters w, stopping the poller after the last watcher. It
+// must be called with s.Locker held.
+func (s *Server) removeWatcher(w *watcher) {
+	if _, ok := s.watchers[w]; !ok {
+		return
+	}
+	delete(s.watchers, w)
+	if len(s.watchers) == 0 {
+		close(s.stopPolling)
+		s.stopPolling = nil
+	}
+}
+
+// pollEvery publishes changes made outside the server until stop is closed.
+func (s *Server) pollEvery(interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			s.poll()
+		}
+	}
+}
+
+// poll publishes changes made outside the server. A task store that cannot be
+// loaded, for example while it is being written, is skipped until the next
+// poll rather than reported as having no tasks.
+func (s *Server) poll() {
+	s.Locker.Lock()
+	defer s.Locker.Unlock()
+	tasks, err := loadTasks(context.Background())
+	if err != nil {
+		slog.Warn("Failed to poll the task store", "error", err)
+		return
+	}
+	s.publish(tasks)
+}
+
+func (s *Server) ListTasks(ctx context.Context, req *pb.ListTasksRequest) (*pb.ListTasksResponse, error) {
+	if err := services.ValidateSortOrder(req.GetSort()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	var tasks []services.Task
+	err := s.batch(ctx, func(b *tasktracker.Batch) (err error) {
+		tasks, err = b.List(tasktracker.ListOptions{Filter: req.GetFilter(), Sort: req.GetSort()})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp := &pb.ListTasksResponse{}
+	for _, task := range tasks {
+		resp.Tasks = append(resp.Tasks, toProto(task))
+	}
+	return resp, nil
+}
+
+func (s *Server) GetTask(ctx context.Context, req *pb.GetTaskRequest) (*pb.Task, error) {
+	return s.respond(ctx, req.GetTask(), (*tasktracker.Batch).Get)
+}
+
+func (s *Server) CreateTask(ctx context.Context, req *pb.CreateTaskRequest) (*pb.Task, error) {
+	update, err := newTaskUpdate(req.GetTask(), nil)
+	if err != nil {
+		return nil, err
+	}
+	if !update.has("title") {
+		return nil, status.Error(codes.InvalidArgument, "title is required")
+	}
+
+	var task services.Task
+	update.apply(&task)
+	err = s.batch(ctx, func(b *tasktracker.Batch) (err error) {
+		task, err = b.Add(task)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toProto(task), nil
+}
+
+func (s *Server) UpdateTask(ctx context.Context, req *pb.UpdateTaskRequest) (*pb.Task, error) {
+	update, err := newTaskUpdate(req.GetFields(), req.GetUpdateMask())
+	if err != nil {
+		return nil, err
+	}
+	return s.respond(ctx, req.GetTask(), func(b *tasktracker.Batch, key string) (services.Task, error) {
+		return b.Update(key, update.apply)
+	})
+}
+
+func (s *Server) DeleteTask(ctx context.Context, req *pb.DeleteTaskRequest) (*pb.DeleteTaskResponse, error) {
+	err := s.batch(ctx, func(b *tasktracker.Batch) error {
+		return b.Delete(req.GetTask())
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &pb.DeleteTaskResponse{}, nil
+}
+
+func (s *Server) StartTask(ctx context.Context, req *pb.StartTaskRequest) (*pb.Task, error) {
+	return s.respond(ctx, req.GetTask(), (*tasktracker.Batch).Start)
+}
+
+// CompleteTask marks a task as completed. A completed recurring task keeps
+// its ID under a dated title.
+func (s *Server) CompleteTask(ctx context.Context, req *pb.CompleteTaskRequest) (*pb.Task, error) {
+	return s.respond(ctx, req.GetTask(), (*tasktracker.Batch).Complete)
+}
+
+func (s *Server) ReopenTask(ctx context.Context, req *pb.ReopenTaskRequest) (*pb.Task, error) {
+	return s.respond(ctx, req.GetTask(), (*tasktracker.Batch).Reopen)
+}
+
+// respond runs op on the task with the given ID, ID prefix or title and
+// returns the task op returns.
+func (s *Server) respond(ctx context.Context, key string, op func(b *tasktracker.Batch, key string) (services.Task, error)) (*pb.Task, error) {
+	var task services.Task
+	err := s.batch(ctx, func(b *tasktracker.Batch) (err error) {
+		task, err = op(b, key)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toProto(task), nil
+}
+
+func (s *Server) WatchTasks(req *pb.WatchTasksRequest, stream pb.TaskTracker_WatchTasksServer) error {
+	query, err := services.ParseQuery(req.GetFilter())
+	if err != nil {
+		return status.Error(codes.InvalidArgument, "invalid filter: "+err.Error())
+	}
+	w := &watcher{query: query, events: make(chan *pb.TaskEvent, watchBuffer)}
+
+	// Catch up with changes made outside the server first, so that the
+	// initial tasks and the events that follow agree
+	s.Locker.Lock()
+	tasks, err := loadTasks(stream.Context())
+	if err != nil {
+		s.Locker.Unlock()
+		return statusError(err)
+	}
+	s.publish(tasks)
+	s.addWatcher(w)
+	var initial []services.Task
+	if req.GetSendInitial() {
+		for _, task := range s.snapshot {
+			if query.Match(task) {
+				initial = append(initial, task)
+			}
+		}
+	}
+	s.Locker.Unlock()
+	defer func() {
+		s.Locker.Lock()
+		defer s.Locker.Unlock()
+		s.removeWatcher(w)
+	}()
+
+	slices.SortFunc(initial, func(a, b services.Task) int { return strings.Compare(a.Title, b.Title) })
+	for _, task := range initial {
+		if err := stream.Send(&pb.TaskEvent{Type: pb.TaskEventType_TASK_EVENT_TYPE_CREATED, Task: toProto(task)}); err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case event, ok := <-w.events:
+			if !ok {
+				return status.Error(codes.ResourceExhausted, "watcher fell behind")
+			}
+			if err := stream.Send(event); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// editableFields are the task fields that can be set by CreateTask and
+// UpdateTask.
+var editableFields = []string{"title", "description", "notes", "tags", "priority", "due", "recurrence", "estimate"}
+
+// taskUpdate holds the fields of a create or update request, converted to
+// their task values. The values are checked when the task is saved, as for
+// the Go API.
+type taskUpdate struct {
+	fields   *pb.Task
+	paths    []string
+	priority services.TaskPriority
+	due      time.Time
+	estimate services.Estimate
+}
+
+// newTaskUpdate converts the fields named in mask. Without a mask, the
+// editable fields set in fields are used.
+func newTaskUpdate(fields *pb.Task, mask *fieldmaskpb.FieldMask) (*taskUpdate, error) {
+	if fields == nil {
+		fields = &pb.Task{}
+	}
+	update := &taskUpdate{fields: fields}
+	if len(mask.GetPaths()) > 0 {
+		for _, path := range mask.GetPaths() {
+			if !slices.Contains(editableFields, path) {
+				return nil, status.Errorf(codes.InvalidArgument, "field %q cannot be updated", path)
+			}
+		}
+		update.paths = mask.GetPaths()
+	} else {
+		fields.ProtoReflect().Range(func(field protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+			if name := string(field.Name()); slices.Contains(editableFields, name) {
+				update.paths = append(update.paths, name)
+			}
+			return true
+		})
+	}
+
+	if update.has("priority") {
+		var ok bool
+		if update.priority, ok = priorityFromProto[fields.GetPriority()]; !ok {
+			// Left for the check of the task to reject
+			update.priority = services.TaskPriority(fields.GetPriority().String())
+		}
+	}
+	if update.has("due") && fields.GetDue() != nil {
+		if err := fields.GetDue().CheckValid(); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid due date")
+		}
+		due := fields.GetDue().AsTime().In(time.Local)
+		update.due = time.Date(due.Year(), due.Month(), due.Day(), 0, 0, 0, 0, time.Local)
+	}
+	if update.has("estimate") {
+		estimate, err := services.ParseEstimate(fields.GetEstimate())
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		update.estimate = estimate
+	}
+	return update, nil
+}
+
+func (u *taskUpdate) has(path string) bool {
+	return slices.Contains(u.paths, path)
+}
+
+func (u *taskUpdate) apply(task *services.Task) {
+	if u.has("title") {
+		task.Title = u.fields.GetTitle()
+	}
+	if u.has("description") {
+		task.Description = u.fields.GetDescription()
+	}
+	if u.has("notes") {
+		task.Notes = slices.Clone(u.fields.GetNotes())
+	}
+	if u.has("tags") {
+		task.Tags = slices.Clone(u.fields.GetTags())
+	}
+	if u.has("priority") {
+		task.Priority = u.priority
+	}
+	if u.has("due") {
+		task.Due = u.due
+	}
+	if u.has("recurrence") {
+		task.Recurrence = u.fields.GetRecurrence()
+	}
+	if u.has("estimate") {
+		task.Estimate = u.estimate
+	}
+}
+
+var (
+	statusToProto = map[services.TaskStatus]pb.TaskStatus{
+		services.TaskStatusPending:    pb.TaskStatus_TASK_STATUS_PENDING,
+		services.TaskStatusInProgress: pb.TaskStatus_TASK_STATUS_IN_PROGRESS,
+		services.TaskStatusCompleted:  pb.TaskStatus_TASK_STATUS_COMPLETED,
+	}
+	priorityToProto = map[services.TaskPriority]pb.TaskPriority{
+		"":                            pb.TaskPriority_TASK_PRIORITY_UNSPECIFIED,
+		services.TaskPriorityLow:      pb.TaskPriority_TASK_PRIORITY_LOW,
+		services.TaskPriorityMedium:   pb.TaskPriority_TASK_PRIORITY_MEDIUM,
+		services.TaskPriorityHigh:     pb.TaskPriority_TASK_PRIORITY_HIGH,
+		services.TaskPriorityCritical: pb.TaskPriority_TASK_PRIORITY_CRITICAL,
+	}
+	priorityFromProto = map[pb.TaskPriority]services.TaskPriority{}
+)
+
+func init() {
+	for priority, value := range priorityToProto {
+		priorityFromProto[value] = priority
+	}
+}
+
+// timestamp converts t, leaving zero times unset.
+func timestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+func toProto(task services.Task) *pb.Task {
+	message := &pb.Task{
+		Id:          task.ID,
+		Title:       task.Title,
+		Description: task.Description,
+		Notes:       task.Notes,
+		Tags:        task.Tags,
+		Priority:    priorityToProto[task.Priority],
+		Due:         timestamp(task.Due),
+		Recurrence:  task.Recurrence,
+		Estimate:    task.Estimate.String(),
+		Status:      statusToProto[task.Status],
+		CreatedAt:   timestamp(task.CreatedAt),
+		UpdatedAt:   timestamp(task.UpdatedAt),
+		StartedAt:   timestamp(task.StartedAt),
+		CompletedAt: timestamp(task.CompletedAt),
+	}
+	for _, entry := range task.TimeEntries {
+		message.TimeEntries = append(message.TimeEntries, &pb.TimeEntry{Start: timestamp(entry.Start), End: timestamp(entry.End)})
+	}
+	return message
+}
@@ -0,0 +1,287 @@
+package grpcserver
+
+import (
+	"context"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/savabush/taskTracker/internal/services"
+	pb "github.com/savabush/taskTracker/pkg/tasktrackerpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func useTempTaskFile(t *testing.T) string {
+	t.Helper()
+	tmpFile, err := os.CreateTemp(t.TempDir(), "tasks_test*.json")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	tmpFile.Write([]byte(`{"tasks":{}}`))
+	tmpFile.Close()
+
+	origFileName := services.GetTasksFileName()
+	services.SetTasksFileName(tmpFile.Name())
+	t.Cleanup(func() { services.SetTasksFileName(origFileName) })
+	return tmpFile.Name()
+}
+
+// loadService loads the task store, failing the test if it cannot be loaded.
+func loadService(t *testing.T) *services.TaskService {
+	t.Helper()
+	service, err := services.NewTaskService()
+	if err != nil {
+		t.Fatalf("Failed to load tasks: %v", err)
+	}
+	return service
+}
+
+// startServer serves a new Server on an in-process listener and returns a
+// client connected to it.
+func startServer(t *testing.T) pb.TaskTrackerClient {
+	t.Helper()
+	useTempTaskFile(t)
+	return serve(t)
+}
+
+// serve serves a new Server on the current task file and returns a client
+// connected to it.
+func serve(t *testing.T) pb.TaskTrackerClient {
+	t.Helper()
+	server := NewServer()
+	server.PollInterval = 10 * time.Millisecond
+
+	listener := bufconn.Listen(1 << 20)
+	grpcServer := grpc.NewServer()
+	pb.RegisterTaskTrackerServer(grpcServer, server)
+	go grpcServer.Serve(listener)
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return pb.NewTaskTrackerClient(conn)
+}
+
+func TestServer_CRUD(t *testing.T) {
+	client := startServer(t)
+	ctx := context.Background()
+
+	due := time.Date(2026, 10, 20, 0, 0, 0, 0, time.Local)
+	created, err := client.CreateTask(ctx, &pb.CreateTaskRequest{Task: &pb.Task{
+		Title:       "Write report",
+		Description: "Quarterly",
+		Tags:        []string{"work"},
+		Priority:    pb.TaskPriority_TASK_PRIORITY_HIGH,
+		Due:         timestamppb.New(due),
+		Estimate:    "2h",
+		Status:      pb.TaskStatus_TASK_STATUS_COMPLETED,
+	}})
+	if err != nil {
+		t.Fatalf("CreateTask returned unexpected error: %v", err)
+	}
+	if created.GetStatus() != pb.TaskStatus_TASK_STATUS_PENDING || created.GetEstimate() != "2h" || !created.GetDue().AsTime().Equal(due) {
+		t.Errorf("Unexpected created task %v", created)
+	}
+	if _, err := client.CreateTask(ctx, &pb.CreateTaskRequest{Task: &pb.Task{Title: "Write report"}}); status.Code(err) != codes.AlreadyExists {
+		t.Errorf("Expected AlreadyExists for duplicate title, got %v", err)
+	}
+	client.CreateTask(ctx, &pb.CreateTaskRequest{Task: &pb.Task{Title: "Call mom"}})
+
+	list, err := client.ListTasks(ctx, &pb.ListTasksRequest{Filter: "tag:work"})
+	if err != nil || len(list.GetTasks()) != 1 {
+		t.Errorf("ListTasks filtered = %v, %v", list, err)
+	}
+	list, _ = client.ListTasks(ctx, &pb.ListTasksRequest{Sort: "title"})
+	if len(list.GetTasks()) != 2 || list.GetTasks()[0].GetTitle() != "Call mom" {
+		t.Errorf("ListTasks sorted = %v", list)
+	}
+	if task, err := client.GetTask(ctx, &pb.GetTaskRequest{Task: created.GetId()}); err != nil || task.GetTitle() != "Write report" {
+		t.Errorf("GetTask by ID = %v, %v", task, err)
+	}
+	if task, err := client.GetTask(ctx, &pb.GetTaskRequest{Task: created.GetId()[:8]}); err != nil || task.GetTitle() != "Write report" {
+		t.Errorf("GetTask by ID prefix = %v, %v", task, err)
+	}
+
+	// With a mask, unset fields are cleared; without one, set fields change
+	updated, err := client.UpdateTask(ctx, &pb.UpdateTaskRequest{
+		Task:       "Write report",
+		Fields:     &pb.Task{Title: "Write annual report"},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"title", "description"}},
+	})
+	if err != nil || updated.GetTitle() != "Write annual report" || updated.GetDescription() != "" || updated.GetId() != created.GetId() {
+		t.Errorf("UpdateTask with mask = %v, %v", updated, err)
+	}
+	updated, err = client.UpdateTask(ctx, &pb.UpdateTaskRequest{Task: created.GetId(), Fields: &pb.Task{Priority: pb.TaskPriority_TASK_PRIORITY_LOW, Id: "ignored"}})
+	if err != nil || updated.GetPriority() != pb.TaskPriority_TASK_PRIORITY_LOW || len(updated.GetTags()) != 1 {
+		t.Errorf("UpdateTask without mask = %v, %v", updated, err)
+	}
+	if _, err := client.UpdateTask(ctx, &pb.UpdateTaskRequest{Task: "Call mom", Fields: &pb.Task{Title: "Write annual report"}}); status.Code(err) != codes.AlreadyExists {
+		t.Errorf("Expected AlreadyExists for rename conflict, got %v", err)
+	}
+
+	if task, err := client.StartTask(ctx, &pb.StartTaskRequest{Task: "Call mom"}); err != nil || task.GetStatus() != pb.TaskStatus_TASK_STATUS_IN_PROGRESS || task.GetStartedAt() == nil {
+		t.Errorf("StartTask = %v, %v", task, err)
+	}
+	if task, err := client.CompleteTask(ctx, &pb.CompleteTaskRequest{Task: "Call mom"}); err != nil || task.GetStatus() != pb.TaskStatus_TASK_STATUS_COMPLETED || task.GetCompletedAt() == nil {
+		t.Errorf("CompleteTask = %v, %v", task, err)
+	}
+	if task, err := client.ReopenTask(ctx, &pb.ReopenTaskRequest{Task: "Call mom"}); err != nil || task.GetStatus() != pb.TaskStatus_TASK_STATUS_PENDING || task.GetCompletedAt() != nil {
+		t.Errorf("ReopenTask = %v, %v", task, err)
+	}
+	if _, err := client.ReopenTask(ctx, &pb.ReopenTaskRequest{Task: "Call mom"}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("ReopenTask of a pending task error = %v, want FailedPrecondition", err)
+	}
+
+	if _, err := client.DeleteTask(ctx, &pb.DeleteTaskRequest{Task: "Call mom"}); err != nil {
+		t.Errorf("DeleteTask returned unexpected error: %v", err)
+	}
+	if _, err := client.GetTask(ctx, &pb.GetTaskRequest{Task: "Call mom"}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound after delete, got %v", err)
+	}
+}
+
+func TestServer_Errors(t *testing.T) {
+	client := startServer(t)
+	ctx := context.Background()
+	client.CreateTask(ctx, &pb.CreateTaskRequest{Task: &pb.Task{Title: "Existing"}})
+
+	tests := []struct {
+		name string
+		call func() error
+		want codes.Code
+	}{
+		{name: "Invalid filter", want: codes.InvalidArgument, call: func() error {
+			_, err := client.ListTasks(ctx, &pb.ListTasksRequest{Filter: "priority>"})
+			return err
+		}},
+		{name: "Invalid sort", want: codes.InvalidArgument, call: func() error {
+			_, err := client.ListTasks(ctx, &pb.ListTasksRequest{Sort: "color"})
+			return err
+		}},
+		{name: "Missing title", want: codes.InvalidArgument, call: func() error {
+			_, err := client.CreateTask(ctx, &pb.CreateTaskRequest{Task: &pb.Task{Description: "no title"}})
+			return err
+		}},
+		{name: "Invalid priority", want: codes.InvalidArgument, call: func() error {
+			_, err := client.CreateTask(ctx, &pb.CreateTaskRequest{Task: &pb.Task{Title: "X", Priority: 42}})
+			return err
+		}},
+		{name: "Invalid recurrence", want: codes.InvalidArgument, call: func() error {
+			_, err := client.CreateTask(ctx, &pb.CreateTaskRequest{Task: &pb.Task{Title: "X", Recurrence: "sometimes"}})
+			return err
+		}},
+		{name: "Read-only field in mask", want: codes.InvalidArgument, call: func() error {
+			_, err := client.UpdateTask(ctx, &pb.UpdateTaskRequest{Task: "Existing", UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"status"}}})
+			return err
+		}},
+		{name: "Empty title", want: codes.InvalidArgument, call: func() error {
+			_, err := client.UpdateTask(ctx, &pb.UpdateTaskRequest{Task: "Existing", UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"title"}}})
+			return err
+		}},
+		{name: "Unknown task", want: codes.NotFound, call: func() error {
+			_, err := client.StartTask(ctx, &pb.StartTaskRequest{Task: "Missing"})
+			return err
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.call(); status.Code(err) != tt.want {
+				t.Errorf("Expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestServer_WatchTasks(t *testing.T) {
+	client := startServer(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	client.CreateTask(ctx, &pb.CreateTaskRequest{Task: &pb.Task{Title: "Existing", Tags: []string{"work"}}})
+
+	stream, err := client.WatchTasks(ctx, &pb.WatchTasksRequest{Filter: "tag:work", SendInitial: true})
+	if err != nil {
+		t.Fatalf("WatchTasks returned unexpected error: %v", err)
+	}
+	expect := func(kind pb.TaskEventType, title string) {
+		t.Helper()
+		event, err := stream.Recv()
+		if err != nil {
+			t.Fatalf("Recv returned unexpected error: %v", err)
+		}
+		if event.GetType() != kind || event.GetTask().GetTitle() != title {
+			t.Fatalf("Got %v %q, want %v %q", event.GetType(), event.GetTask().GetTitle(), kind, title)
+		}
+	}
+	expect(pb.TaskEventType_TASK_EVENT_TYPE_CREATED, "Existing")
+
+	// Changes to tasks outside the filter are not reported
+	client.CreateTask(ctx, &pb.CreateTaskRequest{Task: &pb.Task{Title: "Personal"}})
+	client.CreateTask(ctx, &pb.CreateTaskRequest{Task: &pb.Task{Title: "Report", Tags: []string{"work"}}})
+	expect(pb.TaskEventType_TASK_EVENT_TYPE_CREATED, "Report")
+	client.StartTask(ctx, &pb.StartTaskRequest{Task: "Report"})
+	expect(pb.TaskEventType_TASK_EVENT_TYPE_UPDATED, "Report")
+
+	// Changes made by the CLI are picked up by polling
+	taskService := loadService(t)
+	taskService.CompleteTask("Existing")
+	taskService.SaveTasks()
+	expect(pb.TaskEventType_TASK_EVENT_TYPE_UPDATED, "Existing")
+
+	client.DeleteTask(ctx, &pb.DeleteTaskRequest{Task: "Report"})
+	expect(pb.TaskEventType_TASK_EVENT_TYPE_DELETED, "Report")
+
+	invalid, _ := client.WatchTasks(ctx, &pb.WatchTasksRequest{Filter: "and"})
+	if _, err := invalid.Recv(); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for invalid filter, got %v", err)
+	}
+}
+
+func TestServer_LoadError(t *testing.T) {
+	path := useTempTaskFile(t)
+	client := serve(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	client.CreateTask(ctx, &pb.CreateTaskRequest{Task: &pb.Task{Title: "Existing"}})
+	stream, err := client.WatchTasks(ctx, &pb.WatchTasksRequest{SendInitial: true})
+	if err != nil {
+		t.Fatalf("WatchTasks returned unexpected error: %v", err)
+	}
+	if _, err := stream.Recv(); err != nil {
+		t.Fatalf("Recv returned unexpected error: %v", err)
+	}
+
+	saved, _ := os.ReadFile(path)
+	os.WriteFile(path, saved[:len(saved)/2], 0644)
+	if _, err := client.ListTasks(ctx, &pb.ListTasksRequest{}); status.Code(err) != codes.Internal {
+		t.Errorf("Expected Internal for an unreadable task store, got %v", err)
+	}
+	if _, err := client.CompleteTask(ctx, &pb.CompleteTaskRequest{Task: "Existing"}); status.Code(err) != codes.Internal {
+		t.Errorf("Expected Internal for an unreadable task store, got %v", err)
+	}
+	// Let the poller run into the unreadable store before it is restored
+	time.Sleep(50 * time.Millisecond)
+	if data, _ := os.ReadFile(path); len(data) != len(saved)/2 {
+		t.Errorf("Expected the unreadable task store to be left alone, got %q", data)
+	}
+	os.WriteFile(path, saved, 0644)
+
+	// Tasks are not reported deleted while the store cannot be loaded
+	client.CreateTask(ctx, &pb.CreateTaskRequest{Task: &pb.Task{Title: "New"}})
+	event, err := stream.Recv()
+	if err != nil || event.GetType() != pb.TaskEventType_TASK_EVENT_TYPE_CREATED || event.GetTask().GetTitle() != "New" {
+		t.Errorf("Recv = %v, %v, want the new task created", event, err)
+	}
+}
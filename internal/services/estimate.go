@@ -0,0 +1,66 @@
+package services
+
+import (
+	"time"
+
+	"github.com/savabush/taskTracker/pkg/tasktracker/model"
+)
+
+// WorkDay is the length of a "d" unit in duration estimates.
+const WorkDay = model.WorkDay
+
+// Estimate is the expected effort for a task, either as a duration or in
+// story points. It is stored as text such as "2h30m" or "5pt".
+type Estimate = model.Estimate
+
+// ParseEstimate parses an estimate; see model.ParseEstimate.
+func ParseEstimate(value string) (Estimate, error) {
+	return model.ParseEstimate(value)
+}
+
+// Effort sums estimated and logged effort over a set of tasks. Remaining is
+// the estimated time not yet logged on open tasks with duration estimates.
+type Effort struct {
+	Tasks       int           `json:"tasks"`
+	Unestimated int           `json:"unestimated"`
+	Estimated   time.Duration `json:"-"`
+	Points      float64       `json:"estimated_points"`
+	Logged      time.Duration `json:"-"`
+	Remaining   time.Duration `json:"-"`
+
+	EstimatedHours float64 `json:"estimated_hours"`
+	LoggedHours    float64 `json:"logged_hours"`
+	RemainingHours float64 `json:"remaining_hours"`
+}
+
+// SummarizeEffort computes the effort totals for tasks, counting running
+// timers up to now.
+func SummarizeEffort(tasks []Task, now time.Time) Effort {
+	var effort Effort
+	for _, task := range tasks {
+		effort.Tasks++
+		logged := task.LoggedTime(now)
+		effort.Logged += logged
+
+		switch {
+		case task.Estimate.IsZero():
+			effort.Unestimated++
+		case task.Estimate.Points > 0:
+			effort.Points += task.Estimate.Points
+		default:
+			effort.Estimated += task.Estimate.Duration
+			if task.Status != TaskStatusCompleted && task.Estimate.Duration > logged {
+				effort.Remaining += task.Estimate.Duration - logged
+			}
+		}
+	}
+	effort.EstimatedHours = effort.Estimated.Hours()
+	effort.LoggedHours = effort.Logged.Hours()
+	effort.RemainingHours = effort.Remaining.Hours()
+	return effort
+}
+
+// HasData reports whether any task in the set was estimated or tracked.
+func (e Effort) HasData() bool {
+	return e.Estimated > 0 || e.Points > 0 || e.Logged > 0
+}
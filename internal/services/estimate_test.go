@@ -0,0 +1,102 @@
+package services
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestParseEstimate(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    Estimate
+		str     string
+		wantErr bool
+	}{
+		{value: "", want: Estimate{}, str: ""},
+		{value: "90m", want: Estimate{Duration: 90 * time.Minute}, str: "1h30m"},
+		{value: "1.5h", want: Estimate{Duration: 90 * time.Minute}, str: "1h30m"},
+		{value: "2h", want: Estimate{Duration: 2 * time.Hour}, str: "2h"},
+		{value: "2d", want: Estimate{Duration: 16 * time.Hour}, str: "16h"},
+		{value: "5pt", want: Estimate{Points: 5}, str: "5pt"},
+		{value: "0.5 points", want: Estimate{Points: 0.5}, str: "0.5pt"},
+		{value: "3SP", want: Estimate{Points: 3}, str: "3pt"},
+		{value: "soon", wantErr: true},
+		{value: "-1h", wantErr: true},
+		{value: "0pt", wantErr: true},
+		{value: "xd", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseEstimate(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseEstimate(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ParseEstimate(%q) = %+v, want %+v", tt.value, got, tt.want)
+			}
+			if got.String() != tt.str {
+				t.Errorf("String() = %q, want %q", got.String(), tt.str)
+			}
+		})
+	}
+}
+
+func TestEstimateJSON(t *testing.T) {
+	task := Task{Title: "Task", Estimate: Estimate{Duration: 150 * time.Minute}}
+	data, err := json.Marshal(task)
+	if err != nil {
+		t.Fatalf("Marshal returned unexpected error: %v", err)
+	}
+
+	var decoded Task
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal returned unexpected error: %v", err)
+	}
+	if decoded.Estimate != task.Estimate {
+		t.Errorf("Estimate not preserved: got %+v, want %+v", decoded.Estimate, task.Estimate)
+	}
+
+	// Tasks without an estimate omit the field
+	data, _ = json.Marshal(Task{Title: "Task"})
+	var fields map[string]any
+	json.Unmarshal(data, &fields)
+	if _, ok := fields["estimate"]; ok {
+		t.Errorf("Expected estimate to be omitted, got %s", data)
+	}
+}
+
+func TestSummarizeEffort(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.Local)
+	tasks := []Task{
+		{
+			Title: "a", Status: TaskStatusInProgress, Estimate: Estimate{Duration: 4 * time.Hour},
+			TimeEntries: []TimeEntry{{Start: now.Add(-time.Hour), End: now}},
+		},
+		{
+			Title: "b", Status: TaskStatusCompleted, Estimate: Estimate{Duration: 2 * time.Hour},
+			TimeEntries: []TimeEntry{{Start: now.Add(-5 * time.Hour), End: now.Add(-2 * time.Hour)}},
+		},
+		{Title: "c", Status: TaskStatusPending, Estimate: Estimate{Points: 3}},
+		{Title: "d", Status: TaskStatusPending},
+	}
+
+	effort := SummarizeEffort(tasks, now)
+	if effort.Tasks != 4 || effort.Unestimated != 1 {
+		t.Errorf("Expected 4 tasks with 1 unestimated, got %+v", effort)
+	}
+	if effort.Estimated != 6*time.Hour || effort.Points != 3 {
+		t.Errorf("Expected 6h + 3pt estimated, got %v + %v", effort.Estimated, effort.Points)
+	}
+	if effort.Logged != 4*time.Hour {
+		t.Errorf("Expected 4h logged, got %v", effort.Logged)
+	}
+	// Only open tasks count towards the remaining effort
+	if effort.Remaining != 3*time.Hour {
+		t.Errorf("Expected 3h remaining, got %v", effort.Remaining)
+	}
+}
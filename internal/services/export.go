@@ -0,0 +1,213 @@
+package services
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// ExportFormats lists the formats accepted by ExportTasks.
+var ExportFormats = []string{FormatCSV, FormatJSON, FormatTodoTxt, FormatMarkdown, FormatICS}
+
+// ExportTasks writes tasks in the given format. CSV, JSON, todo.txt and
+// Markdown exports can be read back with ParseImport. now is used as the
+// iCalendar DTSTAMP.
+func ExportTasks(w io.Writer, tasks []Task, format string, now time.Time) error {
+	switch format {
+	case FormatCSV:
+		return exportCSV(w, tasks)
+	case FormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(tasks)
+	case FormatTodoTxt:
+		for _, task := range tasks {
+			if _, err := fmt.Fprintln(w, FormatTodoTxtLine(task)); err != nil {
+				return err
+			}
+		}
+		return nil
+	case FormatMarkdown:
+		return exportMarkdown(w, tasks)
+	case FormatICS:
+		return exportICS(w, tasks, now)
+	}
+	return errors.New("format must be one of: " + strings.Join(ExportFormats, ", "))
+}
+
+func exportCSV(w io.Writer, tasks []Task) error {
+	writer := csv.NewWriter(w)
+	header := append([]string{"id"}, ImportFields...)
+	header = append(header, "created", "updated", "completed")
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	timestamp := func(t time.Time) string {
+		if t.IsZero() {
+			return ""
+		}
+		return t.Format(time.RFC3339)
+	}
+	for _, task := range tasks {
+		due := ""
+		if !task.Due.IsZero() {
+			due = task.Due.Format(DateLayout)
+		}
+		// Columns follow ImportFields so that the export can be imported
+		err := writer.Write([]string{
+			task.ID,
+			task.Title,
+			task.Description,
+			strings.Join(task.Notes, "\n"),
+			strings.Join(task.Tags, ","),
+			string(task.Priority),
+			due,
+			string(task.Status),
+			task.Estimate.String(),
+			task.Recurrence,
+			timestamp(task.CreatedAt),
+			timestamp(task.UpdatedAt),
+			timestamp(task.CompletedAt),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func exportMarkdown(w io.Writer, tasks []Task) error {
+	for _, task := range tasks {
+		mark := " "
+		switch task.Status {
+		case TaskStatusCompleted:
+			mark = "x"
+		case TaskStatusInProgress:
+			mark = "/"
+		}
+		line := "- [" + mark + "] " + strings.ReplaceAll(task.Title, "#", `\#`)
+		for _, tag := range task.Tags {
+			line += " #" + tag
+		}
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// icsPriorities maps task priorities to iCalendar PRIORITY values, where 1
+// is the highest and 9 the lowest.
+var icsPriorities = map[TaskPriority]int{
+	TaskPriorityCritical: 1,
+	TaskPriorityHigh:     3,
+	TaskPriorityMedium:   5,
+	TaskPriorityLow:      9,
+}
+
+var icsStatuses = map[TaskStatus]string{
+	TaskStatusPending:    "NEEDS-ACTION",
+	TaskStatusInProgress: "IN-PROCESS",
+	TaskStatusCompleted:  "COMPLETED",
+}
+
+// exportICS writes tasks as an RFC 5545 calendar of VTODO components.
+func exportICS(w io.Writer, tasks []Task, now time.Time) error {
+	ics := &icsWriter{w: w}
+	utc := func(t time.Time) string {
+		return t.UTC().Format("20060102T150405Z")
+	}
+
+	ics.line("BEGIN:VCALENDAR")
+	ics.line("VERSION:2.0")
+	ics.line("PRODID:-//taskTracker//EN")
+	ics.line("CALSCALE:GREGORIAN")
+	for _, task := range tasks {
+		ics.line("BEGIN:VTODO")
+		ics.line("UID:" + task.ID + "@taskTracker")
+		ics.line("DTSTAMP:" + utc(now))
+		ics.line("SUMMARY:" + icsEscape(task.Title))
+		if description := strings.Join(append([]string{task.Description}, task.Notes...), "\n\n"); strings.TrimSpace(description) != "" {
+			ics.line("DESCRIPTION:" + icsEscape(strings.TrimSpace(description)))
+		}
+		if len(task.Tags) > 0 {
+			tags := make([]string, len(task.Tags))
+			for i, tag := range task.Tags {
+				tags[i] = icsEscape(tag)
+			}
+			ics.line("CATEGORIES:" + strings.Join(tags, ","))
+		}
+		if priority, ok := icsPriorities[task.Priority]; ok {
+			ics.line(fmt.Sprintf("PRIORITY:%d", priority))
+		}
+		if !task.Due.IsZero() {
+			if rule, err := ParseRecurrence(task.Recurrence); task.Recurrence != "" && err == nil {
+				// RRULE requires DTSTART, and DUE would have to come after
+				// it, so recurring tasks span their due date instead
+				ics.line("DTSTART;VALUE=DATE:" + task.Due.Format("20060102"))
+				ics.line("DURATION:P1D")
+				ics.line("RRULE:" + rule.String())
+			} else {
+				ics.line("DUE;VALUE=DATE:" + task.Due.Format("20060102"))
+			}
+		}
+		if status, ok := icsStatuses[task.Status]; ok {
+			ics.line("STATUS:" + status)
+		}
+		if !task.CompletedAt.IsZero() {
+			ics.line("COMPLETED:" + utc(task.CompletedAt))
+		}
+		if !task.CreatedAt.IsZero() {
+			ics.line("CREATED:" + utc(task.CreatedAt))
+		}
+		if !task.UpdatedAt.IsZero() {
+			ics.line("LAST-MODIFIED:" + utc(task.UpdatedAt))
+		}
+		ics.line("END:VTODO")
+	}
+	ics.line("END:VCALENDAR")
+	return ics.err
+}
+
+// icsWriter writes CRLF-terminated content lines, folding lines longer than
+// 75 octets without splitting UTF-8 sequences.
+type icsWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (iw *icsWriter) line(s string) {
+	if iw.err != nil {
+		return
+	}
+	var b strings.Builder
+	limit := 75
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !isRuneStart(s[cut]) {
+			cut--
+		}
+		b.WriteString(s[:cut] + "\r\n ")
+		s = s[cut:]
+		// Continuation lines start with a space that counts towards the limit
+		limit = 74
+	}
+	b.WriteString(s + "\r\n")
+	_, iw.err = io.WriteString(iw.w, b.String())
+}
+
+func isRuneStart(b byte) bool {
+	return b&0xC0 != 0x80
+}
+
+var icsEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+func icsEscape(s string) string {
+	return icsEscaper.Replace(s)
+}
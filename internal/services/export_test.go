@@ -0,0 +1,158 @@
+package services
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func exportTestTasks() []Task {
+	created := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	return []Task{
+		{
+			ID:          "id-1",
+			Title:       "Pay rent",
+			Description: "Transfer; to landlord, by 1st",
+			Notes:       []string{"IBAN in wiki", "Ask about deposit"},
+			Tags:        []string{"home", "bills"},
+			Priority:    TaskPriorityHigh,
+			Due:         time.Date(2026, 11, 1, 0, 0, 0, 0, time.Local),
+			Recurrence:  "monthly",
+			Estimate:    Estimate{Duration: 15 * time.Minute},
+			Status:      TaskStatusPending,
+			CreatedAt:   created,
+			UpdatedAt:   created,
+		},
+		{
+			ID:          "id-2",
+			Title:       "Review PR #42",
+			Tags:        []string{"work"},
+			Status:      TaskStatusCompleted,
+			CreatedAt:   created,
+			UpdatedAt:   created,
+			CompletedAt: created.Add(time.Hour),
+		},
+		{
+			ID:        "id-3",
+			Title:     "Write docs",
+			Status:    TaskStatusInProgress,
+			CreatedAt: created,
+			UpdatedAt: created,
+		},
+	}
+}
+
+func TestExportTasksRoundTrip(t *testing.T) {
+	tasks := exportTestTasks()
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	for _, format := range []string{FormatCSV, FormatJSON, FormatTodoTxt, FormatMarkdown} {
+		t.Run(format, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := ExportTasks(&buf, tasks, format, now); err != nil {
+				t.Fatalf("ExportTasks returned unexpected error: %v", err)
+			}
+			imported, err := ParseImport(&buf, format, nil)
+			if err != nil {
+				t.Fatalf("ParseImport of export returned unexpected error: %v\n%s", err, buf.String())
+			}
+			if len(imported) != len(tasks) {
+				t.Fatalf("Expected %d tasks back, got %d", len(tasks), len(imported))
+			}
+			for i, task := range imported {
+				if task.Title != tasks[i].Title || len(task.Tags) != len(tasks[i].Tags) {
+					t.Errorf("Task %d = %q %v, want %q %v", i, task.Title, task.Tags, tasks[i].Title, tasks[i].Tags)
+				}
+				// todo.txt has no in-progress state
+				if task.Status != tasks[i].Status && !(format == FormatTodoTxt && tasks[i].Status == TaskStatusInProgress) {
+					t.Errorf("Task %q status = %s, want %s", task.Title, task.Status, tasks[i].Status)
+				}
+			}
+
+			if format == FormatCSV || format == FormatJSON {
+				rent := imported[0]
+				if rent.Description != tasks[0].Description || len(rent.Notes) != 2 || rent.Recurrence != "monthly" {
+					t.Errorf("Expected all fields to round trip, got %+v", rent)
+				}
+				if !rent.Due.Equal(tasks[0].Due) || rent.Estimate != tasks[0].Estimate || rent.Priority != TaskPriorityHigh {
+					t.Errorf("Expected due, estimate and priority to round trip, got %+v", rent)
+				}
+			}
+		})
+	}
+}
+
+func TestExportICS(t *testing.T) {
+	tasks := exportTestTasks()
+	tasks[2].Title = strings.Repeat("Very long title ", 8) + "é"
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	var buf bytes.Buffer
+	if err := ExportTasks(&buf, tasks, FormatICS, now); err != nil {
+		t.Fatalf("ExportTasks returned unexpected error: %v", err)
+	}
+	out := buf.String()
+	unfolded := strings.ReplaceAll(out, "\r\n ", "")
+
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\nVERSION:2.0\r\n",
+		"UID:id-1@taskTracker\r\n",
+		"DTSTAMP:20261019T120000Z\r\n",
+		"SUMMARY:Pay rent\r\n",
+		`DESCRIPTION:Transfer\; to landlord\, by 1st\n\nIBAN in wiki\n\nAsk about deposit` + "\r\n",
+		"CATEGORIES:home,bills\r\n",
+		"PRIORITY:3\r\n",
+		"DTSTART;VALUE=DATE:20261101\r\nDURATION:P1D\r\nRRULE:FREQ=MONTHLY\r\n",
+		"STATUS:NEEDS-ACTION\r\n",
+		"STATUS:COMPLETED\r\nCOMPLETED:20261001T100000Z\r\n",
+		"STATUS:IN-PROCESS\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(unfolded, want) {
+			t.Errorf("Expected ICS to contain %q", want)
+		}
+	}
+	if strings.Count(out, "BEGIN:VTODO") != 3 || strings.Count(out, "END:VTODO") != 3 {
+		t.Errorf("Expected 3 VTODO components")
+	}
+
+	// Long lines are folded at 75 octets
+	for _, line := range strings.Split(strings.TrimSuffix(out, "\r\n"), "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("Line longer than 75 octets: %q", line)
+		}
+	}
+	if !strings.Contains(unfolded, "SUMMARY:"+tasks[2].Title+"\r\n") {
+		t.Errorf("Expected folded summary to unfold to the title")
+	}
+}
+
+func TestExportICSDue(t *testing.T) {
+	tasks := exportTestTasks()[:1]
+	oneOff := tasks[0]
+	oneOff.ID, oneOff.Recurrence = "id-4", ""
+	tasks = append(tasks, oneOff)
+
+	var buf bytes.Buffer
+	if err := ExportTasks(&buf, tasks, FormatICS, time.Now()); err != nil {
+		t.Fatalf("ExportTasks returned unexpected error: %v", err)
+	}
+	todos := strings.Split(buf.String(), "BEGIN:VTODO")[1:]
+
+	// RFC 5545 forbids DUE together with DURATION, and a DUE that is not
+	// after DTSTART
+	recurring := todos[0]
+	if strings.Contains(recurring, "DUE") || !strings.Contains(recurring, "DTSTART;VALUE=DATE:20261101\r\n") || !strings.Contains(recurring, "DURATION:P1D\r\n") {
+		t.Errorf("Expected the recurring task to start on its due date and last a day, got %q", recurring)
+	}
+	if strings.Contains(todos[1], "DTSTART") || strings.Contains(todos[1], "DURATION") || !strings.Contains(todos[1], "DUE;VALUE=DATE:20261101\r\n") {
+		t.Errorf("Expected the one-off task to have only a due date, got %q", todos[1])
+	}
+}
+
+func TestExportTasksUnknownFormat(t *testing.T) {
+	if err := ExportTasks(&bytes.Buffer{}, nil, "xml", time.Now()); err == nil {
+		t.Errorf("Expected error for unknown format")
+	}
+}
@@ -0,0 +1,122 @@
+package services
+
+import (
+	"bufio"
+	"io"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// gitIgnore matches paths against the patterns of .gitignore files. Paths
+// are slash-separated and relative to the root of the scanned tree.
+type gitIgnore struct {
+	rules []ignoreRule
+}
+
+// ignoreRule is one pattern of a .gitignore file.
+type ignoreRule struct {
+	// dir is the directory of the .gitignore file, "" for the root.
+	dir      string
+	pattern  *regexp.Regexp
+	anchored bool
+	negate   bool
+	dirOnly  bool
+}
+
+// add reads the patterns of the .gitignore file in dir. Patterns added later
+// take precedence, as those of nested .gitignore files do in git.
+func (g *gitIgnore) add(dir string, r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		rule := ignoreRule{dir: dir}
+		if strings.HasPrefix(line, "!") {
+			rule.negate, line = true, line[1:]
+		} else if strings.HasPrefix(line, `\!`) || strings.HasPrefix(line, `\#`) {
+			line = line[1:]
+		}
+		if strings.HasSuffix(line, "/") {
+			rule.dirOnly, line = true, strings.TrimRight(line, "/")
+		}
+		// A slash other than a trailing one anchors the pattern to dir
+		rule.anchored = strings.Contains(line, "/")
+		line = strings.TrimPrefix(line, "/")
+		if line == "" {
+			continue
+		}
+		pattern, err := regexp.Compile("^" + globRegexp(line) + "$")
+		if err != nil {
+			continue
+		}
+		rule.pattern = pattern
+		g.rules = append(g.rules, rule)
+	}
+	return scanner.Err()
+}
+
+// ignored reports whether the file or directory at name is ignored. The last
+// matching pattern decides, so a negated pattern can re-include a path.
+func (g *gitIgnore) ignored(name string, isDir bool) bool {
+	ignored := false
+	for _, rule := range g.rules {
+		if rule.dirOnly && !isDir {
+			continue
+		}
+		rel := name
+		if rule.dir != "" {
+			var ok bool
+			if rel, ok = strings.CutPrefix(name, rule.dir+"/"); !ok {
+				continue
+			}
+		}
+		if !rule.anchored {
+			rel = path.Base(rel)
+		}
+		if rule.pattern.MatchString(rel) {
+			ignored = !rule.negate
+		}
+	}
+	return ignored
+}
+
+// globRegexp translates a gitignore glob to a regular expression: * and ?
+// do not match a slash, ** matches any number of directories.
+func globRegexp(glob string) string {
+	var b strings.Builder
+	for i := 0; i < len(glob); i++ {
+		switch c := glob[i]; {
+		case strings.HasPrefix(glob[i:], "**/"):
+			b.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(glob[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case c == '*':
+			b.WriteString("[^/]*")
+		case c == '?':
+			b.WriteString("[^/]")
+		case c == '[':
+			end := strings.IndexByte(glob[i+1:], ']')
+			if end < 0 {
+				b.WriteString(`\[`)
+				continue
+			}
+			class := glob[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			b.WriteString("[" + strings.ReplaceAll(class, `\`, `\\`) + "]")
+			i += end + 1
+		case c == '\\' && i+1 < len(glob):
+			i++
+			b.WriteString(regexp.QuoteMeta(glob[i : i+1]))
+		default:
+			b.WriteString(regexp.QuoteMeta(glob[i : i+1]))
+		}
+	}
+	return b.String()
+}
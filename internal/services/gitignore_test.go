@@ -0,0 +1,45 @@
+package services
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGitIgnore(t *testing.T) {
+	ignore := &gitIgnore{}
+	ignore.add("", strings.NewReader(`# build output
+/build
+*.log
+!keep.log
+vendor/
+docs/**/*.tmp
+`))
+	ignore.add("web", strings.NewReader("dist\n"))
+
+	tests := []struct {
+		name  string
+		isDir bool
+		want  bool
+	}{
+		{name: "build", isDir: true, want: true},
+		{name: "src/build", isDir: true, want: false},
+		{name: "debug.log", want: true},
+		{name: "logs/app/debug.log", want: true},
+		{name: "logs/keep.log", want: false},
+		{name: "vendor", isDir: true, want: true},
+		{name: "vendor", isDir: false, want: false},
+		{name: "docs/a/b/notes.tmp", want: true},
+		{name: "notes.tmp", want: false},
+		{name: "web/dist", isDir: true, want: true},
+		{name: "dist", isDir: true, want: false},
+		{name: "main.go", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ignore.ignored(tt.name, tt.isDir); got != tt.want {
+				t.Errorf("ignored(%q, %v) = %v, want %v", tt.name, tt.isDir, got, tt.want)
+			}
+		})
+	}
+}
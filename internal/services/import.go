@@ -0,0 +1,397 @@
+package services
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Import and export formats.
+const (
+	FormatCSV      = "csv"
+	FormatJSON     = "json"
+	FormatJSONL    = "jsonl"
+	FormatTodoTxt  = "todotxt"
+	FormatMarkdown = "markdown-checklist"
+	FormatICS      = "ics"
+)
+
+// ImportFormats lists the formats accepted by ParseImport.
+var ImportFormats = []string{FormatCSV, FormatJSON, FormatJSONL, FormatTodoTxt, FormatMarkdown}
+
+// ImportFields lists the task fields CSV columns can be mapped to.
+var ImportFields = []string{"title", "description", "notes", "tags", "priority", "due", "status", "estimate", "recurrence"}
+
+// ParseImport reads tasks in the given format. The returned tasks are drafts:
+// IDs, and the timestamps they lack, are assigned when they are imported.
+// mapping maps CSV column headers to task fields and is ignored by the other
+// formats; columns named like a field are mapped to it by default.
+func ParseImport(r io.Reader, format string, mapping map[string]string) ([]Task, error) {
+	switch format {
+	case FormatCSV:
+		return parseCSVImport(r, mapping)
+	case FormatJSON:
+		return parseJSONImport(r)
+	case FormatJSONL:
+		return parseLineImport(r, func(line string) (Task, error) {
+			var task Task
+			err := json.Unmarshal([]byte(line), &task)
+			return task, err
+		})
+	case FormatTodoTxt:
+		return parseLineImport(r, ParseTodoTxtLine)
+	case FormatMarkdown:
+		return parseMarkdownImport(r)
+	}
+	return nil, errors.New("format must be one of: " + strings.Join(ImportFormats, ", "))
+}
+
+// ValidateImportMapping checks that every CSV column is mapped to a known
+// task field.
+func ValidateImportMapping(mapping map[string]string) error {
+	for column, field := range mapping {
+		if !slices.Contains(ImportFields, strings.ToLower(field)) {
+			return fmt.Errorf("cannot map column %q to unknown field %q, expected one of: %s", column, field, strings.Join(ImportFields, ", "))
+		}
+	}
+	return nil
+}
+
+func parseCSVImport(r io.Reader, mapping map[string]string) ([]Task, error) {
+	if err := ValidateImportMapping(mapping); err != nil {
+		return nil, err
+	}
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make([]string, len(header))
+	for i, name := range header {
+		// Spreadsheet exports often start with a byte order mark
+		name = strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))
+		for column, field := range mapping {
+			if strings.EqualFold(column, name) {
+				columns[i] = strings.ToLower(field)
+			}
+		}
+		if columns[i] == "" && slices.Contains(ImportFields, strings.ToLower(name)) {
+			columns[i] = strings.ToLower(name)
+		}
+	}
+	if !slices.Contains(columns, "title") {
+		return nil, errors.New("CSV has no title column; map one with e.g. --map Name=title")
+	}
+
+	var tasks []Task
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+
+		task := Task{Status: TaskStatusPending}
+		for i, value := range record {
+			if i >= len(columns) || columns[i] == "" {
+				continue
+			}
+			if err := setImportField(&task, columns[i], strings.TrimSpace(value)); err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+		}
+		if err := validateImportedTask(&task); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, nil
+}
+
+// setImportField sets one task field from its text representation.
+func setImportField(task *Task, field, value string) error {
+	if value == "" {
+		return nil
+	}
+	switch field {
+	case "title":
+		task.Title = value
+	case "description":
+		task.Description = value
+	case "notes":
+		// Multiple notes are written one per line
+		for _, note := range strings.Split(value, "\n") {
+			if note = strings.TrimSpace(note); note != "" {
+				task.Notes = append(task.Notes, note)
+			}
+		}
+	case "tags":
+		task.Tags = append(task.Tags, strings.FieldsFunc(value, func(r rune) bool {
+			return r == ',' || r == ';' || r == ' '
+		})...)
+	case "priority":
+		task.Priority = TaskPriority(strings.ToLower(value))
+	case "due":
+		due, err := parseImportDate(value)
+		if err != nil {
+			return err
+		}
+		task.Due = due
+	case "status":
+		status, err := parseImportStatus(value)
+		if err != nil {
+			return err
+		}
+		task.Status = status
+	case "estimate":
+		estimate, err := ParseEstimate(value)
+		if err != nil {
+			return err
+		}
+		task.Estimate = estimate
+	case "recurrence":
+		task.Recurrence = value
+	}
+	return nil
+}
+
+// parseImportDate accepts YYYY-MM-DD and RFC 3339 timestamps, keeping only
+// the date.
+func parseImportDate(value string) (time.Time, error) {
+	if date, err := time.ParseInLocation(DateLayout, value, time.Local); err == nil {
+		return date, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		t = t.Local()
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid due date %q, expected YYYY-MM-DD", value)
+}
+
+// parseImportStatus maps the status names used by common task tools to task
+// statuses.
+func parseImportStatus(value string) (TaskStatus, error) {
+	switch strings.ToLower(strings.NewReplacer(" ", "", "-", "", "_", "").Replace(value)) {
+	case "pending", "todo", "open", "new", "backlog":
+		return TaskStatusPending, nil
+	case "inprogress", "doing", "started", "active":
+		return TaskStatusInProgress, nil
+	case "completed", "complete", "done", "closed", "x", "true", "yes":
+		return TaskStatusCompleted, nil
+	}
+	return "", fmt.Errorf("unknown status %q", value)
+}
+
+// validateImportedTask checks the fields of a parsed task, defaulting an
+// empty status to pending.
+func validateImportedTask(task *Task) error {
+	task.Title = strings.TrimSpace(task.Title)
+	if task.Title == "" {
+		return errors.New("task title cannot be empty")
+	}
+	if task.Status == "" {
+		task.Status = TaskStatusPending
+	}
+	if !task.Status.IsValid() {
+		return fmt.Errorf("unknown status %q", task.Status)
+	}
+	if task.Priority != "" && !task.Priority.IsValid() {
+		return errors.New("priority must be one of: low, medium, high, critical")
+	}
+	if task.Recurrence != "" {
+		if _, err := ParseRecurrence(task.Recurrence); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseJSONImport reads a JSON array of tasks or a tasks file written by
+// this tool.
+func parseJSONImport(r io.Reader) ([]Task, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var tasks []Task
+	if err := json.Unmarshal(data, &tasks); err != nil {
+		var wrapper struct {
+			Tasks map[string]Task `json:"tasks"`
+		}
+		if wrapperErr := json.Unmarshal(data, &wrapper); wrapperErr != nil || wrapper.Tasks == nil {
+			return nil, fmt.Errorf("expected a JSON array of tasks: %w", err)
+		}
+		tasks, _ = SortTasks(wrapper.Tasks, "")
+	}
+
+	for i := range tasks {
+		if err := validateImportedTask(&tasks[i]); err != nil {
+			return nil, fmt.Errorf("task %d: %w", i+1, err)
+		}
+	}
+	return tasks, nil
+}
+
+// errSkipLine is returned by line parsers for lines that hold no task.
+var errSkipLine = errors.New("skip line")
+
+// parseLineImport parses one task per non-blank line.
+func parseLineImport(r io.Reader, parse func(line string) (Task, error)) ([]Task, error) {
+	var tasks []Task
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		task, err := parse(text)
+		if err == errSkipLine {
+			continue
+		}
+		if err == nil {
+			err = validateImportedTask(&task)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, scanner.Err()
+}
+
+var (
+	checklistItem = regexp.MustCompile(`^\s*(?:[-*+]|\d+[.)])\s+\[([ xX/])\]\s+(.*)$`)
+	checklistTag  = regexp.MustCompile(`(^|\s)#([\p{L}\p{N}_-]+)`)
+)
+
+// parseMarkdownImport reads "- [ ] task" checklist items, ignoring all other
+// lines. Items checked with x are completed, items marked with / are in
+// progress, and #hashtags become tags. The field comments of the Markdown
+// storage are left out of the title.
+func parseMarkdownImport(r io.Reader) ([]Task, error) {
+	return parseLineImport(r, func(line string) (Task, error) {
+		match := checklistItem.FindStringSubmatch(line)
+		if match == nil {
+			return Task{}, errSkipLine
+		}
+		task := Task{Status: TaskStatusPending}
+		switch match[1] {
+		case "x", "X":
+			task.Status = TaskStatusCompleted
+		case "/":
+			task.Status = TaskStatusInProgress
+		}
+		text := match[2]
+		if comment := markdownComment.FindStringSubmatch(text); comment != nil {
+			text = comment[1]
+		}
+		task.Title, task.Tags = parseChecklistText(text)
+		return task, nil
+	})
+}
+
+// parseChecklistText splits the text of a checklist item into its title and
+// #hashtags. A # written as \# is part of the title.
+func parseChecklistText(text string) (string, []string) {
+	var tags []string
+	for _, tag := range checklistTag.FindAllStringSubmatch(text, -1) {
+		tags = append(tags, tag[2])
+	}
+	title := strings.Join(strings.Fields(checklistTag.ReplaceAllString(text, "$1")), " ")
+	return strings.ReplaceAll(title, `\#`, "#"), tags
+}
+
+// ImportPlan splits parsed tasks into new tasks and duplicates of existing
+// tasks or of earlier tasks in the same import.
+type ImportPlan struct {
+	New        []Task
+	Duplicates []Task
+}
+
+// PlanImport detects duplicates of existing tasks by comparing titles
+// case-insensitively and ignoring repeated whitespace.
+func PlanImport(existing, tasks []Task) ImportPlan {
+	seen := make(map[string]bool, len(existing))
+	for _, task := range existing {
+		seen[normalizeTitle(task.Title)] = true
+	}
+
+	var plan ImportPlan
+	for _, task := range tasks {
+		key := normalizeTitle(task.Title)
+		if seen[key] {
+			plan.Duplicates = append(plan.Duplicates, task)
+			continue
+		}
+		seen[key] = true
+		plan.New = append(plan.New, task)
+	}
+	return plan
+}
+
+// ImportTask adds an imported task through AddTask, so that it gets a fresh
+// ID like tasks added on the command line, and returns the added task. The
+// status, the logged time and the creation, start and completion times of
+// the source are kept; the times it lacks are set to the time of the import.
+// A running time entry is left out, since it would start a second timer.
+func (s *TaskService) ImportTask(draft Task) Task {
+	s.AddTask(draft.Title)
+	s.UpdateTask(draft.Title, func(task *Task) {
+		task.Description = draft.Description
+		task.Notes = draft.Notes
+		task.Tags = draft.Tags
+		task.Priority = draft.Priority
+		task.Due = draft.Due
+		task.Recurrence = draft.Recurrence
+		task.Estimate = draft.Estimate
+		task.Status = draft.Status
+		for _, entry := range draft.TimeEntries {
+			if !entry.End.IsZero() {
+				task.TimeEntries = append(task.TimeEntries, entry)
+			}
+		}
+		now := task.CreatedAt
+		if !draft.CreatedAt.IsZero() {
+			task.CreatedAt = draft.CreatedAt
+		}
+		if task.Status != TaskStatusPending {
+			task.StartedAt = draft.StartedAt
+		}
+		switch task.Status {
+		case TaskStatusInProgress:
+			if task.StartedAt.IsZero() {
+				task.StartedAt = now
+			}
+		case TaskStatusCompleted:
+			task.CompletedAt = draft.CompletedAt
+			if task.CompletedAt.IsZero() {
+				task.CompletedAt = now
+			}
+		}
+	})
+	task, _ := s.GetTask(draft.Title)
+	return task
+}
+
+func normalizeTitle(title string) string {
+	return strings.ToLower(strings.Join(strings.Fields(title), " "))
+}
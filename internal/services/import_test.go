@@ -0,0 +1,245 @@
+package services
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func titles(tasks []Task) []string {
+	var result []string
+	for _, task := range tasks {
+		result = append(result, task.Title)
+	}
+	return result
+}
+
+func TestParseImportCSV(t *testing.T) {
+	input := "\ufeffName,Labels,Priority,Due,State,Ignored\n" +
+		"Pay rent,home;bills,High,2026-11-01,todo,x\n" +
+		"\"Fix, bug\",work,,,Done\n"
+	mapping := map[string]string{"name": "title", "Labels": "tags", "State": "status"}
+
+	tasks, err := ParseImport(strings.NewReader(input), FormatCSV, mapping)
+	if err != nil {
+		t.Fatalf("ParseImport returned unexpected error: %v", err)
+	}
+	if len(tasks) != 2 {
+		t.Fatalf("Expected 2 tasks, got %d", len(tasks))
+	}
+	rent := tasks[0]
+	if rent.Title != "Pay rent" || rent.Priority != TaskPriorityHigh || rent.Status != TaskStatusPending {
+		t.Errorf("Unexpected first task %+v", rent)
+	}
+	if len(rent.Tags) != 2 || rent.Tags[1] != "bills" {
+		t.Errorf("Expected tags [home bills], got %v", rent.Tags)
+	}
+	if !rent.Due.Equal(time.Date(2026, 11, 1, 0, 0, 0, 0, time.Local)) {
+		t.Errorf("Unexpected due date %v", rent.Due)
+	}
+	if tasks[1].Title != "Fix, bug" || tasks[1].Status != TaskStatusCompleted {
+		t.Errorf("Unexpected second task %+v", tasks[1])
+	}
+}
+
+func TestParseImportCSVErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		mapping map[string]string
+		want    string
+	}{
+		{name: "No title column", input: "Name\nPay rent\n", want: "no title column"},
+		{name: "Unknown field", input: "Name\nPay rent\n", mapping: map[string]string{"Name": "summary"}, want: "unknown field"},
+		{name: "Bad priority", input: "title,priority\nPay rent,urgent\n", want: "line 2: priority"},
+		{name: "Bad status", input: "title,status\nA\nPay rent,someday\n", want: "line 3: unknown status"},
+		{name: "Bad due", input: "title,due\nPay rent,soon\n", want: "invalid due date"},
+		{name: "Empty title", input: "title,tags\n,home\n", want: "title cannot be empty"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseImport(strings.NewReader(tt.input), FormatCSV, tt.mapping)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestParseImportJSON(t *testing.T) {
+	array := `[{"title":"A","status":"completed","priority":"low","estimate":"2h"},{"title":"B","tags":["x"]}]`
+	tasks, err := ParseImport(strings.NewReader(array), FormatJSON, nil)
+	if err != nil {
+		t.Fatalf("ParseImport returned unexpected error: %v", err)
+	}
+	if len(tasks) != 2 || tasks[0].Status != TaskStatusCompleted || tasks[1].Status != TaskStatusPending {
+		t.Errorf("Unexpected tasks %+v", tasks)
+	}
+	if tasks[0].Estimate.Duration != 2*time.Hour {
+		t.Errorf("Expected estimate to be parsed, got %v", tasks[0].Estimate)
+	}
+
+	// A tasks file written by this tool can be imported as well
+	wrapper := `{"tasks":{"A":{"title":"A","status":"pending","created_at":"2026-10-01T00:00:00Z"}},"views":{}}`
+	tasks, err = ParseImport(strings.NewReader(wrapper), FormatJSON, nil)
+	if err != nil || len(tasks) != 1 || tasks[0].Title != "A" {
+		t.Errorf("Expected tasks file to be imported, got %v (%v)", tasks, err)
+	}
+
+	if _, err := ParseImport(strings.NewReader(`{"title":"A"}`), FormatJSON, nil); err == nil {
+		t.Errorf("Expected error for a single object")
+	}
+	if _, err := ParseImport(strings.NewReader(`[{"title":"A","status":"blocked"}]`), FormatJSON, nil); err == nil {
+		t.Errorf("Expected error for an unknown status")
+	}
+}
+
+func TestParseImportJSONL(t *testing.T) {
+	input := "{\"title\":\"A\"}\n\n{\"title\":\"B\",\"due\":\"2026-10-20T00:00:00Z\"}\n"
+	tasks, err := ParseImport(strings.NewReader(input), FormatJSONL, nil)
+	if err != nil {
+		t.Fatalf("ParseImport returned unexpected error: %v", err)
+	}
+	if got := titles(tasks); len(got) != 2 || got[1] != "B" {
+		t.Errorf("Unexpected tasks %v", got)
+	}
+
+	_, err = ParseImport(strings.NewReader("{\"title\":\"A\"}\n{broken\n"), FormatJSONL, nil)
+	if err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("Expected error on line 2, got %v", err)
+	}
+}
+
+func TestParseImportTodoTxt(t *testing.T) {
+	input := "(A) Call mom +family\n\nx Buy milk\n"
+	tasks, err := ParseImport(strings.NewReader(input), FormatTodoTxt, nil)
+	if err != nil {
+		t.Fatalf("ParseImport returned unexpected error: %v", err)
+	}
+	if len(tasks) != 2 || tasks[0].Priority != TaskPriorityCritical || tasks[1].Status != TaskStatusCompleted {
+		t.Errorf("Unexpected tasks %+v", tasks)
+	}
+}
+
+func TestParseImportMarkdown(t *testing.T) {
+	input := `# Sprint
+
+Some prose with - [ ] inline text.
+
+- [ ] Write docs #work
+- [x] Ship it
+  * [/] Review #team-a #urgent
+1. [X] Numbered item
+- [ ] Fix \#7 #bug <!-- id:11111111-1111-1111-1111-111111111111 priority:high -->
+- plain bullet
+`
+	tasks, err := ParseImport(strings.NewReader(input), FormatMarkdown, nil)
+	if err != nil {
+		t.Fatalf("ParseImport returned unexpected error: %v", err)
+	}
+	want := []string{"Write docs", "Ship it", "Review", "Numbered item", "Fix #7"}
+	got := titles(tasks)
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Fatalf("Expected %v, got %v", want, got)
+	}
+	statuses := []TaskStatus{TaskStatusPending, TaskStatusCompleted, TaskStatusInProgress, TaskStatusCompleted}
+	for i, status := range statuses {
+		if tasks[i].Status != status {
+			t.Errorf("Task %q status = %s, want %s", tasks[i].Title, tasks[i].Status, status)
+		}
+	}
+	if len(tasks[2].Tags) != 2 || tasks[2].Tags[0] != "team-a" {
+		t.Errorf("Expected hashtags as tags, got %v", tasks[2].Tags)
+	}
+	if len(tasks[4].Tags) != 1 || tasks[4].Tags[0] != "bug" {
+		t.Errorf("Expected only the bug tag, got %v", tasks[4].Tags)
+	}
+}
+
+func TestParseImportUnknownFormat(t *testing.T) {
+	if _, err := ParseImport(strings.NewReader(""), "xml", nil); err == nil {
+		t.Errorf("Expected error for unknown format")
+	}
+}
+
+func TestPlanImportAndImportTask(t *testing.T) {
+	// Set up a temporary file
+	_, cleanup := createTempTaskFile(t)
+	defer cleanup()
+
+	service := loadService(t)
+	service.AddTask("Pay rent")
+
+	drafts := []Task{
+		{Title: "pay  RENT", Status: TaskStatusPending},
+		{Title: "Write docs", Status: TaskStatusInProgress, Tags: []string{"work"}, Priority: TaskPriorityHigh},
+		{Title: "write docs", Status: TaskStatusPending},
+		{Title: "Ship it", Status: TaskStatusCompleted, ID: "foreign-id"},
+	}
+	plan := PlanImport(slices.Collect(maps.Values(service.Tasks)), drafts)
+	if got := titles(plan.New); strings.Join(got, "|") != "Write docs|Ship it" {
+		t.Errorf("Unexpected new tasks %v", got)
+	}
+	if got := titles(plan.Duplicates); strings.Join(got, "|") != "pay  RENT|write docs" {
+		t.Errorf("Unexpected duplicates %v", got)
+	}
+	if len(service.Tasks) != 1 {
+		t.Fatalf("Planning must not change the store")
+	}
+
+	for _, draft := range plan.New {
+		service.ImportTask(draft)
+	}
+	if len(service.Tasks) != 3 {
+		t.Fatalf("Expected 2 tasks to be added, store has %d", len(service.Tasks))
+	}
+	docs, _ := service.GetTask("Write docs")
+	if docs.ID == "" || docs.CreatedAt.IsZero() || docs.StartedAt.IsZero() {
+		t.Errorf("Expected ID and timestamps to be assigned, got %+v", docs)
+	}
+	if docs.Priority != TaskPriorityHigh || !docs.HasTag("work") {
+		t.Errorf("Expected fields to be imported, got %+v", docs)
+	}
+	ship, _ := service.GetTask("Ship it")
+	if ship.ID == "foreign-id" || ship.CompletedAt.IsZero() {
+		t.Errorf("Expected fresh ID and completion time, got %+v", ship)
+	}
+}
+
+func TestImportTaskKeepsTimestamps(t *testing.T) {
+	_, cleanup := createTempTaskFile(t)
+	defer cleanup()
+
+	created := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	started := created.Add(24 * time.Hour)
+	completed := started.Add(48 * time.Hour)
+	service := loadService(t)
+	for _, draft := range []Task{
+		{Title: "Done", Status: TaskStatusCompleted, CreatedAt: created, StartedAt: started, CompletedAt: completed},
+		{Title: "Doing", Status: TaskStatusInProgress, CreatedAt: created},
+		{Title: "Todo", Status: TaskStatusPending, StartedAt: started, CompletedAt: completed},
+		{Title: "Logged", Status: TaskStatusInProgress, TimeEntries: []TimeEntry{{Start: started, End: completed}, {Start: completed}}},
+	} {
+		service.ImportTask(draft)
+	}
+
+	done, _ := service.GetTask("Done")
+	if !done.CreatedAt.Equal(created) || !done.StartedAt.Equal(started) || !done.CompletedAt.Equal(completed) {
+		t.Errorf("Expected the imported timestamps to be kept, got %+v", done)
+	}
+	doing, _ := service.GetTask("Doing")
+	if !doing.CreatedAt.Equal(created) || !doing.StartedAt.After(created) || !doing.CompletedAt.IsZero() {
+		t.Errorf("Expected a missing start time to be set to now, got %+v", doing)
+	}
+	todo, _ := service.GetTask("Todo")
+	if todo.CreatedAt.Before(completed) || !todo.StartedAt.IsZero() || !todo.CompletedAt.IsZero() {
+		t.Errorf("Expected a pending task to be created now without start or completion times, got %+v", todo)
+	}
+	logged, _ := service.GetTask("Logged")
+	if len(logged.TimeEntries) != 1 || !logged.TimeEntries[0].End.Equal(completed) {
+		t.Errorf("Expected the finished time entry to be kept, got %+v", logged.TimeEntries)
+	}
+}
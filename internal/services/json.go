@@ -3,12 +3,15 @@ package services
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"log/slog"
 	"os"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/savabush/taskTracker/pkg/tasktracker/model"
 )
 
 const (
@@ -26,14 +29,45 @@ func SetTasksFileName(name string) {
 	tasksFileName = name
 }
 
-type TaskStatus string
+// Errors returned by TaskService. They are wrapped with the task or view
+// name, so check for them with errors.Is.
+var (
+	ErrTaskNotFound      = errors.New("task not found")
+	ErrDuplicateTitle    = errors.New("task already exists")
+	ErrInvalidTransition = errors.New("invalid status change")
+	ErrViewNotFound      = errors.New("view not found")
+	ErrAmbiguousID       = errors.New("ambiguous task ID")
+)
+
+// notFound returns ErrTaskNotFound for the given title.
+func notFound(title string) error {
+	return fmt.Errorf("%w: %q", ErrTaskNotFound, title)
+}
+
+// The task types are defined in the public model package, so that tasks
+// read through the tasktracker package need no conversion.
+type (
+	Task         = model.Task
+	TaskStatus   = model.TaskStatus
+	TaskPriority = model.TaskPriority
+)
 
 const (
-	TaskStatusPending    TaskStatus = "pending"
-	TaskStatusInProgress TaskStatus = "inProgress"
-	TaskStatusCompleted  TaskStatus = "completed"
+	TaskStatusPending    = model.StatusPending
+	TaskStatusInProgress = model.StatusInProgress
+	TaskStatusCompleted  = model.StatusCompleted
 )
 
+const (
+	TaskPriorityLow      = model.PriorityLow
+	TaskPriorityMedium   = model.PriorityMedium
+	TaskPriorityHigh     = model.PriorityHigh
+	TaskPriorityCritical = model.PriorityCritical
+)
+
+// DateLayout is the format used for due dates on the command line.
+const DateLayout = "2006-01-02"
+
 var baseDataInData = []byte(`{"tasks":{}}`)
 
 func createFileIfNotExists(filename string) error {
@@ -44,25 +78,62 @@ func createFileIfNotExists(filename string) error {
 	return nil
 }
 
-type Task struct {
-	ID        string     `json:"id"`
-	Title     string     `json:"title"`
-	Status    TaskStatus `json:"status"`
-	CreatedAt time.Time  `json:"created_at"`
-	UpdatedAt time.Time  `json:"updated_at"`
+// ShortIDLength is the number of characters of a task ID shown by list, and
+// MinIDPrefixLength the number a prefix needs to select a task.
+const (
+	ShortIDLength     = model.ShortIDLength
+	MinIDPrefixLength = model.MinIDPrefixLength
+)
+
+// FindTask looks a task up by ID, then by title, then by a prefix of its ID
+// of at least MinIDPrefixLength characters that no other task ID starts
+// with.
+func FindTask(tasks map[string]Task, key string) (Task, error) {
+	for _, task := range tasks {
+		if task.ID == key {
+			return task, nil
+		}
+	}
+	if task, ok := tasks[key]; ok {
+		return task, nil
+	}
+	if len(key) < MinIDPrefixLength {
+		return Task{}, notFound(key)
+	}
+	var matches []Task
+	for _, task := range tasks {
+		if strings.HasPrefix(task.ID, strings.ToLower(key)) {
+			matches = append(matches, task)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return Task{}, notFound(key)
+	case 1:
+		return matches[0], nil
+	}
+	return Task{}, fmt.Errorf("%w: %q matches %d tasks", ErrAmbiguousID, key, len(matches))
 }
 
 type TaskService struct {
-	Tasks map[string]Task `json:"tasks"`
-	mu    sync.RWMutex
+	Tasks     map[string]Task      `json:"tasks"`
+	Views     map[string]View      `json:"views,omitempty"`
+	Reminders map[string]time.Time `json:"reminders,omitempty"`
+	mu        sync.RWMutex
 }
 
-func NewTaskService() *TaskService {
+// NewTaskService loads the task store. A store that cannot be loaded is
+// reported rather than treated as empty, so that saving cannot overwrite it.
+func NewTaskService() (*TaskService, error) {
 	service := &TaskService{
-		Tasks: make(map[string]Task),
+		Tasks:     make(map[string]Task),
+		Views:     make(map[string]View),
+		Reminders: make(map[string]time.Time),
+	}
+	if err := service.LoadTasks(); err != nil {
+		return nil, err
 	}
-	service.LoadTasks()
-	return service
+	return service, nil
 }
 
 func (s *TaskService) AddTask(title string) error {
@@ -115,7 +186,7 @@ func (s *TaskService) GetTask(title string) (Task, error) {
 	defer s.mu.RUnlock()
 	task, ok := s.Tasks[title]
 	if !ok {
-		return Task{}, errors.New("task not found")
+		return Task{}, notFound(title)
 	}
 	return task, nil
 }
@@ -125,93 +196,254 @@ func (s *TaskService) DeleteTask(title string) error {
 	defer s.mu.Unlock()
 	_, ok := s.Tasks[title]
 	if !ok {
-		return errors.New("task not found")
+		return notFound(title)
+	}
+	delete(s.Tasks, title)
+	return nil
+}
+
+// UpdateTask applies update to the task with the given title and bumps its
+// UpdatedAt timestamp. The title itself must not be changed by update.
+func (s *TaskService) UpdateTask(title string, update func(task *Task)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	task, ok := s.Tasks[title]
+	if !ok {
+		return notFound(title)
+	}
+	update(&task)
+	task.Title = title
+	task.UpdatedAt = time.Now()
+	s.Tasks[title] = task
+	return nil
+}
+
+// RenameTask changes the title of a task, keeping all of its other fields.
+func (s *TaskService) RenameTask(title, newTitle string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	task, ok := s.Tasks[title]
+	if !ok {
+		return notFound(title)
+	}
+	if _, exists := s.Tasks[newTitle]; exists && newTitle != title {
+		return fmt.Errorf("%w: %q", ErrDuplicateTitle, newTitle)
 	}
 	delete(s.Tasks, title)
+	task.Title = newTitle
+	task.UpdatedAt = time.Now()
+	s.Tasks[newTitle] = task
 	return nil
 }
 
 func (s *TaskService) SaveTasks() error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	slog.Debug("Saving tasks to file", "filename", tasksFileName, "count", len(s.Tasks))
-	type TasksWrapper struct {
-		Tasks map[string]Task `json:"tasks"`
+	return storage.Save(StoreData{
+		Tasks:     s.Tasks,
+		Views:     s.Views,
+		Reminders: s.Reminders,
+	})
+}
+
+func (s *TaskService) LoadTasks() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data, err := storage.Load()
+	if err != nil {
+		return err
+	}
+	if data.Tasks != nil {
+		s.Tasks = data.Tasks
+	}
+	if data.Views != nil {
+		s.Views = data.Views
 	}
+	if data.Reminders != nil {
+		s.Reminders = data.Reminders
+	}
+	return nil
+}
 
-	wrapper := TasksWrapper{
-		Tasks: s.Tasks,
+// JSONStorage stores tasks, views and reminder state in a single JSON file.
+// An empty Path uses the file set with SetTasksFileName.
+type JSONStorage struct {
+	Path string
+}
+
+func (j JSONStorage) path() string {
+	if j.Path != "" {
+		return j.Path
 	}
+	return tasksFileName
+}
 
-	jsonData, err := json.Marshal(wrapper)
+func (j JSONStorage) Save(data StoreData) error {
+	filename := j.path()
+	slog.Debug("Saving tasks to file", "filename", filename, "count", len(data.Tasks))
+	jsonData, err := encodeStoreData(data)
 	if err != nil {
-		slog.Error("Failed to marshal tasks", "error", err)
-		return errors.New("failed to marshal tasks")
+		return err
 	}
 	slog.Debug("Writing tasks to file", "bytes", len(jsonData))
-	return os.WriteFile(tasksFileName, jsonData, 0644)
+	return os.WriteFile(filename, jsonData, 0644)
 }
 
-func (s *TaskService) LoadTasks() error {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-	slog.Debug("Loading tasks from file", "filename", tasksFileName)
-	jsonData, err := os.ReadFile(tasksFileName)
+func (j JSONStorage) Load() (StoreData, error) {
+	filename := j.path()
+	slog.Debug("Loading tasks from file", "filename", filename)
+	jsonData, err := os.ReadFile(filename)
 	if err != nil {
 		if os.IsNotExist(err) {
 			slog.Debug("Tasks file does not exist, creating new file")
-			err = createFileIfNotExists(tasksFileName)
+			err = createFileIfNotExists(filename)
 			if err != nil {
 				slog.Error("Failed to create tasks file", "error", err)
-				return err
+				return StoreData{}, err
 			}
-			return nil
+			return StoreData{}, nil
 		} else {
 			slog.Error("Failed to read tasks file", "error", err)
-			return errors.New("failed to read tasks")
+			return StoreData{}, errors.New("failed to read tasks")
 		}
 	}
 
 	if len(jsonData) == 0 {
 		slog.Debug("Tasks file is empty")
-		return nil
+		return StoreData{}, nil
+	}
+
+	return decodeStoreData(jsonData)
+}
+
+// storeWrapper is the layout of tasks.json.
+type storeWrapper struct {
+	Tasks     map[string]Task      `json:"tasks"`
+	Views     map[string]View      `json:"views,omitempty"`
+	Reminders map[string]time.Time `json:"reminders,omitempty"`
+}
+
+func encodeStoreData(data StoreData) ([]byte, error) {
+	wrapper := storeWrapper{
+		Tasks:     data.Tasks,
+		Views:     data.Views,
+		Reminders: data.Reminders,
 	}
 
-	type TasksWrapper struct {
-		Tasks map[string]Task `json:"tasks"`
+	jsonData, err := json.Marshal(wrapper)
+	if err != nil {
+		slog.Error("Failed to marshal tasks", "error", err)
+		return nil, errors.New("failed to marshal tasks")
 	}
+	return jsonData, nil
+}
 
-	var wrapper TasksWrapper
+// decodeStoreData parses tasks.json, falling back to the old format that
+// only held the tasks map.
+func decodeStoreData(jsonData []byte) (StoreData, error) {
+	var wrapper storeWrapper
 	wrapper.Tasks = make(map[string]Task)
+	wrapper.Views = make(map[string]View)
+	wrapper.Reminders = make(map[string]time.Time)
 
 	slog.Debug("Unmarshaling tasks data", "bytes", len(jsonData))
-	err = json.Unmarshal(jsonData, &wrapper)
+	err := json.Unmarshal(jsonData, &wrapper)
 	if err != nil {
 		slog.Debug("Failed to unmarshal with wrapper format, trying old format", "error", err)
-		err = json.Unmarshal(jsonData, &s.Tasks)
+		tasks := make(map[string]Task)
+		err = json.Unmarshal(jsonData, &tasks)
 		if err != nil {
 			slog.Error("Failed to unmarshal tasks data", "error", err)
-			return errors.New("failed to unmarshal tasks")
+			return StoreData{}, errors.New("failed to unmarshal tasks")
 		}
-		slog.Debug("Successfully unmarshaled using old format", "tasks", len(s.Tasks))
-	} else {
-		s.Tasks = wrapper.Tasks
-		slog.Debug("Successfully unmarshaled tasks", "count", len(s.Tasks))
+		slog.Debug("Successfully unmarshaled using old format", "tasks", len(tasks))
+		return StoreData{Tasks: tasks}, nil
 	}
 
-	return nil
+	slog.Debug("Successfully unmarshaled tasks", "count", len(wrapper.Tasks), "views", len(wrapper.Views))
+	return StoreData{Tasks: wrapper.Tasks, Views: wrapper.Views, Reminders: wrapper.Reminders}, nil
 }
 
+// CompleteTask marks a task as completed. Completing a recurring task keeps
+// the completed instance under a dated title and adds the next occurrence
+// under the original title.
 func (s *TaskService) CompleteTask(title string) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	task, ok := s.Tasks[title]
 	if !ok {
-		return errors.New("task not found")
+		return notFound(title)
+	}
+	if task.Status == TaskStatusCompleted {
+		return fmt.Errorf("%w: %q is already completed", ErrInvalidTransition, title)
 	}
 	task.Status = TaskStatusCompleted
 	task.UpdatedAt = time.Now()
+	task.CompletedAt = task.UpdatedAt
 	s.Tasks[title] = task
+
+	if task.Recurrence != "" {
+		return s.spawnNextOccurrence(task)
+	}
+	return nil
+}
+
+// spawnNextOccurrence must be called with s.mu held.
+func (s *TaskService) spawnNextOccurrence(completed Task) error {
+	rule, err := ParseRecurrence(completed.Recurrence)
+	if err != nil {
+		return err
+	}
+
+	// Roll forward from the due date, skipping occurrences already in the
+	// past so that completing a long-overdue task does not spawn another
+	// overdue one.
+	from := completed.Due
+	recurrence := completed.Recurrence
+	if from.IsZero() {
+		from = completed.CompletedAt
+	} else if anchored := rule.Anchored(from); anchored.ByMonthDay != rule.ByMonthDay {
+		// Write the day of the due date into the rule so that the next
+		// occurrences return to it after a shorter month
+		rule, recurrence = anchored, anchored.String()
+	}
+	today := truncateDay(completed.CompletedAt)
+	next, ok := rule.Next(from)
+	for ok && next.Before(today) {
+		next, ok = rule.Next(next)
+	}
+	if !ok {
+		slog.Debug("Recurrence ended", "task", completed.Title)
+		return nil
+	}
+
+	title := completed.Title
+	historyTitle := fmt.Sprintf("%s (%s)", title, from.Format(DateLayout))
+	for i := 2; ; i++ {
+		if _, exists := s.Tasks[historyTitle]; !exists {
+			break
+		}
+		historyTitle = fmt.Sprintf("%s (%s #%d)", title, from.Format(DateLayout), i)
+	}
+	now := time.Now()
+	occurrence := Task{
+		ID:          uuid.New().String(),
+		Title:       title,
+		Description: completed.Description,
+		Tags:        append([]string(nil), completed.Tags...),
+		Priority:    completed.Priority,
+		Due:         next,
+		Recurrence:  recurrence,
+		Status:      TaskStatusPending,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	completed.Title = historyTitle
+	completed.Recurrence = ""
+	s.Tasks[historyTitle] = completed
+	s.Tasks[occurrence.Title] = occurrence
+	slog.Debug("Spawned next occurrence", "task", occurrence.Title, "due", next.Format(DateLayout))
 	return nil
 }
 
@@ -220,10 +452,35 @@ func (s *TaskService) InProgressTask(title string) error {
 	defer s.mu.Unlock()
 	task, ok := s.Tasks[title]
 	if !ok {
-		return errors.New("task not found")
+		return notFound(title)
+	}
+	if task.Status == TaskStatusInProgress {
+		return fmt.Errorf("%w: %q is already in progress", ErrInvalidTransition, title)
 	}
 	task.Status = TaskStatusInProgress
 	task.UpdatedAt = time.Now()
+	if task.StartedAt.IsZero() {
+		task.StartedAt = task.UpdatedAt
+	}
+	task.CompletedAt = time.Time{}
+	s.Tasks[title] = task
+	return nil
+}
+
+// ReopenTask marks a task as pending again.
+func (s *TaskService) ReopenTask(title string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	task, ok := s.Tasks[title]
+	if !ok {
+		return notFound(title)
+	}
+	if task.Status == TaskStatusPending {
+		return fmt.Errorf("%w: %q is already pending", ErrInvalidTransition, title)
+	}
+	task.Status = TaskStatusPending
+	task.UpdatedAt = time.Now()
+	task.CompletedAt = time.Time{}
 	s.Tasks[title] = task
 	return nil
 }
@@ -1,6 +1,7 @@
 package services
 
 import (
+	"errors"
 	"os"
 	"testing"
 	"time"
@@ -24,13 +25,26 @@ func createTempTaskFile(t *testing.T) (string, func()) {
 	}
 }
 
+// loadService loads the task store, failing the test if it cannot be loaded.
+func loadService(t *testing.T) *TaskService {
+	t.Helper()
+	service, err := NewTaskService()
+	if err != nil {
+		t.Fatalf("Failed to load tasks: %v", err)
+	}
+	return service
+}
+
 func TestNewTaskService(t *testing.T) {
 	// Set up a temporary file
 	_, cleanup := createTempTaskFile(t)
 	defer cleanup()
 
 	// Create a new service
-	service := NewTaskService()
+	service, err := NewTaskService()
+	if err != nil {
+		t.Fatalf("NewTaskService returned unexpected error: %v", err)
+	}
 
 	// Verify it's initialized correctly
 	if service.Tasks == nil {
@@ -38,13 +52,23 @@ func TestNewTaskService(t *testing.T) {
 	}
 }
 
+func TestNewTaskService_LoadError(t *testing.T) {
+	fileName, cleanup := createTempTaskFile(t)
+	defer cleanup()
+	os.WriteFile(fileName, []byte(`{"tasks":{`), 0644)
+
+	if service, err := NewTaskService(); err == nil || service != nil {
+		t.Errorf("NewTaskService() = %v, %v, want an error", service, err)
+	}
+}
+
 func TestAddTask(t *testing.T) {
 	// Set up a temporary file
 	_, cleanup := createTempTaskFile(t)
 	defer cleanup()
 
 	// Create a new service
-	service := NewTaskService()
+	service := loadService(t)
 
 	// Test adding a task
 	taskTitle := "Test Task"
@@ -87,7 +111,7 @@ func TestGetTasks(t *testing.T) {
 	defer cleanup()
 
 	// Create a new service with various tasks
-	service := NewTaskService()
+	service := loadService(t)
 
 	// Add tasks with different statuses
 	service.AddTask("Pending Task")
@@ -153,7 +177,7 @@ func TestGetTask(t *testing.T) {
 	defer cleanup()
 
 	// Create a service and add a task
-	service := NewTaskService()
+	service := loadService(t)
 	taskTitle := "Test Task"
 	service.AddTask(taskTitle)
 
@@ -173,13 +197,52 @@ func TestGetTask(t *testing.T) {
 	}
 }
 
+func TestFindTask(t *testing.T) {
+	tasks := map[string]Task{
+		"a":    {ID: "3f2c9a1e-0000-4000-8000-000000000001", Title: "a"},
+		"b":    {ID: "3f2c7b04-0000-4000-8000-000000000002", Title: "b"},
+		"3f2c": {ID: "7b04d5c2-0000-4000-8000-000000000003", Title: "3f2c"},
+	}
+
+	tests := []struct {
+		key     string
+		want    string
+		wantErr error
+	}{
+		{key: "3f2c9a1e-0000-4000-8000-000000000001", want: "a"},
+		{key: "b", want: "b"},
+		{key: "3f2c9a1e", want: "a"},
+		{key: "3F2C7B", want: "b"},
+		{key: "3f2c", want: "3f2c"},
+		{key: "3f2", wantErr: ErrTaskNotFound},
+		{key: "7b0", wantErr: ErrTaskNotFound},
+		{key: "3f2c9", want: "a"},
+		{key: "3f2c0", wantErr: ErrTaskNotFound},
+		{key: "7b04", want: "3f2c"},
+	}
+	for _, tt := range tests {
+		task, err := FindTask(tasks, tt.key)
+		if !errors.Is(err, tt.wantErr) || task.Title != tt.want {
+			t.Errorf("FindTask(%q) = %q, %v, want %q, %v", tt.key, task.Title, err, tt.want, tt.wantErr)
+		}
+	}
+
+	delete(tasks, "3f2c")
+	if _, err := FindTask(tasks, "3f2c"); !errors.Is(err, ErrAmbiguousID) {
+		t.Errorf("Expected ErrAmbiguousID for a shared prefix, got %v", err)
+	}
+	if id := tasks["a"].ShortID(); id != "3f2c9a1e" {
+		t.Errorf("ShortID() = %q, want 3f2c9a1e", id)
+	}
+}
+
 func TestDeleteTask(t *testing.T) {
 	// Set up a temporary file
 	_, cleanup := createTempTaskFile(t)
 	defer cleanup()
 
 	// Create a service and add a task
-	service := NewTaskService()
+	service := loadService(t)
 	taskTitle := "Test Task"
 	service.AddTask(taskTitle)
 
@@ -205,7 +268,7 @@ func TestSaveAndLoadTasks(t *testing.T) {
 	defer cleanup()
 
 	// Create a service and add some tasks
-	service := NewTaskService()
+	service := loadService(t)
 	service.AddTask("Task1")
 	service.AddTask("Task2")
 	service.CompleteTask("Task2")
@@ -217,7 +280,7 @@ func TestSaveAndLoadTasks(t *testing.T) {
 	}
 
 	// Create a new service to load tasks
-	service2 := NewTaskService()
+	service2 := loadService(t)
 
 	// Verify tasks were loaded correctly
 	if len(service2.Tasks) != 2 {
@@ -236,7 +299,7 @@ func TestSaveAndLoadTasks(t *testing.T) {
 	os.Remove(tmpFile)
 	os.WriteFile(tmpFile, []byte{}, 0644)
 
-	service3 := NewTaskService()
+	service3 := loadService(t)
 	if len(service3.Tasks) != 0 {
 		t.Errorf("Expected 0 tasks when loading from empty file, got %d", len(service3.Tasks))
 	}
@@ -248,7 +311,7 @@ func TestMarkTaskStatus(t *testing.T) {
 	defer cleanup()
 
 	// Create a service and add a task
-	service := NewTaskService()
+	service := loadService(t)
 	taskTitle := "Test Task"
 	service.AddTask(taskTitle)
 
@@ -301,3 +364,144 @@ func TestMarkTaskStatus(t *testing.T) {
 		t.Errorf("Expected error marking non-existent task as completed")
 	}
 }
+
+func TestUpdateTask(t *testing.T) {
+	// Set up a temporary file
+	_, cleanup := createTempTaskFile(t)
+	defer cleanup()
+
+	service := loadService(t)
+	service.AddTask("Test Task")
+	originalTime := service.Tasks["Test Task"].UpdatedAt
+	time.Sleep(10 * time.Millisecond)
+
+	err := service.UpdateTask("Test Task", func(task *Task) {
+		task.Description = "Details"
+		task.Notes = append(task.Notes, "First note")
+		task.Title = "Renamed"
+	})
+	if err != nil {
+		t.Errorf("UpdateTask returned unexpected error: %v", err)
+	}
+
+	task, err := service.GetTask("Test Task")
+	if err != nil {
+		t.Fatalf("Task should keep its title after update: %v", err)
+	}
+	if task.Description != "Details" || len(task.Notes) != 1 {
+		t.Errorf("Update was not applied: %+v", task)
+	}
+	if !task.UpdatedAt.After(originalTime) {
+		t.Errorf("Expected UpdatedAt time to be updated")
+	}
+
+	err = service.UpdateTask("Non-existent Task", func(task *Task) {})
+	if err == nil {
+		t.Errorf("Expected error updating non-existent task")
+	}
+}
+
+func TestRenameTask(t *testing.T) {
+	// Set up a temporary file
+	_, cleanup := createTempTaskFile(t)
+	defer cleanup()
+
+	service := loadService(t)
+	service.AddTask("Old")
+	service.AddTask("Other")
+
+	if err := service.RenameTask("Old", "New"); err != nil {
+		t.Fatalf("RenameTask returned unexpected error: %v", err)
+	}
+	if _, err := service.GetTask("Old"); err == nil {
+		t.Errorf("Expected old title to be removed")
+	}
+	task, err := service.GetTask("New")
+	if err != nil || task.Title != "New" {
+		t.Errorf("Expected task under new title, got %+v (%v)", task, err)
+	}
+
+	if err := service.RenameTask("New", "Other"); !errors.Is(err, ErrDuplicateTitle) {
+		t.Errorf("Expected ErrDuplicateTitle renaming onto an existing task, got %v", err)
+	}
+	if err := service.RenameTask("Missing", "Anything"); !errors.Is(err, ErrTaskNotFound) {
+		t.Errorf("Expected ErrTaskNotFound renaming non-existent task, got %v", err)
+	}
+}
+
+func TestTaskNotFoundErrors(t *testing.T) {
+	_, cleanup := createTempTaskFile(t)
+	defer cleanup()
+
+	service := loadService(t)
+	checks := map[string]error{
+		"DeleteTask":     service.DeleteTask("Missing"),
+		"UpdateTask":     service.UpdateTask("Missing", func(*Task) {}),
+		"CompleteTask":   service.CompleteTask("Missing"),
+		"InProgressTask": service.InProgressTask("Missing"),
+		"ReopenTask":     service.ReopenTask("Missing"),
+	}
+	_, checks["GetTask"] = service.GetTask("Missing")
+	for name, err := range checks {
+		if !errors.Is(err, ErrTaskNotFound) {
+			t.Errorf("%s: expected ErrTaskNotFound, got %v", name, err)
+		}
+	}
+}
+
+func TestStatusTransitions(t *testing.T) {
+	_, cleanup := createTempTaskFile(t)
+	defer cleanup()
+
+	service := loadService(t)
+	service.AddTask("Task")
+
+	if err := service.ReopenTask("Task"); !errors.Is(err, ErrInvalidTransition) {
+		t.Errorf("Expected ErrInvalidTransition reopening a pending task, got %v", err)
+	}
+	if err := service.InProgressTask("Task"); err != nil {
+		t.Fatalf("InProgressTask returned unexpected error: %v", err)
+	}
+	if err := service.InProgressTask("Task"); !errors.Is(err, ErrInvalidTransition) {
+		t.Errorf("Expected ErrInvalidTransition starting a task in progress, got %v", err)
+	}
+	if err := service.CompleteTask("Task"); err != nil {
+		t.Fatalf("CompleteTask returned unexpected error: %v", err)
+	}
+	if err := service.CompleteTask("Task"); !errors.Is(err, ErrInvalidTransition) {
+		t.Errorf("Expected ErrInvalidTransition completing a completed task, got %v", err)
+	}
+
+	if err := service.ReopenTask("Task"); err != nil {
+		t.Fatalf("ReopenTask returned unexpected error: %v", err)
+	}
+	task := service.Tasks["Task"]
+	if task.Status != TaskStatusPending || !task.CompletedAt.IsZero() {
+		t.Errorf("Expected a pending task without completion time, got %+v", task)
+	}
+}
+
+func TestTaskIsOverdue(t *testing.T) {
+	now := time.Date(2026, 10, 19, 15, 0, 0, 0, time.Local)
+	yesterday := time.Date(2026, 10, 18, 0, 0, 0, 0, time.Local)
+	today := time.Date(2026, 10, 19, 0, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name string
+		task Task
+		want bool
+	}{
+		{name: "No due date", task: Task{Status: TaskStatusPending}, want: false},
+		{name: "Due yesterday", task: Task{Status: TaskStatusPending, Due: yesterday}, want: true},
+		{name: "Due today", task: Task{Status: TaskStatusPending, Due: today}, want: false},
+		{name: "Completed late", task: Task{Status: TaskStatusCompleted, Due: yesterday}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.task.IsOverdue(now); got != tt.want {
+				t.Errorf("IsOverdue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,469 @@
+package services
+
+import (
+	"errors"
+	"log/slog"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// markdownSections are the headings written for each status, in document
+// order.
+var markdownSections = []struct {
+	Status  TaskStatus
+	Heading string
+}{
+	{TaskStatusPending, "Pending"},
+	{TaskStatusInProgress, "In Progress"},
+	{TaskStatusCompleted, "Completed"},
+}
+
+var (
+	markdownHeading = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	markdownItem    = regexp.MustCompile(`^[-*+]\s+\[([ xX/])\]\s+(.*)$`)
+	markdownComment = regexp.MustCompile(`^(.*?)\s*<!--(.*?)-->\s*$`)
+)
+
+// MarkdownStorage keeps tasks in a human-editable Markdown file such as
+// TASKS.md. Tasks are top-level "- [ ]" checklist items under Pending, In
+// Progress and Completed headings, marked "- [/]" when in progress and
+// "- [x]" when completed, with their ID and other fields in a hidden HTML
+// comment at the end of the line. A # in a title is written as \# so that it
+// is not read back as a tag. Indented lines below an item
+// hold its description and "- " notes; description lines that would read as
+// a note or start with a space or backslash are escaped with a backslash. Prose, other headings and unchanged
+// items are written back as they were. Views and reminder state are kept in
+// a .tasktracker.json file in the same directory.
+type MarkdownStorage struct {
+	File     string
+	MetaFile string
+
+	mu     sync.Mutex
+	blocks []markdownBlock
+}
+
+// markdownBlock is a heading, a task item with its indented lines, or any
+// other line of the document.
+type markdownBlock struct {
+	lines []string
+	// heading is the status a status heading stands for
+	heading TaskStatus
+	isHead  bool
+	// section is the status of the section the block is in, if any
+	section TaskStatus
+	title   string
+	task    Task
+	// hasID is false for items written by hand, which get an ID comment
+	// on the next save
+	hasID bool
+}
+
+func NewMarkdownStorage(file string) *MarkdownStorage {
+	return &MarkdownStorage{
+		File:     file,
+		MetaFile: filepath.Join(filepath.Dir(file), storeMetaFile),
+	}
+}
+
+func (m *MarkdownStorage) Load() (StoreData, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	meta, err := loadStoreMeta(m.MetaFile)
+	if err != nil {
+		return StoreData{}, err
+	}
+	lines, modTime, err := readLines(m.File)
+	if err != nil {
+		return StoreData{}, err
+	}
+	slog.Debug("Loading Markdown tasks file", "filename", m.File, "lines", len(lines))
+
+	data := StoreData{
+		Tasks:     make(map[string]Task),
+		Views:     meta.Views,
+		Reminders: meta.Reminders,
+	}
+	m.blocks = parseMarkdownBlocks(lines, data.Tasks, modTime)
+	return data, nil
+}
+
+// parseMarkdownBlocks splits a document into blocks and adds its tasks to
+// tasks. Items that fail to parse or repeat an earlier title are kept as
+// plain lines.
+func parseMarkdownBlocks(lines []string, tasks map[string]Task, modTime time.Time) []markdownBlock {
+	var blocks []markdownBlock
+	var section TaskStatus
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		if match := markdownHeading.FindStringSubmatch(line); match != nil {
+			status, _ := parseImportStatus(match[2])
+			section = status
+			blocks = append(blocks, markdownBlock{lines: []string{line}, isHead: true, heading: status, section: status})
+			continue
+		}
+
+		block := markdownBlock{lines: []string{line}, section: section}
+		if markdownItem.MatchString(line) {
+			// Blank lines belong to the item if indented lines follow them
+			for next := i + 1; next < len(lines); next++ {
+				if isMarkdownContinuation(lines[next]) {
+					block.lines = append(block.lines, lines[i+1:next+1]...)
+					i = next
+				} else if strings.TrimSpace(lines[next]) != "" {
+					break
+				}
+			}
+			task, hasID, err := parseMarkdownTask(block.lines, section, modTime)
+			if _, exists := tasks[task.Title]; err == nil && !exists {
+				block.title, block.task, block.hasID = task.Title, task, hasID
+				tasks[task.Title] = task
+			} else {
+				slog.Debug("Keeping Markdown item as is", "line", line, "error", err)
+			}
+		}
+		blocks = append(blocks, block)
+	}
+	return blocks
+}
+
+func isMarkdownContinuation(line string) bool {
+	return strings.TrimSpace(line) != "" && (strings.HasPrefix(line, "  ") || strings.HasPrefix(line, "\t"))
+}
+
+// parseMarkdownTask parses an item and its indented lines. Unchecked items
+// under an In Progress heading are in progress.
+func parseMarkdownTask(lines []string, section TaskStatus, modTime time.Time) (Task, bool, error) {
+	match := markdownItem.FindStringSubmatch(lines[0])
+	task := Task{Status: TaskStatusPending}
+	switch {
+	case match[1] == "x" || match[1] == "X":
+		task.Status = TaskStatusCompleted
+	case match[1] == "/" || section == TaskStatusInProgress:
+		task.Status = TaskStatusInProgress
+	}
+
+	text, hasID := match[2], false
+	if comment := markdownComment.FindStringSubmatch(text); comment != nil {
+		text = comment[1]
+		var err error
+		if hasID, err = parseMarkdownFields(&task, comment[2]); err != nil {
+			return Task{}, false, err
+		}
+	}
+	task.Title, task.Tags = parseChecklistText(text)
+	if task.Title == "" {
+		return Task{}, false, errors.New("task title cannot be empty")
+	}
+
+	// The indent of the first indented line is the indent of all of them
+	var indent string
+	for _, line := range lines[1:] {
+		if strings.TrimSpace(line) != "" {
+			indent = line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+			break
+		}
+	}
+	var description []string
+	for _, line := range lines[1:] {
+		if trimmed, ok := strings.CutPrefix(line, indent); ok && strings.TrimSpace(line) != "" {
+			line = trimmed
+		} else {
+			line = strings.TrimSpace(line)
+		}
+		if note, ok := strings.CutPrefix(line, "- "); ok {
+			task.Notes = append(task.Notes, note)
+		} else {
+			description = append(description, strings.TrimPrefix(line, `\`))
+		}
+	}
+	task.Description = strings.Join(description, "\n")
+
+	if task.ID == "" {
+		// Derive a stable ID for items written by hand
+		task.ID = uuid.NewSHA1(uuid.NameSpaceOID, []byte(task.Title)).String()
+	}
+	if task.UpdatedAt.IsZero() {
+		task.UpdatedAt = modTime
+	}
+	if task.Status == TaskStatusCompleted && task.CompletedAt.IsZero() {
+		task.CompletedAt = task.UpdatedAt
+	}
+	return task, hasID, nil
+}
+
+// parseMarkdownFields reads the key:value fields of an item's comment and
+// reports whether it has an ID.
+func parseMarkdownFields(task *Task, comment string) (bool, error) {
+	timestamp := func(value string) (time.Time, error) {
+		return time.Parse(time.RFC3339, value)
+	}
+	for _, field := range strings.Fields(comment) {
+		key, value, _ := strings.Cut(field, ":")
+		var err error
+		switch key {
+		case "id":
+			task.ID = value
+		case "priority":
+			task.Priority = TaskPriority(value)
+			if !task.Priority.IsValid() {
+				err = errors.New("invalid priority " + value)
+			}
+		case "due":
+			task.Due, err = time.ParseInLocation(DateLayout, value, time.Local)
+		case "est":
+			task.Estimate, err = ParseEstimate(value)
+		case "rec":
+			_, err = ParseRecurrence(value)
+			task.Recurrence = value
+		case "created":
+			task.CreatedAt, err = timestamp(value)
+		case "updated":
+			task.UpdatedAt, err = timestamp(value)
+		case "started":
+			task.StartedAt, err = timestamp(value)
+		case "completed":
+			task.CompletedAt, err = timestamp(value)
+		case "time":
+			for _, span := range strings.Split(value, ",") {
+				start, end, _ := strings.Cut(span, "/")
+				var entry TimeEntry
+				if entry.Start, err = timestamp(start); err == nil && end != "" {
+					entry.End, err = timestamp(end)
+				}
+				if err != nil {
+					break
+				}
+				task.TimeEntries = append(task.TimeEntries, entry)
+			}
+		}
+		if err != nil {
+			return false, errors.New("invalid " + key + " in " + field)
+		}
+	}
+	return task.ID != "", nil
+}
+
+// formatMarkdownTask renders a task as an item with a hidden field comment
+// and indented description and note lines.
+func formatMarkdownTask(task Task) []string {
+	mark := " "
+	switch task.Status {
+	case TaskStatusInProgress:
+		mark = "/"
+	case TaskStatusCompleted:
+		mark = "x"
+	}
+	item := "- [" + mark + "] " + strings.ReplaceAll(task.Title, "#", `\#`)
+	for _, tag := range task.Tags {
+		item += " #" + tag
+	}
+
+	timestamp := func(t time.Time) string {
+		return t.UTC().Format(time.RFC3339)
+	}
+	fields := []string{"id:" + task.ID}
+	if task.Priority != "" {
+		fields = append(fields, "priority:"+string(task.Priority))
+	}
+	if !task.Due.IsZero() {
+		fields = append(fields, "due:"+task.Due.Format(DateLayout))
+	}
+	if !task.Estimate.IsZero() {
+		fields = append(fields, "est:"+task.Estimate.String())
+	}
+	if rule, err := ParseRecurrence(task.Recurrence); task.Recurrence != "" && err == nil {
+		fields = append(fields, "rec:"+rule.String())
+	}
+	if !task.CreatedAt.IsZero() {
+		fields = append(fields, "created:"+timestamp(task.CreatedAt))
+	}
+	if !task.UpdatedAt.IsZero() {
+		fields = append(fields, "updated:"+timestamp(task.UpdatedAt))
+	}
+	if !task.StartedAt.IsZero() {
+		fields = append(fields, "started:"+timestamp(task.StartedAt))
+	}
+	if !task.CompletedAt.IsZero() {
+		fields = append(fields, "completed:"+timestamp(task.CompletedAt))
+	}
+	if len(task.TimeEntries) > 0 {
+		spans := make([]string, len(task.TimeEntries))
+		for i, entry := range task.TimeEntries {
+			spans[i] = timestamp(entry.Start) + "/"
+			if !entry.End.IsZero() {
+				spans[i] += timestamp(entry.End)
+			}
+		}
+		fields = append(fields, "time:"+strings.Join(spans, ","))
+	}
+
+	lines := []string{item + " <!-- " + strings.Join(fields, " ") + " -->"}
+	if task.Description != "" {
+		for _, line := range strings.Split(task.Description, "\n") {
+			switch {
+			case line == "":
+				lines = append(lines, "")
+			case strings.HasPrefix(line, "- ") || strings.HasPrefix(line, `\`) || strings.TrimLeft(line, " \t") != line:
+				lines = append(lines, `  \`+line)
+			default:
+				lines = append(lines, "  "+line)
+			}
+		}
+	}
+	for _, note := range task.Notes {
+		lines = append(lines, "  - "+note)
+	}
+	return lines
+}
+
+func (m *MarkdownStorage) Save(data StoreData) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// Keep every block in place except deleted tasks and tasks whose status
+	// no longer matches their section, which move to their status section
+	// together with new tasks.
+	written := make(map[string]bool, len(data.Tasks))
+	moved := make(map[TaskStatus][]Task)
+	var blocks []markdownBlock
+	removed := false
+	for _, block := range m.blocks {
+		if block.title == "" {
+			// Removing the items between two blank lines leaves one
+			if removed && isBlankMarkdownBlock(block) && len(blocks) > 0 && isBlankMarkdownBlock(blocks[len(blocks)-1]) {
+				continue
+			}
+			removed = false
+			blocks = append(blocks, block)
+			continue
+		}
+		task, ok := data.Tasks[block.title]
+		if !ok {
+			removed = true
+			continue
+		}
+		written[task.Title] = true
+		if block.section != "" && block.section != task.Status {
+			moved[task.Status] = append(moved[task.Status], task)
+			removed = true
+			continue
+		}
+		removed = false
+		if !block.hasID || !sameMarkdownTask(task, block.task) {
+			block.lines = formatMarkdownTask(task)
+		}
+		block.title, block.task = task.Title, task
+		blocks = append(blocks, block)
+	}
+
+	var added []Task
+	for title, task := range data.Tasks {
+		if !written[title] {
+			added = append(added, task)
+		}
+	}
+	sort.Slice(added, func(i, j int) bool {
+		if !added[i].CreatedAt.Equal(added[j].CreatedAt) {
+			return added[i].CreatedAt.Before(added[j].CreatedAt)
+		}
+		return added[i].Title < added[j].Title
+	})
+	for _, task := range added {
+		moved[task.Status] = append(moved[task.Status], task)
+	}
+
+	for _, section := range markdownSections {
+		if len(moved[section.Status]) > 0 {
+			blocks = insertMarkdownTasks(blocks, section.Status, section.Heading, moved[section.Status])
+		}
+	}
+
+	var lines []string
+	for _, block := range blocks {
+		lines = append(lines, block.lines...)
+	}
+	slog.Debug("Writing Markdown tasks file", "filename", m.File, "lines", len(lines))
+	if err := writeLines(m.File, lines); err != nil {
+		return err
+	}
+	if err := saveStoreMeta(m.MetaFile, storeMeta{Views: data.Views, Reminders: data.Reminders}); err != nil {
+		return err
+	}
+
+	// Later saves compare against what was just written
+	m.blocks = parseMarkdownBlocks(lines, make(map[string]Task), time.Now())
+	return nil
+}
+
+// sameMarkdownTask reports whether two tasks render the same.
+func sameMarkdownTask(a, b Task) bool {
+	return strings.Join(formatMarkdownTask(a), "\n") == strings.Join(formatMarkdownTask(b), "\n")
+}
+
+func isBlankMarkdownBlock(block markdownBlock) bool {
+	return !block.isHead && block.title == "" && strings.TrimSpace(block.lines[0]) == ""
+}
+
+// insertMarkdownTasks adds tasks after the last item of the status's
+// section, adding the section at the end of the document if it is missing.
+func insertMarkdownTasks(blocks []markdownBlock, status TaskStatus, heading string, tasks []Task) []markdownBlock {
+	items := make([]markdownBlock, len(tasks))
+	for i, task := range tasks {
+		items[i] = markdownBlock{lines: formatMarkdownTask(task), section: status, title: task.Title, task: task, hasID: true}
+	}
+	blank := markdownBlock{lines: []string{""}, section: status}
+	isBlank := func(i int) bool {
+		return i < len(blocks) && isBlankMarkdownBlock(blocks[i])
+	}
+
+	head, last := -1, -1
+	level := "##"
+	for i, block := range blocks {
+		if block.isHead && block.heading != "" {
+			level = strings.Fields(block.lines[0])[0]
+		}
+		if block.isHead && block.heading == status && head < 0 {
+			head, last = i, i
+			continue
+		}
+		if head >= 0 && block.isHead {
+			break
+		}
+		if head >= 0 && block.title != "" {
+			last = i
+		}
+	}
+
+	if head < 0 {
+		var section []markdownBlock
+		if len(blocks) == 0 {
+			section = append(section, markdownBlock{lines: []string{"# Tasks"}, isHead: true}, blank)
+		} else if !isBlank(len(blocks) - 1) {
+			section = append(section, blank)
+		}
+		section = append(section, markdownBlock{lines: []string{level + " " + heading}, isHead: true, heading: status, section: status}, blank)
+		return append(append(blocks, section...), items...)
+	}
+
+	at := last + 1
+	if last == head {
+		// The first item of a section is separated from its heading by a
+		// blank line
+		if isBlank(at) {
+			at++
+		} else {
+			items = append([]markdownBlock{blank}, items...)
+		}
+		if at < len(blocks) && !isBlank(at) {
+			items = append(items, blank)
+		}
+	}
+	return append(blocks[:at], append(items, blocks[at:]...)...)
+}
@@ -0,0 +1,255 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func useMarkdownStorage(t *testing.T, content string) *MarkdownStorage {
+	t.Helper()
+	storage := NewMarkdownStorage(filepath.Join(t.TempDir(), "TASKS.md"))
+	if content != "" {
+		os.WriteFile(storage.File, []byte(content), 0644)
+	}
+
+	original := GetStorage()
+	SetStorage(storage)
+	t.Cleanup(func() { SetStorage(original) })
+	return storage
+}
+
+const markdownDoc = `# Project tasks
+
+Notes for contributors, kept as written.
+
+## Pending
+
+- [ ] Write docs #docs <!-- id:11111111-1111-1111-1111-111111111111 priority:high due:2026-10-20 -->
+  Cover the storage flags.
+  - ask for review
+- [ ] Fix bug <!-- id:22222222-2222-2222-2222-222222222222 -->
+
+Some prose between sections.
+
+## In Progress
+
+- [ ] Refactor parser <!-- id:33333333-3333-3333-3333-333333333333 -->
+
+## Done
+
+- [x] Release 1.0 <!-- id:44444444-4444-4444-4444-444444444444 completed:2026-10-01T10:00:00Z -->
+
+## Ideas
+
+- not a task
+`
+
+func TestMarkdownStorageLoad(t *testing.T) {
+	useMarkdownStorage(t, markdownDoc)
+
+	service := loadService(t)
+	if len(service.Tasks) != 4 {
+		t.Fatalf("Expected 4 tasks, got %d: %v", len(service.Tasks), service.Tasks)
+	}
+
+	docs, err := service.GetTask("Write docs")
+	if err != nil {
+		t.Fatalf("Expected task: %v", err)
+	}
+	if docs.ID != "11111111-1111-1111-1111-111111111111" || docs.Priority != TaskPriorityHigh || docs.Due.Format(DateLayout) != "2026-10-20" {
+		t.Errorf("Unexpected fields %+v", docs)
+	}
+	if strings.Join(docs.Tags, ",") != "docs" || docs.Description != "Cover the storage flags." || strings.Join(docs.Notes, ",") != "ask for review" {
+		t.Errorf("Unexpected tags, description or notes %+v", docs)
+	}
+
+	for title, status := range map[string]TaskStatus{
+		"Fix bug":         TaskStatusPending,
+		"Refactor parser": TaskStatusInProgress,
+		"Release 1.0":     TaskStatusCompleted,
+	} {
+		if task, _ := service.GetTask(title); task.Status != status {
+			t.Errorf("Expected %q to be %s, got %s", title, status, task.Status)
+		}
+	}
+}
+
+func TestMarkdownStorageRoundTrip(t *testing.T) {
+	storage := useMarkdownStorage(t, markdownDoc)
+
+	// Saving without changes leaves the file byte for byte identical
+	if err := loadService(t).SaveTasks(); err != nil {
+		t.Fatalf("SaveTasks returned unexpected error: %v", err)
+	}
+	if got := readFile(t, storage.File); got != markdownDoc {
+		t.Errorf("TASKS.md changed on save:\n%s", got)
+	}
+
+	// Status changes move items to their section; other changes are made in
+	// place and prose is kept
+	service := loadService(t)
+	service.UpdateTask("Fix bug", func(task *Task) { task.Priority = TaskPriorityLow })
+	service.CompleteTask("Write docs")
+	service.DeleteTask("Refactor parser")
+	service.AddTask("New task")
+	service.SaveTasks()
+
+	got := readFile(t, storage.File)
+	for _, want := range []string{
+		"Notes for contributors, kept as written.\n\n## Pending\n\n- [ ] Fix bug <!-- id:22222222-2222-2222-2222-222222222222 priority:low ",
+		"- [ ] New task <!-- id:",
+		"\n\nSome prose between sections.\n\n## In Progress\n\n## Done\n\n- [x] Release 1.0 ",
+		"- [x] Write docs #docs <!-- id:11111111-1111-1111-1111-111111111111 ",
+		"  Cover the storage flags.\n  - ask for review\n\n## Ideas\n\n- not a task\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Expected TASKS.md to contain %q, got:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "Refactor parser") {
+		t.Errorf("Expected deleted task to be removed, got:\n%s", got)
+	}
+
+	reloaded := loadService(t)
+	if len(reloaded.Tasks) != 4 {
+		t.Fatalf("Expected 4 tasks after reload, got %v", reloaded.Tasks)
+	}
+	added, _ := service.GetTask("New task")
+	if task, _ := reloaded.GetTask("New task"); task.ID != added.ID || !task.CreatedAt.Equal(added.CreatedAt.Truncate(1e9)) {
+		t.Errorf("Expected new task to survive reload, got %+v", task)
+	}
+	if task, _ := reloaded.GetTask("Write docs"); task.Status != TaskStatusCompleted || task.CompletedAt.IsZero() {
+		t.Errorf("Expected completed task after reload, got %+v", task)
+	}
+}
+
+func TestMarkdownStorageSections(t *testing.T) {
+	storage := useMarkdownStorage(t, "Just a list:\n\n- [ ] Hand written\n- [/] Started by hand\n")
+
+	// Items outside status sections stay where they are and get an ID
+	// comment; new sections are added at the end
+	service := loadService(t)
+	if task, _ := service.GetTask("Started by hand"); task.Status != TaskStatusInProgress {
+		t.Errorf("Expected [/] item to be in progress, got %s", task.Status)
+	}
+	service.AddTask("Added")
+	service.InProgressTask("Added")
+	service.SaveTasks()
+
+	got := readFile(t, storage.File)
+	if !strings.HasPrefix(got, "Just a list:\n\n- [ ] Hand written <!-- id:") {
+		t.Errorf("Expected hand written item to get an ID in place, got:\n%s", got)
+	}
+	if !strings.Contains(got, "\n\n## In Progress\n\n- [/] Added <!-- id:") {
+		t.Errorf("Expected new section for in-progress task, got:\n%s", got)
+	}
+	if task, _ := loadService(t).GetTask("Added"); task.Status != TaskStatusInProgress {
+		t.Errorf("Expected [/] item under In Progress to stay in progress, got %s", task.Status)
+	}
+
+	// A new file gets a title
+	storage = useMarkdownStorage(t, "")
+	service = loadService(t)
+	service.AddTask("First")
+	service.SaveView(View{Name: "work", Query: "tag:work"})
+	service.SaveTasks()
+	if got := readFile(t, storage.File); !strings.HasPrefix(got, "# Tasks\n\n## Pending\n\n- [ ] First <!-- id:") {
+		t.Errorf("Unexpected new file:\n%s", got)
+	}
+	if _, err := loadService(t).GetView("work"); err != nil {
+		t.Errorf("Expected view to be kept in the metadata file: %v", err)
+	}
+}
+
+func TestMarkdownStorageInProgressOutsideSections(t *testing.T) {
+	storage := useMarkdownStorage(t, "# Notes\n\n- [ ] hand task\n")
+
+	// Items under other headings stay in place, so their mark alone must
+	// carry the status
+	service := loadService(t)
+	service.InProgressTask("hand task")
+	service.SaveTasks()
+
+	if got := readFile(t, storage.File); !strings.HasPrefix(got, "# Notes\n\n- [/] hand task <!-- id:") {
+		t.Errorf("Expected in-progress item marked [/] in place, got:\n%s", got)
+	}
+	if task, _ := loadService(t).GetTask("hand task"); task.Status != TaskStatusInProgress {
+		t.Errorf("Expected task to stay in progress after reload, got %s", task.Status)
+	}
+}
+
+func TestMarkdownStorageHashInTitle(t *testing.T) {
+	storage := useMarkdownStorage(t, "")
+
+	service := loadService(t)
+	for _, title := range []string{"fix #42 bug", "#1 priority", `keep a\#b`} {
+		service.AddTask(title)
+	}
+	service.UpdateTask("fix #42 bug", func(task *Task) { task.Tags = []string{"backend"} })
+	service.SaveTasks()
+
+	if got := readFile(t, storage.File); !strings.Contains(got, `- [ ] fix \#42 bug #backend <!-- id:`) {
+		t.Errorf("Expected # in the title to be escaped, got:\n%s", got)
+	}
+	reloaded := loadService(t)
+	for _, title := range []string{"fix #42 bug", "#1 priority", `keep a\#b`} {
+		if _, err := reloaded.GetTask(title); err != nil {
+			t.Errorf("Expected %q to survive reload, got %v", title, reloaded.Tasks)
+		}
+	}
+	if task, _ := reloaded.GetTask("fix #42 bug"); len(task.Tags) != 1 || task.Tags[0] != "backend" {
+		t.Errorf("Expected only the backend tag, got %v", task.Tags)
+	}
+}
+
+func TestMarkdownStorageDescriptions(t *testing.T) {
+	storage := useMarkdownStorage(t, "")
+
+	descriptions := map[string]string{
+		"Paragraphs": "First paragraph.\n\nSecond paragraph.",
+		"Lists":      "Steps:\n- not a note\n  indented\n\\escaped",
+		"Leading":    "\n  code",
+	}
+	service := loadService(t)
+	for title, description := range descriptions {
+		service.AddTask(title)
+		service.UpdateTask(title, func(task *Task) {
+			task.Description = description
+			task.Notes = []string{"a note"}
+		})
+	}
+	service.AddTask("Next")
+	service.SaveTasks()
+
+	if got := readFile(t, storage.File); !strings.Contains(got, "  Steps:\n  \\- not a note\n  \\  indented\n  \\\\escaped\n  - a note\n") {
+		t.Errorf("Expected description lines to be escaped, got:\n%s", got)
+	}
+	reloaded := loadService(t)
+	for title, description := range descriptions {
+		task, err := reloaded.GetTask(title)
+		if err != nil || task.Description != description || strings.Join(task.Notes, "|") != "a note" {
+			t.Errorf("Round trip of %q gave %q, notes %q (%v)", title, task.Description, task.Notes, err)
+		}
+	}
+	if task, _ := reloaded.GetTask("Next"); task.Description != "" || len(task.Notes) != 0 {
+		t.Errorf("Expected the next item to stay separate, got %+v", task)
+	}
+}
+
+func TestMarkdownStorageInvalidItem(t *testing.T) {
+	doc := "## Pending\n\n- [ ] Bad due <!-- id:x due:tomorrow -->\n- [ ] Good\n- [ ] Good\n"
+	storage := useMarkdownStorage(t, doc)
+
+	// Items that cannot be parsed or repeat a title are kept as text
+	service := loadService(t)
+	if len(service.Tasks) != 1 {
+		t.Errorf("Expected only the first valid item to load, got %v", service.Tasks)
+	}
+	service.DeleteTask("Good")
+	service.SaveTasks()
+	if got := readFile(t, storage.File); got != "## Pending\n\n- [ ] Bad due <!-- id:x due:tomorrow -->\n- [ ] Good\n" {
+		t.Errorf("Unexpected TASKS.md:\n%s", got)
+	}
+}
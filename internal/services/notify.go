@@ -0,0 +1,122 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"runtime"
+	"time"
+)
+
+// Notifier delivers reminders to a sink such as the terminal, a desktop
+// notification command or a webhook. Sink names the sink in the
+// sent-reminder state.
+type Notifier interface {
+	Notify(r Reminder, now time.Time) error
+	Sink() string
+}
+
+// TerminalNotifier writes each reminder as a line to W.
+type TerminalNotifier struct {
+	W io.Writer
+}
+
+func (n TerminalNotifier) Sink() string { return "terminal" }
+
+func (n TerminalNotifier) Notify(r Reminder, now time.Time) error {
+	_, err := fmt.Fprintln(n.W, "Reminder: "+r.Message(now))
+	return err
+}
+
+// CommandNotifier runs a shell command for each reminder, e.g.
+// notify-send "$TASK_TITLE" "$TASK_MESSAGE". The reminder is passed in the
+// TASK_ID, TASK_TITLE, TASK_DUE, TASK_OVERDUE and TASK_MESSAGE environment
+// variables.
+type CommandNotifier struct {
+	Command string
+}
+
+func (n CommandNotifier) Sink() string { return "command" }
+
+func (n CommandNotifier) Notify(r Reminder, now time.Time) error {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command("cmd", "/C", n.Command)
+	} else {
+		cmd = exec.Command("sh", "-c", n.Command)
+	}
+	cmd.Env = append(os.Environ(),
+		"TASK_ID="+r.Task.ID,
+		"TASK_TITLE="+r.Task.Title,
+		"TASK_DUE="+r.Task.Due.Format(DateLayout),
+		fmt.Sprintf("TASK_OVERDUE=%t", r.Overdue),
+		"TASK_MESSAGE="+r.Message(now),
+	)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("notification command failed: %w: %s", err, bytes.TrimSpace(output))
+	}
+	return nil
+}
+
+// WebhookNotifier posts each reminder as JSON to URL.
+type WebhookNotifier struct {
+	URL    string
+	Client *http.Client
+}
+
+// ValidateWebhookURL checks that rawURL is an absolute http or https URL.
+func ValidateWebhookURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.New("webhook must be an http or https URL")
+	}
+	return nil
+}
+
+// webhookPayload is the JSON body posted by WebhookNotifier.
+type webhookPayload struct {
+	ID       string       `json:"id"`
+	Title    string       `json:"title"`
+	Due      string       `json:"due"`
+	Overdue  bool         `json:"overdue"`
+	Priority TaskPriority `json:"priority,omitempty"`
+	Tags     []string     `json:"tags,omitempty"`
+	Message  string       `json:"message"`
+}
+
+func (n WebhookNotifier) Sink() string { return "webhook" }
+
+func (n WebhookNotifier) Notify(r Reminder, now time.Time) error {
+	body, err := json.Marshal(webhookPayload{
+		ID:       r.Task.ID,
+		Title:    r.Task.Title,
+		Due:      r.Task.Due.Format(DateLayout),
+		Overdue:  r.Overdue,
+		Priority: r.Task.Priority,
+		Tags:     r.Task.Tags,
+		Message:  r.Message(now),
+	})
+	if err != nil {
+		return err
+	}
+
+	client := n.Client
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	resp, err := client.Post(n.URL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testReminder = Reminder{
+	Task: Task{
+		ID:    "id-1",
+		Title: "Pay rent",
+		Due:   time.Date(2026, 10, 20, 0, 0, 0, 0, time.Local),
+		Tags:  []string{"home"},
+	},
+}
+
+var testNow = time.Date(2026, 10, 19, 9, 0, 0, 0, time.Local)
+
+func TestTerminalNotifier(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (TerminalNotifier{W: &buf}).Notify(testReminder, testNow); err != nil {
+		t.Fatalf("Notify returned unexpected error: %v", err)
+	}
+	if got := buf.String(); got != "Reminder: Pay rent is due tomorrow\n" {
+		t.Errorf("Unexpected terminal output %q", got)
+	}
+}
+
+func TestCommandNotifier(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a POSIX shell command")
+	}
+	out := filepath.Join(t.TempDir(), "out")
+
+	notifier := CommandNotifier{Command: `printf '%s|%s|%s' "$TASK_TITLE" "$TASK_DUE" "$TASK_MESSAGE" > ` + out}
+	if err := notifier.Notify(testReminder, testNow); err != nil {
+		t.Fatalf("Notify returned unexpected error: %v", err)
+	}
+	data, _ := os.ReadFile(out)
+	if got := string(data); got != "Pay rent|2026-10-20|Pay rent is due tomorrow" {
+		t.Errorf("Unexpected command environment %q", got)
+	}
+
+	err := CommandNotifier{Command: "echo broken >&2; exit 3"}.Notify(testReminder, testNow)
+	if err == nil || !strings.Contains(err.Error(), "broken") {
+		t.Errorf("Expected failing command to return its output, got %v", err)
+	}
+}
+
+func TestWebhookNotifier(t *testing.T) {
+	var payload webhookPayload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		json.NewDecoder(r.Body).Decode(&payload)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	if err := (WebhookNotifier{URL: server.URL}).Notify(testReminder, testNow); err != nil {
+		t.Fatalf("Notify returned unexpected error: %v", err)
+	}
+	if payload.ID != "id-1" || payload.Due != "2026-10-20" || payload.Message != "Pay rent is due tomorrow" {
+		t.Errorf("Unexpected webhook payload %+v", payload)
+	}
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+	if err := (WebhookNotifier{URL: failing.URL}).Notify(testReminder, testNow); err == nil {
+		t.Errorf("Expected error for failing webhook")
+	}
+}
+
+func TestValidateWebhookURL(t *testing.T) {
+	tests := map[string]bool{
+		"http://localhost:8080/hook": true,
+		"https://example.com/hook":   true,
+		"ftp://example.com":          false,
+		"localhost:8080":             false,
+		"":                           false,
+	}
+	for rawURL, valid := range tests {
+		if err := ValidateWebhookURL(rawURL); (err == nil) != valid {
+			t.Errorf("ValidateWebhookURL(%q) error = %v, want valid %v", rawURL, err, valid)
+		}
+	}
+}
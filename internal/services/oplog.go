@@ -0,0 +1,507 @@
+package services
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Kinds of records an operation changes.
+const (
+	OpTask     = "task"
+	OpView     = "view"
+	OpReminder = "reminder"
+)
+
+// opDeleted is the field of a task operation that deletes the task. Its
+// value is the version vector of the deleting replica, the latest clock it
+// had seen from each replica, so that edits it had not seen yet survive.
+const opDeleted = "_deleted"
+
+var replicaPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// Op sets one field of a task, or a whole view or reminder, to Value. Ops
+// are ordered by their Lamport clock, with the replica name breaking ties,
+// and the latest op for each field wins. Null values clear a field or delete
+// a view or reminder.
+type Op struct {
+	Replica string          `json:"replica"`
+	Clock   uint64          `json:"clock"`
+	Kind    string          `json:"kind"`
+	Key     string          `json:"key"`
+	Field   string          `json:"field,omitempty"`
+	Value   json.RawMessage `json:"value"`
+}
+
+// after reports whether o is ordered after other.
+func (o Op) after(other Op) bool {
+	if o.Clock != other.Clock {
+		return o.Clock > other.Clock
+	}
+	return o.Replica > other.Replica
+}
+
+func (o Op) isNull() bool {
+	return len(o.Value) == 0 || string(o.Value) == "null"
+}
+
+type opID struct {
+	replica string
+	clock   uint64
+}
+
+type opRegister struct {
+	kind, key, field string
+}
+
+// OpLog is a set of operations from any number of replicas. Its state only
+// depends on which operations it holds, not on the order they were added
+// in, so replicas that exchange their logs converge.
+type OpLog struct {
+	ops       map[opID]Op
+	registers map[opRegister]Op
+	// deleted merges the version vectors of each task's deletions
+	deleted map[string]map[string]uint64
+	seen    map[string]uint64
+	clock   uint64
+}
+
+func NewOpLog() *OpLog {
+	return &OpLog{
+		ops:       make(map[opID]Op),
+		registers: make(map[opRegister]Op),
+		deleted:   make(map[string]map[string]uint64),
+		seen:      make(map[string]uint64),
+	}
+}
+
+// Add records op and reports whether it was new.
+func (l *OpLog) Add(op Op) bool {
+	id := opID{op.Replica, op.Clock}
+	if _, exists := l.ops[id]; exists {
+		return false
+	}
+	l.ops[id] = op
+	l.clock = max(l.clock, op.Clock)
+	l.seen[op.Replica] = max(l.seen[op.Replica], op.Clock)
+	if op.Kind == OpTask && op.Field == opDeleted {
+		var seen map[string]uint64
+		json.Unmarshal(op.Value, &seen)
+		if l.deleted[op.Key] == nil {
+			l.deleted[op.Key] = make(map[string]uint64)
+		}
+		for replica, clock := range seen {
+			l.deleted[op.Key][replica] = max(l.deleted[op.Key][replica], clock)
+		}
+		return true
+	}
+
+	register := opRegister{op.Kind, op.Key, op.Field}
+	if current, ok := l.registers[register]; !ok || op.after(current) {
+		l.registers[register] = op
+	}
+	return true
+}
+
+// Ops returns all operations in clock order.
+func (l *OpLog) Ops() []Op {
+	ops := make([]Op, 0, len(l.ops))
+	for _, op := range l.ops {
+		ops = append(ops, op)
+	}
+	sort.Slice(ops, func(i, j int) bool { return ops[j].after(ops[i]) })
+	return ops
+}
+
+// Len returns the number of operations.
+func (l *OpLog) Len() int {
+	return len(l.ops)
+}
+
+// State returns the tasks, views and reminders the log describes. A task
+// deleted on one replica and edited concurrently on another is kept. Tasks
+// that end up with the same title get numbered titles.
+func (l *OpLog) State() (StoreData, error) {
+	tasks, err := l.tasks()
+	if err != nil {
+		return StoreData{}, err
+	}
+	data := StoreData{
+		Tasks:     retitleMerged(tasks),
+		Views:     make(map[string]View),
+		Reminders: make(map[string]time.Time),
+	}
+	for register, op := range l.registers {
+		if op.isNull() {
+			continue
+		}
+		var err error
+		switch register.kind {
+		case OpView:
+			var view View
+			err = json.Unmarshal(op.Value, &view)
+			data.Views[register.key] = view
+		case OpReminder:
+			var sent time.Time
+			err = json.Unmarshal(op.Value, &sent)
+			data.Reminders[register.key] = sent
+		}
+		if err != nil {
+			return StoreData{}, fmt.Errorf("invalid %s %s: %w", register.kind, register.key, err)
+		}
+	}
+	return data, nil
+}
+
+// tasks returns the live tasks keyed by ID. A deleted task is live again if
+// any of its fields was last set by an operation its deletions had not seen.
+func (l *OpLog) tasks() (map[string]Task, error) {
+	fields := make(map[string]map[string]json.RawMessage)
+	live := make(map[string]bool)
+	for register, op := range l.registers {
+		if register.kind != OpTask {
+			continue
+		}
+		if fields[register.key] == nil {
+			fields[register.key] = make(map[string]json.RawMessage)
+		}
+		if !op.isNull() {
+			fields[register.key][register.field] = op.Value
+		}
+		if deleted, ok := l.deleted[register.key]; !ok || op.Clock > deleted[op.Replica] {
+			live[register.key] = true
+		}
+	}
+
+	tasks := make(map[string]Task, len(fields))
+	for id, values := range fields {
+		if !live[id] {
+			continue
+		}
+		values["id"], _ = json.Marshal(id)
+		raw, _ := json.Marshal(values)
+		var task Task
+		if err := json.Unmarshal(raw, &task); err != nil {
+			return nil, fmt.Errorf("invalid task %s: %w", id, err)
+		}
+		tasks[id] = task
+	}
+	return tasks, nil
+}
+
+// Diff returns the operations that turn the log's state into data, clocked
+// after every operation the log holds. It does not add them.
+func (l *OpLog) Diff(replica string, data StoreData) ([]Op, error) {
+	state, err := l.State()
+	if err != nil {
+		return nil, err
+	}
+	current := make(map[string]Task, len(state.Tasks))
+	for _, task := range state.Tasks {
+		current[task.ID] = task
+	}
+
+	clock := l.clock
+	var ops []Op
+	emit := func(kind, key, field string, value json.RawMessage) {
+		clock++
+		ops = append(ops, Op{Replica: replica, Clock: clock, Kind: kind, Key: key, Field: field, Value: value})
+	}
+
+	seen := make(map[string]bool, len(data.Tasks))
+	for _, task := range sortedByID(data.Tasks) {
+		if task.ID == "" {
+			return nil, fmt.Errorf("task %q has no ID", task.Title)
+		}
+		seen[task.ID] = true
+		newFields, err := taskFields(task)
+		if err != nil {
+			return nil, err
+		}
+		oldFields := map[string]json.RawMessage{}
+		if old, ok := current[task.ID]; ok {
+			if oldFields, err = taskFields(old); err != nil {
+				return nil, err
+			}
+		}
+		for _, field := range sortedFieldNames(oldFields, newFields) {
+			value, ok := newFields[field]
+			if !ok {
+				value = json.RawMessage("null")
+			}
+			if !bytes.Equal(value, oldFields[field]) {
+				emit(OpTask, task.ID, field, value)
+			}
+		}
+	}
+	for _, id := range sortedKeys(current) {
+		if !seen[id] {
+			vector, _ := json.Marshal(l.seen)
+			emit(OpTask, id, opDeleted, vector)
+		}
+	}
+
+	if err := diffValues(state.Views, data.Views, func(key string, value json.RawMessage) {
+		emit(OpView, key, "", value)
+	}); err != nil {
+		return nil, err
+	}
+	if err := diffValues(state.Reminders, data.Reminders, func(key string, value json.RawMessage) {
+		emit(OpReminder, key, "", value)
+	}); err != nil {
+		return nil, err
+	}
+	return ops, nil
+}
+
+// taskFields splits a task into its JSON fields, leaving out the ID that
+// ops are keyed by.
+func taskFields(task Task) (map[string]json.RawMessage, error) {
+	raw, err := json.Marshal(task)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, err
+	}
+	delete(fields, "id")
+	return fields, nil
+}
+
+// diffValues calls emit for every key whose value differs between old and
+// new, with null for removed keys.
+func diffValues[V any](old, new map[string]V, emit func(key string, value json.RawMessage)) error {
+	keys := make(map[string]bool)
+	for key := range old {
+		keys[key] = true
+	}
+	for key := range new {
+		keys[key] = true
+	}
+	names := make([]string, 0, len(keys))
+	for key := range keys {
+		names = append(names, key)
+	}
+	sort.Strings(names)
+
+	for _, key := range names {
+		oldValue, _ := json.Marshal(old[key])
+		newValue, err := json.Marshal(new[key])
+		if err != nil {
+			return err
+		}
+		_, inOld := old[key]
+		_, inNew := new[key]
+		switch {
+		case !inNew:
+			emit(key, json.RawMessage("null"))
+		case !inOld || !bytes.Equal(oldValue, newValue):
+			emit(key, newValue)
+		}
+	}
+	return nil
+}
+
+func sortedByID(tasks map[string]Task) []Task {
+	list := make([]Task, 0, len(tasks))
+	for _, task := range tasks {
+		list = append(list, task)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	return list
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedFieldNames(a, b map[string]json.RawMessage) []string {
+	merged := make(map[string]json.RawMessage, len(a)+len(b))
+	for key, value := range a {
+		merged[key] = value
+	}
+	for key, value := range b {
+		merged[key] = value
+	}
+	return sortedKeys(merged)
+}
+
+// ReadOpLog reads operations from a JSON Lines file or from every .jsonl
+// file in a directory. A missing path is an empty log.
+func ReadOpLog(path string) (*OpLog, error) {
+	log := NewOpLog()
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return log, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	files := []string{path}
+	if info.IsDir() {
+		if files, err = filepath.Glob(filepath.Join(path, "*.jsonl")); err != nil {
+			return nil, err
+		}
+	}
+	for _, file := range files {
+		if err := readOpFile(log, file); err != nil {
+			return nil, err
+		}
+	}
+	return log, nil
+}
+
+func readOpFile(log *OpLog, filename string) error {
+	file, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var op Op
+		if err := json.Unmarshal(scanner.Bytes(), &op); err != nil {
+			return fmt.Errorf("%s:%d: %w", filename, line, err)
+		}
+		if !replicaPattern.MatchString(op.Replica) || op.Clock == 0 || op.Key == "" {
+			return fmt.Errorf("%s:%d: invalid operation", filename, line)
+		}
+		log.Add(op)
+	}
+	return scanner.Err()
+}
+
+// OpLogStorage keeps tasks as an operation log in a directory, with one
+// append-only .jsonl file per replica. Replicas can edit offline and merge
+// each other's files in any order, e.g. through a synced folder or with
+// Replicate, and end up with the same tasks.
+type OpLogStorage struct {
+	Dir     string
+	Replica string
+
+	mu  sync.Mutex
+	log *OpLog
+}
+
+func NewOpLogStorage(dir, replica string) (*OpLogStorage, error) {
+	if !replicaPattern.MatchString(replica) {
+		return nil, errors.New("replica name must start with a letter or digit and contain only letters, digits, '.', '-' and '_'")
+	}
+	return &OpLogStorage{Dir: dir, Replica: replica}, nil
+}
+
+func (s *OpLogStorage) Load() (StoreData, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	log, err := ReadOpLog(s.Dir)
+	if err != nil {
+		slog.Error("Failed to read operation log", "error", err)
+		return StoreData{}, errors.New("failed to read tasks")
+	}
+	slog.Debug("Loaded operation log", "dir", s.Dir, "ops", log.Len())
+	s.log = log
+	return log.State()
+}
+
+func (s *OpLogStorage) Save(data StoreData) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.log == nil {
+		s.log = NewOpLog()
+	}
+	ops, err := s.log.Diff(s.Replica, data)
+	if err != nil {
+		return err
+	}
+	slog.Debug("Appending operations", "replica", s.Replica, "ops", len(ops))
+	if err := s.append(ops); err != nil {
+		return err
+	}
+	for _, op := range ops {
+		s.log.Add(op)
+	}
+	return nil
+}
+
+// Replicate adds the operations from a log file or directory that this log
+// does not have yet and returns how many there were.
+func (s *OpLogStorage) Replicate(path string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	local, err := ReadOpLog(s.Dir)
+	if err != nil {
+		return 0, err
+	}
+	remote, err := ReadOpLog(path)
+	if err != nil {
+		return 0, err
+	}
+
+	var added []Op
+	for _, op := range remote.Ops() {
+		if local.Add(op) {
+			added = append(added, op)
+		}
+	}
+	if err := s.append(added); err != nil {
+		return 0, err
+	}
+	s.log = local
+	return len(added), nil
+}
+
+// append writes ops to the files of their replicas.
+func (s *OpLogStorage) append(ops []Op) error {
+	if len(ops) == 0 {
+		return nil
+	}
+	if err := os.MkdirAll(s.Dir, 0755); err != nil {
+		return err
+	}
+	byReplica := make(map[string][]byte)
+	for _, op := range ops {
+		line, err := json.Marshal(op)
+		if err != nil {
+			return err
+		}
+		byReplica[op.Replica] = append(append(byReplica[op.Replica], line...), '\n')
+	}
+	for replica, lines := range byReplica {
+		file, err := os.OpenFile(filepath.Join(s.Dir, replica+".jsonl"), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		if err != nil {
+			return err
+		}
+		_, err = file.Write(lines)
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,222 @@
+package services
+
+import (
+	"math/rand"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// replicaEdit applies edits to a copy of the log's state on a replica and
+// returns the operations recording them.
+func replicaEdit(t *testing.T, log *OpLog, replica string, edit func(data *StoreData)) []Op {
+	t.Helper()
+	data, err := log.State()
+	if err != nil {
+		t.Fatalf("State returned unexpected error: %v", err)
+	}
+	edit(&data)
+	ops, err := log.Diff(replica, data)
+	if err != nil {
+		t.Fatalf("Diff returned unexpected error: %v", err)
+	}
+	return ops
+}
+
+func cloneOpLog(log *OpLog) *OpLog {
+	clone := NewOpLog()
+	for _, op := range log.Ops() {
+		clone.Add(op)
+	}
+	return clone
+}
+
+func TestOpLogConvergence(t *testing.T) {
+	created := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	newTask := func(id, title string) Task {
+		return Task{ID: id, Title: title, Status: TaskStatusPending, CreatedAt: created, UpdatedAt: created}
+	}
+
+	base := NewOpLog()
+	for _, op := range replicaEdit(t, base, "seed", func(data *StoreData) {
+		data.Tasks["Shared"] = newTask("1", "Shared")
+		data.Tasks["Doomed"] = newTask("2", "Doomed")
+		data.Tasks["Kept"] = newTask("3", "Kept")
+		data.Views["work"] = View{Name: "work", Query: "tag:work"}
+	}) {
+		base.Add(op)
+	}
+
+	// Three replicas edit offline from the same base, with conflicting
+	// edits of the same fields
+	laptop := replicaEdit(t, cloneOpLog(base), "laptop", func(data *StoreData) {
+		shared := data.Tasks["Shared"]
+		shared.Priority, shared.Tags = TaskPriorityHigh, []string{"laptop"}
+		data.Tasks["Shared"] = shared
+		delete(data.Tasks, "Doomed")
+		data.Tasks["Added"] = newTask("4", "Added")
+		data.Reminders["1@2026-10-20"] = created
+	})
+	desktop := replicaEdit(t, cloneOpLog(base), "desktop", func(data *StoreData) {
+		shared := data.Tasks["Shared"]
+		shared.Priority, shared.Status = TaskPriorityLow, TaskStatusCompleted
+		data.Tasks["Shared"] = shared
+		delete(data.Tasks, "Kept")
+		data.Tasks["Added"] = newTask("5", "Added")
+		delete(data.Views, "work")
+	})
+	phone := replicaEdit(t, cloneOpLog(base), "phone", func(data *StoreData) {
+		kept := data.Tasks["Kept"]
+		kept.Notes = []string{"edited while deleted elsewhere"}
+		data.Tasks["Kept"] = kept
+		data.Views["home"] = View{Name: "home", Query: "tag:home"}
+	})
+
+	orders := [][][]Op{
+		{laptop, desktop, phone},
+		{laptop, phone, desktop},
+		{desktop, laptop, phone},
+		{desktop, phone, laptop},
+		{phone, laptop, desktop},
+		{phone, desktop, laptop},
+	}
+	var want StoreData
+	random := rand.New(rand.NewSource(1))
+	for i, order := range orders {
+		log := cloneOpLog(base)
+		var ops []Op
+		for _, replicaOps := range order {
+			ops = append(ops, replicaOps...)
+		}
+		// Operations may also arrive out of order and more than once
+		if i%2 == 1 {
+			random.Shuffle(len(ops), func(a, b int) { ops[a], ops[b] = ops[b], ops[a] })
+			ops = append(ops, ops[:len(ops)/2]...)
+		}
+		for _, op := range ops {
+			log.Add(op)
+		}
+
+		got, err := log.State()
+		if err != nil {
+			t.Fatalf("State returned unexpected error: %v", err)
+		}
+		if i == 0 {
+			want = got
+			continue
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Order %d diverged:\n%+v\nwant:\n%+v", i, got, want)
+		}
+	}
+
+	// The laptop's and desktop's edits of the same field are clocked the same
+	// and the replica name breaks the tie; the phone's edit of a task it did
+	// not know was deleted keeps it
+	shared := want.Tasks["Shared"]
+	if shared.Priority != TaskPriorityHigh || shared.Status != TaskStatusCompleted || strings.Join(shared.Tags, ",") != "laptop" {
+		t.Errorf("Unexpected per-field merge %+v", shared)
+	}
+	if _, ok := want.Tasks["Doomed"]; ok {
+		t.Errorf("Expected deleted task to stay deleted")
+	}
+	if kept, ok := want.Tasks["Kept"]; !ok || len(kept.Notes) != 1 {
+		t.Errorf("Expected concurrent edit to win over deletion, got %+v", want.Tasks)
+	}
+	if want.Tasks["Added"].ID != "4" || want.Tasks["Added (2)"].ID != "5" {
+		t.Errorf("Expected both added tasks to be kept, got %+v", want.Tasks)
+	}
+	if _, ok := want.Views["work"]; ok || len(want.Views) != 1 || len(want.Reminders) != 1 {
+		t.Errorf("Unexpected views %v or reminders %v", want.Views, want.Reminders)
+	}
+}
+
+func TestOpLogDiff(t *testing.T) {
+	log := NewOpLog()
+	task := Task{ID: "1", Title: "Write", Description: "draft", Status: TaskStatusPending}
+	for _, op := range replicaEdit(t, log, "a", func(data *StoreData) { data.Tasks["Write"] = task }) {
+		log.Add(op)
+	}
+
+	// Unchanged state needs no operations; cleared fields are set to null
+	if ops := replicaEdit(t, log, "a", func(data *StoreData) {}); len(ops) != 0 {
+		t.Errorf("Expected no operations for unchanged state, got %v", ops)
+	}
+	ops := replicaEdit(t, log, "b", func(data *StoreData) {
+		task := data.Tasks["Write"]
+		task.Description = ""
+		data.Tasks["Write"] = task
+	})
+	if len(ops) != 1 || ops[0].Field != "description" || string(ops[0].Value) != "null" || ops[0].Clock <= log.clock {
+		t.Fatalf("Unexpected operations %+v", ops)
+	}
+	log.Add(ops[0])
+	if data, _ := log.State(); data.Tasks["Write"].Description != "" {
+		t.Errorf("Expected description to be cleared, got %+v", data.Tasks["Write"])
+	}
+
+	if _, err := log.Diff("a", StoreData{Tasks: map[string]Task{"X": {Title: "X"}}}); err == nil {
+		t.Errorf("Expected error for task without ID")
+	}
+}
+
+func TestOpLogStorage(t *testing.T) {
+	dir := t.TempDir()
+	laptop, _ := NewOpLogStorage(filepath.Join(dir, "laptop"), "laptop")
+	desktop, _ := NewOpLogStorage(filepath.Join(dir, "desktop"), "desktop")
+	original := GetStorage()
+	defer SetStorage(original)
+
+	SetStorage(laptop)
+	service := loadService(t)
+	service.AddTask("Buy milk")
+	service.SaveView(View{Name: "work", Query: "tag:work"})
+	service.SaveTasks()
+	// Saving again without changes appends nothing
+	loadService(t).SaveTasks()
+	lines := strings.Count(readFile(t, filepath.Join(laptop.Dir, "laptop.jsonl")), "\n")
+
+	SetStorage(desktop)
+	if added, err := desktop.Replicate(laptop.Dir); err != nil || added != lines {
+		t.Fatalf("Replicate = %d, %v, want %d", added, err, lines)
+	}
+	if added, _ := desktop.Replicate(filepath.Join(laptop.Dir, "laptop.jsonl")); added != 0 {
+		t.Errorf("Expected known operations to be skipped, got %d", added)
+	}
+	service = loadService(t)
+	if _, err := service.GetView("work"); err != nil {
+		t.Errorf("Expected replicated view: %v", err)
+	}
+	service.CompleteTask("Buy milk")
+	service.SaveTasks()
+
+	SetStorage(laptop)
+	laptop.Replicate(desktop.Dir)
+	if task, _ := loadService(t).GetTask("Buy milk"); task.Status != TaskStatusCompleted {
+		t.Errorf("Expected replicated completion, got %+v", task)
+	}
+	if _, err := os.Stat(filepath.Join(laptop.Dir, "desktop.jsonl")); err != nil {
+		t.Errorf("Expected desktop operations in their own file: %v", err)
+	}
+}
+
+func TestReadOpLogInvalid(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "bad.jsonl")
+	for _, content := range []string{
+		"not json\n",
+		`{"replica":"../x","clock":1,"kind":"task","key":"1","field":"title","value":"X"}` + "\n",
+		`{"replica":"a","clock":0,"kind":"task","key":"1","field":"title","value":"X"}` + "\n",
+	} {
+		os.WriteFile(file, []byte(content), 0644)
+		if _, err := ReadOpLog(file); err == nil {
+			t.Errorf("Expected error for %q", content)
+		}
+	}
+
+	if log, err := ReadOpLog(filepath.Join(t.TempDir(), "missing")); err != nil || log.Len() != 0 {
+		t.Errorf("Expected empty log for missing path, got %v, %v", log, err)
+	}
+}
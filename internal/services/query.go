@@ -0,0 +1,424 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/savabush/taskTracker/pkg/tasktracker/model"
+)
+
+// Query is a parsed filter expression such as
+//
+//	status:pending and (tag:backend or priority>=high) and due<2026-11-01
+//
+// Terms compare a field with a value using one of the operators
+// :, =, !=, <, <=, > or >=, and can be combined with and, or, not and
+// parentheses. A bare status name like "pending" is shorthand for
+// status:pending.
+type Query interface {
+	Match(task Task) bool
+	String() string
+}
+
+// ParseError describes a malformed query. Column is the 1-based position of
+// the offending character.
+// ParseError describes a malformed query. Column is the 1-based position of
+// the offending character.
+type ParseError = model.ParseError
+
+// ParseQuery parses a filter expression. An empty query matches every task.
+func ParseQuery(input string) (Query, error) {
+	p := &parser{input: input}
+	if err := p.tokenize(); err != nil {
+		return nil, err
+	}
+	if p.peek().kind == tokenEOF {
+		return matchAll{}, nil
+	}
+	q, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, p.errorf(tok, "unexpected %s", tok)
+	}
+	return q, nil
+}
+
+// QueryTasks returns the tasks matching q.
+func (s *TaskService) QueryTasks(q Query) map[string]Task {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	matched := make(map[string]Task)
+	for title, task := range s.Tasks {
+		if q.Match(task) {
+			matched[title] = task
+		}
+	}
+	return matched
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenWord
+	tokenString
+	tokenOp
+	tokenLParen
+	tokenRParen
+)
+
+type token struct {
+	kind   tokenKind
+	text   string
+	column int
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokenEOF:
+		return "end of query"
+	case tokenString:
+		return fmt.Sprintf("%q", t.text)
+	default:
+		return fmt.Sprintf("'%s'", t.text)
+	}
+}
+
+type parser struct {
+	input  string
+	tokens []token
+	pos    int
+}
+
+func (p *parser) errorf(tok token, format string, args ...any) error {
+	return &ParseError{Query: p.input, Column: tok.column, Msg: fmt.Sprintf(format, args...)}
+}
+
+func isWordRune(r rune) bool {
+	return !unicode.IsSpace(r) && !strings.ContainsRune(`()'":=!<>`, r)
+}
+
+func (p *parser) tokenize() error {
+	column := func(offset int) int {
+		return utf8.RuneCountInString(p.input[:offset]) + 1
+	}
+
+	for i := 0; i < len(p.input); {
+		r, size := utf8.DecodeRuneInString(p.input[i:])
+		switch {
+		case unicode.IsSpace(r):
+			i += size
+		case r == '(':
+			p.tokens = append(p.tokens, token{kind: tokenLParen, text: "(", column: column(i)})
+			i++
+		case r == ')':
+			p.tokens = append(p.tokens, token{kind: tokenRParen, text: ")", column: column(i)})
+			i++
+		case r == '"' || r == '\'':
+			end := strings.IndexRune(p.input[i+1:], r)
+			if end < 0 {
+				return &ParseError{Query: p.input, Column: column(i), Msg: "unterminated string"}
+			}
+			p.tokens = append(p.tokens, token{kind: tokenString, text: p.input[i+1 : i+1+end], column: column(i)})
+			i += end + 2
+		case strings.ContainsRune(":=!<>", r):
+			op := p.input[i : i+1]
+			if i+1 < len(p.input) && p.input[i+1] == '=' && r != ':' && r != '=' {
+				op = p.input[i : i+2]
+			}
+			if op == "!" {
+				return &ParseError{Query: p.input, Column: column(i), Msg: "expected '!='"}
+			}
+			p.tokens = append(p.tokens, token{kind: tokenOp, text: op, column: column(i)})
+			i += len(op)
+		default:
+			start := i
+			for i < len(p.input) {
+				r, size := utf8.DecodeRuneInString(p.input[i:])
+				if !isWordRune(r) {
+					break
+				}
+				i += size
+			}
+			p.tokens = append(p.tokens, token{kind: tokenWord, text: p.input[start:i], column: column(start)})
+		}
+	}
+	p.tokens = append(p.tokens, token{kind: tokenEOF, column: utf8.RuneCountInString(p.input) + 1})
+	return nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *parser) isKeyword(tok token, keyword string) bool {
+	return tok.kind == tokenWord && strings.EqualFold(tok.text, keyword)
+}
+
+// parseOr parses: and ('or' and)*
+func (p *parser) parseOr() (Query, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword(p.peek(), "or") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orQuery{left, right}
+	}
+	return left, nil
+}
+
+// parseAnd parses: unary ('and' unary)*
+func (p *parser) parseAnd() (Query, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword(p.peek(), "and") {
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = andQuery{left, right}
+	}
+	return left, nil
+}
+
+// parseUnary parses: 'not' unary | '(' or ')' | term
+func (p *parser) parseUnary() (Query, error) {
+	tok := p.peek()
+	switch {
+	case p.isKeyword(tok, "not"):
+		p.next()
+		inner, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notQuery{inner}, nil
+	case tok.kind == tokenLParen:
+		p.next()
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != tokenRParen {
+			return nil, p.errorf(closing, "expected ')' to close '(' at column %d, got %s", tok.column, closing)
+		}
+		return inner, nil
+	case tok.kind == tokenWord:
+		return p.parseTerm()
+	default:
+		return nil, p.errorf(tok, "expected a filter term, got %s", tok)
+	}
+}
+
+// parseTerm parses: field op value | status
+func (p *parser) parseTerm() (Query, error) {
+	field := p.next()
+	if p.isKeyword(field, "and") || p.isKeyword(field, "or") {
+		return nil, p.errorf(field, "expected a filter term, got %s", field)
+	}
+
+	op := p.peek()
+	if op.kind != tokenOp {
+		if status := TaskStatus(field.text); status.IsValid() {
+			return compareQuery{field: "status", op: ":", value: field.text, match: matchStatus(status, ":")}, nil
+		}
+		return nil, p.errorf(field, "expected field:value or a status (pending, inProgress, completed), got %s", field)
+	}
+	p.next()
+
+	value := p.next()
+	if value.kind != tokenWord && value.kind != tokenString {
+		return nil, p.errorf(value, "expected value after '%s%s', got %s", field.text, op.text, value)
+	}
+
+	match, err := p.compile(field, op, value)
+	if err != nil {
+		return nil, err
+	}
+	return compareQuery{field: strings.ToLower(field.text), op: op.text, value: value.text, match: match}, nil
+}
+
+func (p *parser) compile(field, op, value token) (func(Task) bool, error) {
+	equality := op.text == ":" || op.text == "=" || op.text == "!="
+
+	switch strings.ToLower(field.text) {
+	case "status":
+		if !equality {
+			return nil, p.errorf(op, "operator '%s' is not supported for status", op.text)
+		}
+		status := TaskStatus(value.text)
+		if !status.IsValid() {
+			return nil, p.errorf(value, "unknown status %s, expected pending, inProgress or completed", value)
+		}
+		return matchStatus(status, op.text), nil
+	case "tag":
+		if !equality {
+			return nil, p.errorf(op, "operator '%s' is not supported for tag", op.text)
+		}
+		negate := op.text == "!="
+		return func(t Task) bool { return t.HasTag(value.text) != negate }, nil
+	case "priority":
+		priority := TaskPriority(strings.ToLower(value.text))
+		if !priority.IsValid() && !strings.EqualFold(value.text, "none") {
+			return nil, p.errorf(value, "unknown priority %s, expected low, medium, high, critical or none", value)
+		}
+		return func(t Task) bool { return compareInts(t.Priority.Rank(), op.text, priority.Rank()) }, nil
+	case "due", "created", "updated":
+		return p.compileDate(strings.ToLower(field.text), op, value)
+	case "title", "description", "note":
+		if !equality {
+			return nil, p.errorf(op, "operator '%s' is not supported for %s", op.text, field.text)
+		}
+		return matchText(strings.ToLower(field.text), value.text, op.text == "!="), nil
+	default:
+		return nil, p.errorf(field, "unknown field %s, expected one of status, tag, priority, due, created, updated, title, description, note", field)
+	}
+}
+
+func (p *parser) compileDate(field string, op, value token) (func(Task) bool, error) {
+	get := func(t Task) time.Time {
+		switch field {
+		case "created":
+			return t.CreatedAt
+		case "updated":
+			return t.UpdatedAt
+		}
+		return t.Due
+	}
+
+	if strings.EqualFold(value.text, "none") {
+		if op.text != ":" && op.text != "=" && op.text != "!=" {
+			return nil, p.errorf(op, "operator '%s' cannot be used with none", op.text)
+		}
+		negate := op.text == "!="
+		return func(t Task) bool { return get(t).IsZero() != negate }, nil
+	}
+
+	date, err := ParseDate(value.text, time.Now())
+	if err != nil {
+		return nil, p.errorf(value, "invalid date %s, expected YYYY-MM-DD, today, tomorrow or yesterday", value)
+	}
+	return func(t Task) bool {
+		ts := get(t)
+		if ts.IsZero() {
+			return false
+		}
+		day := time.Date(ts.Year(), ts.Month(), ts.Day(), 0, 0, 0, 0, time.Local)
+		return compareInts(day.Compare(date), op.text, 0)
+	}, nil
+}
+
+// ParseDate parses a calendar date in the local time zone. Besides
+// YYYY-MM-DD it accepts today, tomorrow and yesterday relative to now.
+func ParseDate(value string, now time.Time) (time.Time, error) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	switch strings.ToLower(value) {
+	case "today":
+		return today, nil
+	case "tomorrow":
+		return today.AddDate(0, 0, 1), nil
+	case "yesterday":
+		return today.AddDate(0, 0, -1), nil
+	}
+	return time.ParseInLocation(DateLayout, value, time.Local)
+}
+
+func matchStatus(status TaskStatus, op string) func(Task) bool {
+	negate := op == "!="
+	return func(t Task) bool { return (t.Status == status) != negate }
+}
+
+func matchText(field, value string, negate bool) func(Task) bool {
+	needle := strings.ToLower(value)
+	return func(t Task) bool {
+		var haystack []string
+		switch field {
+		case "title":
+			haystack = []string{t.Title}
+		case "description":
+			haystack = []string{t.Description}
+		case "note":
+			haystack = t.Notes
+		}
+		for _, text := range haystack {
+			if strings.Contains(strings.ToLower(text), needle) {
+				return !negate
+			}
+		}
+		return negate
+	}
+}
+
+func compareInts(a int, op string, b int) bool {
+	switch op {
+	case ":", "=":
+		return a == b
+	case "!=":
+		return a != b
+	case "<":
+		return a < b
+	case "<=":
+		return a <= b
+	case ">":
+		return a > b
+	case ">=":
+		return a >= b
+	}
+	return false
+}
+
+type matchAll struct{}
+
+func (matchAll) Match(Task) bool { return true }
+func (matchAll) String() string  { return "" }
+
+type andQuery struct{ left, right Query }
+
+func (q andQuery) Match(t Task) bool { return q.left.Match(t) && q.right.Match(t) }
+func (q andQuery) String() string    { return "(" + q.left.String() + " and " + q.right.String() + ")" }
+
+type orQuery struct{ left, right Query }
+
+func (q orQuery) Match(t Task) bool { return q.left.Match(t) || q.right.Match(t) }
+func (q orQuery) String() string    { return "(" + q.left.String() + " or " + q.right.String() + ")" }
+
+type notQuery struct{ inner Query }
+
+func (q notQuery) Match(t Task) bool { return !q.inner.Match(t) }
+func (q notQuery) String() string    { return "not " + q.inner.String() }
+
+type compareQuery struct {
+	field string
+	op    string
+	value string
+	match func(Task) bool
+}
+
+func (q compareQuery) Match(t Task) bool { return q.match(t) }
+func (q compareQuery) String() string {
+	value := q.value
+	if strings.IndexFunc(value, func(r rune) bool { return !isWordRune(r) }) >= 0 {
+		value = fmt.Sprintf("%q", value)
+	}
+	return q.field + q.op + value
+}
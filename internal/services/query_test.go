@@ -0,0 +1,143 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func queryTestTasks() []Task {
+	date := func(s string) time.Time {
+		d, _ := time.ParseInLocation(DateLayout, s, time.Local)
+		return d
+	}
+	return []Task{
+		{Title: "API docs", Status: TaskStatusPending, Tags: []string{"backend"}, Priority: TaskPriorityLow, Due: date("2026-10-20")},
+		{Title: "Fix crash", Status: TaskStatusPending, Priority: TaskPriorityCritical, Due: date("2026-12-01")},
+		{Title: "Refactor db", Status: TaskStatusInProgress, Tags: []string{"backend", "tech-debt"}, Priority: TaskPriorityHigh},
+		{Title: "Ship v1", Status: TaskStatusCompleted, Description: "Release to production", Notes: []string{"Tag it"}},
+	}
+}
+
+func matchingTitles(q Query) []string {
+	var titles []string
+	for _, task := range queryTestTasks() {
+		if q.Match(task) {
+			titles = append(titles, task.Title)
+		}
+	}
+	return titles
+}
+
+func TestParseQuery(t *testing.T) {
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{query: "", want: []string{"API docs", "Fix crash", "Refactor db", "Ship v1"}},
+		{query: "pending", want: []string{"API docs", "Fix crash"}},
+		{query: "status:inProgress", want: []string{"Refactor db"}},
+		{query: "status!=pending", want: []string{"Refactor db", "Ship v1"}},
+		{query: "tag:BACKEND", want: []string{"API docs", "Refactor db"}},
+		{query: "tag!=backend", want: []string{"Fix crash", "Ship v1"}},
+		{query: "priority>=high", want: []string{"Fix crash", "Refactor db"}},
+		{query: "priority:none", want: []string{"Ship v1"}},
+		{query: "due<2026-11-01", want: []string{"API docs"}},
+		{query: "due:none", want: []string{"Refactor db", "Ship v1"}},
+		{query: "description:production", want: []string{"Ship v1"}},
+		{query: `title:"fix crash"`, want: []string{"Fix crash"}},
+		{query: "note:'tag it'", want: []string{"Ship v1"}},
+		{query: "not pending", want: []string{"Refactor db", "Ship v1"}},
+		{query: "pending or completed and tag:backend", want: []string{"API docs", "Fix crash"}},
+		{query: "(pending or completed) and tag:backend", want: []string{"API docs"}},
+		{
+			query: "status:pending and (tag:backend or priority>=high) and due<2026-11-01",
+			want:  []string{"API docs"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			q, err := ParseQuery(tt.query)
+			if err != nil {
+				t.Fatalf("ParseQuery(%q) returned unexpected error: %v", tt.query, err)
+			}
+			got := matchingTitles(q)
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("ParseQuery(%q) matched %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseQueryErrors(t *testing.T) {
+	tests := []struct {
+		query   string
+		column  int
+		message string
+	}{
+		{query: "invalid", column: 1, message: "expected field:value"},
+		{query: "status:done", column: 8, message: "unknown status"},
+		{query: "colour:red", column: 1, message: "unknown field"},
+		{query: "pending and", column: 12, message: "expected a filter term"},
+		{query: "priority>=", column: 11, message: "expected value"},
+		{query: "priority>=urgent", column: 11, message: "unknown priority"},
+		{query: "due<11/01/2026", column: 5, message: "invalid date"},
+		{query: "(pending or completed", column: 22, message: "expected ')'"},
+		{query: "pending)", column: 8, message: "unexpected ')'"},
+		{query: "tag>backend", column: 4, message: "not supported"},
+		{query: `title:"open`, column: 7, message: "unterminated string"},
+		{query: "status!pending", column: 7, message: "expected '!='"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			_, err := ParseQuery(tt.query)
+			var parseErr *ParseError
+			if !errors.As(err, &parseErr) {
+				t.Fatalf("ParseQuery(%q) error = %v, want *ParseError", tt.query, err)
+			}
+			if parseErr.Column != tt.column {
+				t.Errorf("Column = %d, want %d (%v)", parseErr.Column, tt.column, err)
+			}
+			if !strings.Contains(parseErr.Msg, tt.message) {
+				t.Errorf("Message = %q, want it to contain %q", parseErr.Msg, tt.message)
+			}
+		})
+	}
+}
+
+func TestParseErrorPointer(t *testing.T) {
+	_, err := ParseQuery("pending and priority>=")
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("Expected *ParseError, got %v", err)
+	}
+	want := "pending and priority>=\n                      ^"
+	if got := parseErr.Pointer(); got != want {
+		t.Errorf("Pointer() = %q, want %q", got, want)
+	}
+}
+
+func TestQueryTasks(t *testing.T) {
+	_, cleanup := createTempTaskFile(t)
+	defer cleanup()
+
+	service := loadService(t)
+	service.AddTask("Tagged")
+	service.UpdateTask("Tagged", func(task *Task) { task.Tags = []string{"urgent"} })
+	service.AddTask("Untagged")
+
+	q, err := ParseQuery("tag:urgent")
+	if err != nil {
+		t.Fatalf("ParseQuery returned unexpected error: %v", err)
+	}
+	tasks := service.QueryTasks(q)
+	if len(tasks) != 1 {
+		t.Fatalf("Expected 1 task, got %d", len(tasks))
+	}
+	if _, ok := tasks["Tagged"]; !ok {
+		t.Errorf("Expected 'Tagged' task in result")
+	}
+}
@@ -0,0 +1,284 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Recurrence frequencies.
+const (
+	FreqDaily   = "daily"
+	FreqWeekly  = "weekly"
+	FreqMonthly = "monthly"
+	FreqYearly  = "yearly"
+)
+
+var rruleFreqs = map[string]string{
+	"DAILY":   FreqDaily,
+	"WEEKLY":  FreqWeekly,
+	"MONTHLY": FreqMonthly,
+	"YEARLY":  FreqYearly,
+}
+
+var rruleDays = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+var unitFreqs = map[string]string{
+	"day":   FreqDaily,
+	"week":  FreqWeekly,
+	"month": FreqMonthly,
+	"year":  FreqYearly,
+}
+
+// Recurrence is a parsed recurrence rule. Rules are written either as
+// daily, weekly, monthly or yearly, as "every N days|weeks|months|years", or
+// as an RFC 5545 RRULE subset supporting FREQ, INTERVAL, BYDAY, BYMONTHDAY and
+// UNTIL, e.g. "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH".
+type Recurrence struct {
+	Freq       string
+	Interval   int
+	ByDay      []time.Weekday
+	ByMonthDay int
+	Until      time.Time
+}
+
+// ParseRecurrence parses a recurrence rule.
+func ParseRecurrence(rule string) (Recurrence, error) {
+	rule = strings.TrimSpace(rule)
+	lower := strings.ToLower(rule)
+
+	switch lower {
+	case FreqDaily, FreqWeekly, FreqMonthly, FreqYearly:
+		return Recurrence{Freq: lower, Interval: 1}, nil
+	}
+
+	if rest, ok := strings.CutPrefix(lower, "every "); ok {
+		fields := strings.Fields(rest)
+		interval := 1
+		if len(fields) == 2 {
+			n, err := strconv.Atoi(fields[0])
+			if err != nil || n < 1 {
+				return Recurrence{}, fmt.Errorf("invalid interval %q in recurrence %q", fields[0], rule)
+			}
+			interval = n
+			fields = fields[1:]
+		}
+		if len(fields) == 1 {
+			if freq, ok := unitFreqs[strings.TrimSuffix(fields[0], "s")]; ok {
+				return Recurrence{Freq: freq, Interval: interval}, nil
+			}
+		}
+		return Recurrence{}, fmt.Errorf("invalid recurrence %q, expected e.g. \"every 2 weeks\"", rule)
+	}
+
+	if strings.Contains(rule, "=") {
+		return parseRRule(strings.TrimPrefix(rule, "RRULE:"))
+	}
+
+	return Recurrence{}, fmt.Errorf("invalid recurrence %q, expected daily, weekly, monthly, yearly, \"every N weeks\" or an RRULE", rule)
+}
+
+func parseRRule(rule string) (Recurrence, error) {
+	r := Recurrence{Interval: 1}
+	for _, part := range strings.Split(rule, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return Recurrence{}, fmt.Errorf("invalid RRULE part %q", part)
+		}
+		key, value = strings.ToUpper(strings.TrimSpace(key)), strings.ToUpper(strings.TrimSpace(value))
+		switch key {
+		case "FREQ":
+			freq, ok := rruleFreqs[value]
+			if !ok {
+				return Recurrence{}, fmt.Errorf("unsupported RRULE frequency %q", value)
+			}
+			r.Freq = freq
+		case "INTERVAL":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return Recurrence{}, fmt.Errorf("invalid RRULE interval %q", value)
+			}
+			r.Interval = n
+		case "BYDAY":
+			for _, day := range strings.Split(value, ",") {
+				weekday, ok := rruleDays[day]
+				if !ok {
+					return Recurrence{}, fmt.Errorf("invalid RRULE day %q", day)
+				}
+				r.ByDay = append(r.ByDay, weekday)
+			}
+		case "BYMONTHDAY":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 || n > 31 {
+				return Recurrence{}, fmt.Errorf("invalid RRULE month day %q", value)
+			}
+			r.ByMonthDay = n
+		case "UNTIL":
+			// Only the date part of DATE or DATE-TIME values is used
+			date := value
+			if len(date) > 8 {
+				date = date[:8]
+			}
+			until, err := time.ParseInLocation("20060102", date, time.Local)
+			if err != nil {
+				return Recurrence{}, fmt.Errorf("invalid RRULE until %q", value)
+			}
+			r.Until = until
+		default:
+			return Recurrence{}, fmt.Errorf("unsupported RRULE part %q", key)
+		}
+	}
+
+	if r.Freq == "" {
+		return Recurrence{}, errors.New("RRULE requires FREQ")
+	}
+	if len(r.ByDay) > 0 && r.Freq != FreqWeekly {
+		return Recurrence{}, errors.New("RRULE BYDAY is only supported with FREQ=WEEKLY")
+	}
+	if r.ByMonthDay > 0 && r.Freq != FreqMonthly && r.Freq != FreqYearly {
+		return Recurrence{}, errors.New("RRULE BYMONTHDAY is only supported with FREQ=MONTHLY or FREQ=YEARLY")
+	}
+	return r, nil
+}
+
+// Next returns the first occurrence strictly after the given date. The
+// boolean is false once the rule's UNTIL date has passed. Monthly and yearly
+// rules without BYMONTHDAY keep the day of the given date, clamped to the
+// length of the target month; use Anchored to keep a day that was clamped.
+func (r Recurrence) Next(after time.Time) (time.Time, bool) {
+	after = truncateDay(after)
+	var next time.Time
+
+	day := after.Day()
+	if r.ByMonthDay > 0 {
+		day = r.ByMonthDay
+	}
+	switch r.Freq {
+	case FreqDaily:
+		next = after.AddDate(0, 0, r.Interval)
+	case FreqWeekly:
+		next = r.nextWeekly(after)
+	case FreqMonthly:
+		if r.ByMonthDay > 0 {
+			if candidate := dateInMonth(after.Year(), after.Month(), day, after.Location()); candidate.After(after) {
+				return r.checkUntil(candidate)
+			}
+		}
+		next = addMonths(after, r.Interval, day)
+	case FreqYearly:
+		// BYMONTHDAY keeps the day within the month of the given date
+		next = addMonths(after, 12*r.Interval, day)
+	default:
+		return time.Time{}, false
+	}
+	return r.checkUntil(next)
+}
+
+// Anchored returns the rule with the day of due as BYMONTHDAY for monthly and
+// yearly rules whose day may be clamped, so that occurrences computed from a
+// clamped date, such as February 28th for January 31st, return to the
+// original day in longer months.
+func (r Recurrence) Anchored(due time.Time) Recurrence {
+	if (r.Freq == FreqMonthly || r.Freq == FreqYearly) && r.ByMonthDay == 0 && due.Day() > 28 {
+		r.ByMonthDay = due.Day()
+	}
+	return r
+}
+
+// Occurrences returns up to n occurrences following the given date, keeping
+// its day of the month as Anchored does.
+func (r Recurrence) Occurrences(after time.Time, n int) []time.Time {
+	r = r.Anchored(after)
+	var dates []time.Time
+	for len(dates) < n {
+		next, ok := r.Next(after)
+		if !ok {
+			break
+		}
+		dates = append(dates, next)
+		after = next
+	}
+	return dates
+}
+
+func (r Recurrence) checkUntil(next time.Time) (time.Time, bool) {
+	if !r.Until.IsZero() && next.After(r.Until) {
+		return time.Time{}, false
+	}
+	return next, true
+}
+
+// nextWeekly picks the next listed weekday in the current week, or the first
+// listed weekday of the week Interval weeks later. Weeks start on Monday.
+func (r Recurrence) nextWeekly(after time.Time) time.Time {
+	if len(r.ByDay) == 0 {
+		return after.AddDate(0, 0, 7*r.Interval)
+	}
+
+	days := make([]int, len(r.ByDay))
+	for i, day := range r.ByDay {
+		days[i] = mondayIndex(day)
+	}
+	slices.Sort(days)
+
+	current := mondayIndex(after.Weekday())
+	weekStart := after.AddDate(0, 0, -current)
+	for _, day := range days {
+		if day > current {
+			return weekStart.AddDate(0, 0, day)
+		}
+	}
+	return weekStart.AddDate(0, 0, 7*r.Interval+days[0])
+}
+
+func (r Recurrence) String() string {
+	parts := []string{"FREQ=" + strings.ToUpper(r.Freq)}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByDay) > 0 {
+		var days []string
+		for _, day := range r.ByDay {
+			days = append(days, strings.ToUpper(day.String()[:2]))
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if r.ByMonthDay > 0 {
+		parts = append(parts, "BYMONTHDAY="+strconv.Itoa(r.ByMonthDay))
+	}
+	if !r.Until.IsZero() {
+		parts = append(parts, "UNTIL="+r.Until.Format("20060102"))
+	}
+	return strings.Join(parts, ";")
+}
+
+func mondayIndex(day time.Weekday) int {
+	return (int(day) + 6) % 7
+}
+
+func truncateDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// addMonths moves t forward by months, clamping day to the length of the
+// target month so that e.g. January 31st rolls over to February 28th.
+func addMonths(t time.Time, months, day int) time.Time {
+	first := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location()).AddDate(0, months, 0)
+	return dateInMonth(first.Year(), first.Month(), day, t.Location())
+}
+
+func dateInMonth(year int, month time.Month, day int, loc *time.Location) time.Time {
+	last := time.Date(year, month+1, 0, 0, 0, 0, 0, loc).Day()
+	return time.Date(year, month, min(day, last), 0, 0, 0, 0, loc)
+}
@@ -0,0 +1,195 @@
+package services
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func date(s string) time.Time {
+	d, err := time.ParseInLocation(DateLayout, s, time.Local)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+func TestParseRecurrence(t *testing.T) {
+	tests := []struct {
+		rule    string
+		want    string
+		wantErr bool
+	}{
+		{rule: "daily", want: "FREQ=DAILY"},
+		{rule: "Weekly", want: "FREQ=WEEKLY"},
+		{rule: "every 2 weeks", want: "FREQ=WEEKLY;INTERVAL=2"},
+		{rule: "every month", want: "FREQ=MONTHLY"},
+		{rule: "every 3 days", want: "FREQ=DAILY;INTERVAL=3"},
+		{rule: "FREQ=WEEKLY;BYDAY=MO,TH", want: "FREQ=WEEKLY;BYDAY=MO,TH"},
+		{rule: "RRULE:FREQ=MONTHLY;BYMONTHDAY=15;UNTIL=20270101T000000Z", want: "FREQ=MONTHLY;BYMONTHDAY=15;UNTIL=20270101"},
+		{rule: "fortnightly", wantErr: true},
+		{rule: "every 0 days", wantErr: true},
+		{rule: "every 2 fortnights", wantErr: true},
+		{rule: "FREQ=HOURLY", wantErr: true},
+		{rule: "INTERVAL=2", wantErr: true},
+		{rule: "FREQ=DAILY;BYDAY=MO", wantErr: true},
+		{rule: "FREQ=WEEKLY;BYDAY=XX", wantErr: true},
+		{rule: "FREQ=WEEKLY;COUNT=3", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			r, err := ParseRecurrence(tt.rule)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseRecurrence(%q) error = %v, wantErr %v", tt.rule, err, tt.wantErr)
+			}
+			if err == nil && r.String() != tt.want {
+				t.Errorf("ParseRecurrence(%q) = %s, want %s", tt.rule, r, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecurrenceOccurrences(t *testing.T) {
+	tests := []struct {
+		rule string
+		from string
+		want []string
+	}{
+		{rule: "daily", from: "2026-10-30", want: []string{"2026-10-31", "2026-11-01", "2026-11-02"}},
+		{rule: "every 2 weeks", from: "2026-10-19", want: []string{"2026-11-02", "2026-11-16", "2026-11-30"}},
+		// 2026-10-19 is a Monday
+		{rule: "FREQ=WEEKLY;BYDAY=MO,TH", from: "2026-10-19", want: []string{"2026-10-22", "2026-10-26", "2026-10-29"}},
+		{rule: "FREQ=WEEKLY;INTERVAL=2;BYDAY=FR", from: "2026-10-23", want: []string{"2026-11-06", "2026-11-20", "2026-12-04"}},
+		{rule: "monthly", from: "2027-01-31", want: []string{"2027-02-28", "2027-03-31", "2027-04-30"}},
+		{rule: "FREQ=MONTHLY;BYMONTHDAY=31", from: "2027-01-15", want: []string{"2027-01-31", "2027-02-28", "2027-03-31"}},
+		{rule: "yearly", from: "2031-02-28", want: []string{"2032-02-28", "2033-02-28", "2034-02-28"}},
+		{rule: "FREQ=YEARLY;BYMONTHDAY=29", from: "2030-02-28", want: []string{"2031-02-28", "2032-02-29", "2033-02-28"}},
+		{rule: "FREQ=DAILY;UNTIL=20261021", from: "2026-10-19", want: []string{"2026-10-20", "2026-10-21"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			r, err := ParseRecurrence(tt.rule)
+			if err != nil {
+				t.Fatalf("ParseRecurrence returned unexpected error: %v", err)
+			}
+			var got []string
+			for _, d := range r.Occurrences(date(tt.from), 3) {
+				got = append(got, d.Format(DateLayout))
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("Occurrences(%s) = %v, want %v", tt.from, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecurrenceAnchored(t *testing.T) {
+	r, _ := ParseRecurrence("yearly")
+	var got []string
+	for _, d := range r.Occurrences(date("2028-02-29"), 4) {
+		got = append(got, d.Format(DateLayout))
+	}
+	if want := "2029-02-28,2030-02-28,2031-02-28,2032-02-29"; strings.Join(got, ",") != want {
+		t.Errorf("Occurrences(2028-02-29) = %v, want %s", got, want)
+	}
+
+	if anchored := r.Anchored(date("2028-02-15")); anchored.ByMonthDay != 0 {
+		t.Errorf("Expected days that are never clamped to stay unanchored, got %s", anchored)
+	}
+
+	// Occurrences stay in the location of the given date
+	loc := time.FixedZone("UTC+14", 14*3600)
+	monthly, _ := ParseRecurrence("monthly")
+	if next, _ := monthly.Next(time.Date(2027, 1, 31, 0, 0, 0, 0, loc)); next.Location() != loc || next.Day() != 28 {
+		t.Errorf("Next() = %v, want February 28th in %s", next, loc)
+	}
+}
+
+func TestCompleteMonthlyTaskKeepsDay(t *testing.T) {
+	_, cleanup := createTempTaskFile(t)
+	defer cleanup()
+
+	service := loadService(t)
+	service.AddTask("Pay rent")
+	service.UpdateTask("Pay rent", func(task *Task) {
+		task.Recurrence = "monthly"
+		task.Due = date("2027-01-31")
+	})
+
+	var dues []string
+	for range 3 {
+		if err := service.CompleteTask("Pay rent"); err != nil {
+			t.Fatalf("CompleteTask returned unexpected error: %v", err)
+		}
+		dues = append(dues, service.Tasks["Pay rent"].Due.Format(DateLayout))
+	}
+	if want := "2027-02-28,2027-03-31,2027-04-30"; strings.Join(dues, ",") != want {
+		t.Errorf("Due dates = %v, want %s", dues, want)
+	}
+	if rule := service.Tasks["Pay rent"].Recurrence; rule != "FREQ=MONTHLY;BYMONTHDAY=31" {
+		t.Errorf("Expected the day to be kept in the rule, got %q", rule)
+	}
+}
+
+func TestCompleteRecurringTask(t *testing.T) {
+	_, cleanup := createTempTaskFile(t)
+	defer cleanup()
+
+	service := loadService(t)
+	service.AddTask("Release notes")
+	due := truncateDay(time.Now()).AddDate(0, 0, 1)
+	service.UpdateTask("Release notes", func(task *Task) {
+		task.Recurrence = "weekly"
+		task.Due = due
+		task.Tags = []string{"chore"}
+	})
+	originalID := service.Tasks["Release notes"].ID
+
+	if err := service.CompleteTask("Release notes"); err != nil {
+		t.Fatalf("CompleteTask returned unexpected error: %v", err)
+	}
+
+	if len(service.Tasks) != 2 {
+		t.Fatalf("Expected completed instance and next occurrence, got %d tasks", len(service.Tasks))
+	}
+
+	history, ok := service.Tasks["Release notes ("+due.Format(DateLayout)+")"]
+	if !ok {
+		t.Fatalf("Completed instance not kept for history: %v", service.Tasks)
+	}
+	if history.Status != TaskStatusCompleted || history.ID != originalID || history.Recurrence != "" {
+		t.Errorf("Unexpected completed instance: %+v", history)
+	}
+
+	next := service.Tasks["Release notes"]
+	if next.Status != TaskStatusPending || next.ID == originalID {
+		t.Errorf("Expected new pending occurrence, got %+v", next)
+	}
+	if !next.Due.Equal(due.AddDate(0, 0, 7)) {
+		t.Errorf("Expected due date rolled forward a week to %v, got %v", due.AddDate(0, 0, 7), next.Due)
+	}
+	if next.Recurrence != "weekly" || !next.HasTag("chore") {
+		t.Errorf("Expected recurrence and tags to be carried over, got %+v", next)
+	}
+}
+
+func TestCompleteOverdueRecurringTask(t *testing.T) {
+	_, cleanup := createTempTaskFile(t)
+	defer cleanup()
+
+	service := loadService(t)
+	service.AddTask("Standup")
+	service.UpdateTask("Standup", func(task *Task) {
+		task.Recurrence = "daily"
+		task.Due = truncateDay(time.Now()).AddDate(0, 0, -10)
+	})
+
+	service.CompleteTask("Standup")
+
+	// The next occurrence is today, not nine days ago
+	if next := service.Tasks["Standup"]; !next.Due.Equal(truncateDay(time.Now())) {
+		t.Errorf("Expected next occurrence today, got %v", next.Due)
+	}
+}
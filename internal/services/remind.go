@@ -0,0 +1,96 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Reminder is a notification that an open task is due soon or overdue.
+type Reminder struct {
+	Task    Task
+	Overdue bool
+}
+
+// Key identifies the reminder in the sent-reminder state. It includes the
+// due date so that moving a task's due date triggers a new reminder.
+func (r Reminder) Key() string {
+	return r.Task.ID + "@" + r.Task.Due.Format(DateLayout)
+}
+
+// SinkKey identifies the reminder as sent to one sink, so that a sink that
+// failed is retried without repeating the reminder on the others.
+func (r Reminder) SinkKey(sink string) string {
+	return r.Key() + "/" + sink
+}
+
+// Message is a one-line human-readable description of the reminder.
+func (r Reminder) Message(now time.Time) string {
+	due := r.Task.Due.Format(DateLayout)
+	today := truncateDay(now)
+	switch {
+	case r.Overdue:
+		return fmt.Sprintf("%s is overdue (due %s)", r.Task.Title, due)
+	case r.Task.Due.Equal(today):
+		return fmt.Sprintf("%s is due today", r.Task.Title)
+	case r.Task.Due.Equal(today.AddDate(0, 0, 1)):
+		return fmt.Sprintf("%s is due tomorrow", r.Task.Title)
+	}
+	return fmt.Sprintf("%s is due %s", r.Task.Title, due)
+}
+
+// DueReminders returns reminders for the open tasks that are overdue or due
+// before now+within, ordered by due date.
+func DueReminders(tasks []Task, within time.Duration, now time.Time) []Reminder {
+	horizon := now.Add(within)
+	var reminders []Reminder
+	for _, task := range tasks {
+		if task.Due.IsZero() || task.Status == TaskStatusCompleted || !task.Due.Before(horizon) {
+			continue
+		}
+		reminders = append(reminders, Reminder{Task: task, Overdue: task.IsOverdue(now)})
+	}
+
+	sort.Slice(reminders, func(i, j int) bool {
+		if !reminders[i].Task.Due.Equal(reminders[j].Task.Due) {
+			return reminders[i].Task.Due.Before(reminders[j].Task.Due)
+		}
+		return reminders[i].Task.Title < reminders[j].Task.Title
+	})
+	return reminders
+}
+
+// Reminded reports whether the reminder was sent to sink. Reminders recorded
+// without a sink count as sent to all of them.
+func (s *TaskService) Reminded(r Reminder, sink string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	_, sent := s.Reminders[r.SinkKey(sink)]
+	_, sentToAll := s.Reminders[r.Key()]
+	return sent || sentToAll
+}
+
+// MarkReminded records that the reminder was sent to sink. State for tasks
+// that were since completed, deleted or rescheduled is dropped so that the
+// stored state does not grow without bound.
+func (s *TaskService) MarkReminded(r Reminder, sink string, now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Reminders == nil {
+		s.Reminders = make(map[string]time.Time)
+	}
+	s.Reminders[r.SinkKey(sink)] = now
+
+	current := make(map[string]bool, len(s.Tasks))
+	for _, task := range s.Tasks {
+		if !task.Due.IsZero() && task.Status != TaskStatusCompleted {
+			current[Reminder{Task: task}.Key()] = true
+		}
+	}
+	for key := range s.Reminders {
+		if reminder, _, _ := strings.Cut(key, "/"); !current[reminder] {
+			delete(s.Reminders, key)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package services
+
+import (
+	"maps"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestDueReminders(t *testing.T) {
+	// Set up a temporary file
+	_, cleanup := createTempTaskFile(t)
+	defer cleanup()
+
+	now := time.Date(2026, 10, 19, 9, 0, 0, 0, time.Local)
+	day := func(offset int) time.Time {
+		return time.Date(2026, 10, 19+offset, 0, 0, 0, 0, time.Local)
+	}
+
+	service := loadService(t)
+	for title, due := range map[string]time.Time{
+		"Overdue":   day(-2),
+		"Today":     day(0),
+		"Tomorrow":  day(1),
+		"Next week": day(7),
+		"Done":      day(0),
+		"Undated":   {},
+	} {
+		service.AddTask(title)
+		service.UpdateTask(title, func(task *Task) { task.Due = due })
+	}
+	service.CompleteTask("Done")
+
+	reminders := DueReminders(slices.Collect(maps.Values(service.Tasks)), 48*time.Hour, now)
+	var titles []string
+	for _, r := range reminders {
+		titles = append(titles, r.Task.Title)
+	}
+	want := []string{"Overdue", "Today", "Tomorrow"}
+	if len(titles) != len(want) {
+		t.Fatalf("Expected reminders for %v, got %v", want, titles)
+	}
+	for i := range want {
+		if titles[i] != want[i] {
+			t.Errorf("Reminder %d = %q, want %q", i, titles[i], want[i])
+		}
+	}
+	if !reminders[0].Overdue || reminders[1].Overdue {
+		t.Errorf("Expected only the first reminder to be overdue")
+	}
+
+	// Sent reminders are remembered
+	service.MarkReminded(reminders[0], "terminal", now)
+	if !service.Reminded(reminders[0], "terminal") || service.Reminded(reminders[1], "terminal") {
+		t.Errorf("Expected only the first reminder to be sent, got %v", service.Reminders)
+	}
+
+	// Other sinks are still due the reminder
+	if service.Reminded(reminders[0], "webhook") {
+		t.Errorf("Expected the webhook to still be due the reminder")
+	}
+
+	// Reminders recorded without a sink count as sent to every sink
+	service.Reminders[reminders[1].Key()] = now
+	if !service.Reminded(reminders[1], "webhook") {
+		t.Errorf("Expected a reminder recorded without a sink to be sent")
+	}
+
+	// Moving the due date makes the reminder due again
+	service.UpdateTask("Overdue", func(task *Task) { task.Due = day(-1) })
+	overdue, _ := service.GetTask("Overdue")
+	if service.Reminded(Reminder{Task: overdue}, "terminal") {
+		t.Errorf("Expected rescheduled task to be reminded again")
+	}
+}
+
+func TestMarkRemindedPrunesState(t *testing.T) {
+	// Set up a temporary file
+	_, cleanup := createTempTaskFile(t)
+	defer cleanup()
+
+	now := time.Date(2026, 10, 19, 9, 0, 0, 0, time.Local)
+	service := loadService(t)
+	service.AddTask("A")
+	service.AddTask("B")
+	for _, title := range []string{"A", "B"} {
+		service.UpdateTask(title, func(task *Task) { task.Due = truncateDay(now) })
+	}
+
+	reminders := DueReminders(slices.Collect(maps.Values(service.Tasks)), time.Hour, now)
+	service.MarkReminded(reminders[0], "terminal", now)
+	service.CompleteTask(reminders[0].Task.Title)
+	service.MarkReminded(reminders[1], "terminal", now)
+
+	if len(service.Reminders) != 1 {
+		t.Errorf("Expected state of completed task to be pruned, got %v", service.Reminders)
+	}
+
+	// Sent state survives a reload
+	service.SaveTasks()
+	reloaded := loadService(t)
+	if _, ok := reloaded.Reminders[reminders[1].SinkKey("terminal")]; !ok {
+		t.Errorf("Expected sent reminder to be persisted, got %v", reloaded.Reminders)
+	}
+}
+
+func TestReminderMessage(t *testing.T) {
+	now := time.Date(2026, 10, 19, 9, 0, 0, 0, time.Local)
+	tests := []struct {
+		due     time.Time
+		overdue bool
+		want    string
+	}{
+		{due: time.Date(2026, 10, 17, 0, 0, 0, 0, time.Local), overdue: true, want: "Task is overdue (due 2026-10-17)"},
+		{due: time.Date(2026, 10, 19, 0, 0, 0, 0, time.Local), want: "Task is due today"},
+		{due: time.Date(2026, 10, 20, 0, 0, 0, 0, time.Local), want: "Task is due tomorrow"},
+		{due: time.Date(2026, 10, 22, 0, 0, 0, 0, time.Local), want: "Task is due 2026-10-22"},
+	}
+
+	for _, tt := range tests {
+		r := Reminder{Task: Task{Title: "Task", Due: tt.due}, Overdue: tt.overdue}
+		if got := r.Message(now); got != tt.want {
+			t.Errorf("Message() = %q, want %q", got, tt.want)
+		}
+	}
+}
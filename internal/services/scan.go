@@ -0,0 +1,340 @@
+package services
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Comment is a TODO, FIXME or XXX comment found in a source file.
+type Comment struct {
+	// Path is slash-separated and starts with the scanned root.
+	Path string
+	Line int
+	// Kind is TODO, FIXME or XXX.
+	Kind string
+	Text string
+	// FileType names the language of the file, such as go or python.
+	FileType string
+	// Fingerprint identifies the comment across scans. It does not depend
+	// on the line, so that comments moved by edits above them keep it.
+	Fingerprint string
+}
+
+// Location returns the file and line of the comment, as in "main.go:12".
+func (c Comment) Location() string {
+	return c.Path + ":" + strconv.Itoa(c.Line)
+}
+
+// sourceLanguage describes how to find comments in a kind of source file.
+type sourceLanguage struct {
+	fileType string
+	pattern  *regexp.Regexp
+}
+
+// newSourceLanguage matches TODO, FIXME and XXX after one of the comment
+// markers of a language. Languages with /* */ comments also match them at
+// the start of a continuation line beginning with *.
+func newSourceLanguage(fileType string, markers ...string) sourceLanguage {
+	var alternatives []string
+	for _, marker := range markers {
+		alternatives = append(alternatives, regexp.QuoteMeta(marker))
+		if marker == "/*" {
+			alternatives = append(alternatives, `^\s*\*`)
+		}
+	}
+	pattern := `(?:` + strings.Join(alternatives, "|") + `)[\s*/!#-]*\b(TODO|FIXME|XXX)\b(?:\([^)]*\))?[:\s]*(.*)`
+	return sourceLanguage{fileType: fileType, pattern: regexp.MustCompile(pattern)}
+}
+
+var (
+	slashComments = []string{"//", "/*"}
+	hashComments  = []string{"#"}
+)
+
+// sourceExtensions maps file extensions to the languages scanned for
+// comments. Files with other extensions are skipped.
+var sourceExtensions = map[string]sourceLanguage{
+	".go":    newSourceLanguage("go", slashComments...),
+	".c":     newSourceLanguage("c", slashComments...),
+	".h":     newSourceLanguage("c", slashComments...),
+	".cc":    newSourceLanguage("cpp", slashComments...),
+	".cpp":   newSourceLanguage("cpp", slashComments...),
+	".hpp":   newSourceLanguage("cpp", slashComments...),
+	".cs":    newSourceLanguage("csharp", slashComments...),
+	".java":  newSourceLanguage("java", slashComments...),
+	".kt":    newSourceLanguage("kotlin", slashComments...),
+	".swift": newSourceLanguage("swift", slashComments...),
+	".rs":    newSourceLanguage("rust", slashComments...),
+	".js":    newSourceLanguage("javascript", slashComments...),
+	".jsx":   newSourceLanguage("javascript", slashComments...),
+	".mjs":   newSourceLanguage("javascript", slashComments...),
+	".ts":    newSourceLanguage("typescript", slashComments...),
+	".tsx":   newSourceLanguage("typescript", slashComments...),
+	".css":   newSourceLanguage("css", "/*"),
+	".scss":  newSourceLanguage("css", slashComments...),
+	".php":   newSourceLanguage("php", "//", "/*", "#"),
+	".py":    newSourceLanguage("python", hashComments...),
+	".rb":    newSourceLanguage("ruby", hashComments...),
+	".pl":    newSourceLanguage("perl", hashComments...),
+	".sh":    newSourceLanguage("shell", hashComments...),
+	".bash":  newSourceLanguage("shell", hashComments...),
+	".zsh":   newSourceLanguage("shell", hashComments...),
+	".yaml":  newSourceLanguage("yaml", hashComments...),
+	".yml":   newSourceLanguage("yaml", hashComments...),
+	".toml":  newSourceLanguage("toml", hashComments...),
+	".sql":   newSourceLanguage("sql", "--", "/*"),
+	".lua":   newSourceLanguage("lua", "--"),
+	".hs":    newSourceLanguage("haskell", "--"),
+	".html":  newSourceLanguage("html", "<!--"),
+	".xml":   newSourceLanguage("xml", "<!--"),
+}
+
+// sourceNames maps the names of files without a telling extension to their
+// languages.
+var sourceNames = map[string]sourceLanguage{
+	"Makefile":   newSourceLanguage("make", hashComments...),
+	"Dockerfile": newSourceLanguage("docker", hashComments...),
+}
+
+// ScanComments walks the tree under root and returns the TODO, FIXME and XXX
+// comments of its source files, ordered by path and line. Files and
+// directories ignored by the .gitignore files of the tree are skipped, as is
+// the .git directory.
+func ScanComments(root string) ([]Comment, error) {
+	root = filepath.Clean(root)
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", root)
+	}
+
+	ignore := &gitIgnore{}
+	var comments []Comment
+	err = filepath.WalkDir(root, func(name string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(root, name)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		if entry.IsDir() {
+			if rel == "." {
+				rel = ""
+			} else if entry.Name() == ".git" || ignore.ignored(rel, true) {
+				return filepath.SkipDir
+			}
+			return addGitIgnore(ignore, rel, filepath.Join(name, ".gitignore"))
+		}
+		if !entry.Type().IsRegular() || ignore.ignored(rel, false) {
+			return nil
+		}
+		language, ok := sourceExtensions[strings.ToLower(path.Ext(rel))]
+		if !ok {
+			if language, ok = sourceNames[entry.Name()]; !ok {
+				return nil
+			}
+		}
+		found, err := scanFile(name, filepath.ToSlash(name), language)
+		if err != nil {
+			return fmt.Errorf("failed to scan %s: %w", name, err)
+		}
+		comments = append(comments, found...)
+		return nil
+	})
+	return comments, err
+}
+
+// addGitIgnore adds the patterns of the .gitignore file at name, if there is
+// one, for the directory dir.
+func addGitIgnore(ignore *gitIgnore, dir, name string) error {
+	file, err := os.Open(name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return ignore.add(dir, file)
+}
+
+// scanFile returns the comments of the file at name, reported under
+// display.
+func scanFile(name, display string, language sourceLanguage) ([]Comment, error) {
+	file, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var comments []Comment
+	occurrences := make(map[string]int)
+	reader := bufio.NewReader(file)
+	for line := 1; ; line++ {
+		text, err := reader.ReadString('\n')
+		if strings.IndexByte(text, 0) >= 0 {
+			// Binary files have no comments worth tracking
+			return nil, nil
+		}
+		if match := language.pattern.FindStringSubmatch(text); match != nil {
+			comment := Comment{
+				Path:     display,
+				Line:     line,
+				Kind:     match[1],
+				Text:     cleanCommentText(match[2]),
+				FileType: language.fileType,
+			}
+			key := comment.Kind + "\x00" + comment.Text
+			comment.Fingerprint = commentFingerprint(comment, occurrences[key])
+			occurrences[key]++
+			comments = append(comments, comment)
+		}
+		if err == io.EOF {
+			return comments, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// cleanCommentText drops the end of a block comment and repeated whitespace.
+func cleanCommentText(text string) string {
+	text = strings.TrimSpace(text)
+	for _, end := range []string{"*/", "-->"} {
+		text = strings.TrimSpace(strings.TrimSuffix(text, end))
+	}
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// commentFingerprint hashes the path, kind and text of a comment with the
+// number of identical comments before it in the file.
+func commentFingerprint(c Comment, occurrence int) string {
+	sum := sha256.Sum256(fmt.Appendf(nil, "%s\x00%s\x00%s\x00%d", c.Path, c.Kind, c.Text, occurrence))
+	return hex.EncodeToString(sum[:6])
+}
+
+// scanTag marks tasks created from comments, and scanNotePrefix starts the
+// note holding the fingerprint of their comment.
+const (
+	scanTag        = "scan"
+	scanNotePrefix = "fingerprint: "
+)
+
+// ScanFingerprint returns the fingerprint of the comment a task was created
+// from, or "" if it was not created by a scan.
+func ScanFingerprint(task Task) string {
+	for _, note := range task.Notes {
+		if fingerprint, ok := strings.CutPrefix(note, scanNotePrefix); ok {
+			return fingerprint
+		}
+	}
+	return ""
+}
+
+// ScanPlan lists the changes that bring tasks in line with the comments
+// found by a scan.
+type ScanPlan struct {
+	// New holds the tasks to add for comments seen for the first time.
+	New []Task
+	// Moved holds the tasks of comments found at another line of the same
+	// file, with their description set to the new location. A comment moved
+	// to another file has another fingerprint and gets a new task.
+	Moved []Task
+	// Missing holds the open tasks of comments under the scanned root that
+	// were not found any more.
+	Missing []Task
+	// Unchanged counts the comments whose task is up to date.
+	Unchanged int
+}
+
+// PlanScan matches comments found under root to tasks by fingerprint.
+// Comments without a task get a new task titled after the comment, tagged
+// with the scan tag, the file type and the kind of comment, and described
+// by the location of the comment.
+func PlanScan(tasks []Task, comments []Comment, root string) ScanPlan {
+	byFingerprint := make(map[string]Task)
+	taken := make(map[string]bool, len(tasks))
+	for _, task := range tasks {
+		taken[task.Title] = true
+		if fingerprint := ScanFingerprint(task); fingerprint != "" {
+			byFingerprint[fingerprint] = task
+		}
+	}
+
+	var plan ScanPlan
+	found := make(map[string]bool, len(comments))
+	for _, comment := range comments {
+		found[comment.Fingerprint] = true
+		if task, ok := byFingerprint[comment.Fingerprint]; ok {
+			if task.Description == comment.Location() {
+				plan.Unchanged++
+				continue
+			}
+			task.Description = comment.Location()
+			plan.Moved = append(plan.Moved, task)
+			continue
+		}
+
+		task := Task{
+			Title:       scanTitle(comment),
+			Description: comment.Location(),
+			Notes:       []string{scanNotePrefix + comment.Fingerprint},
+			Tags:        []string{scanTag, comment.FileType, strings.ToLower(comment.Kind)},
+			Status:      TaskStatusPending,
+		}
+		if taken[task.Title] {
+			task.Title = fmt.Sprintf("%s (%s)", task.Title, comment.Fingerprint[:7])
+		}
+		taken[task.Title] = true
+		plan.New = append(plan.New, task)
+	}
+
+	for _, task := range tasks {
+		fingerprint := ScanFingerprint(task)
+		if fingerprint == "" || found[fingerprint] || task.Status == TaskStatusCompleted {
+			continue
+		}
+		if underRoot(task.Description, root) {
+			plan.Missing = append(plan.Missing, task)
+		}
+	}
+	slices.SortFunc(plan.Missing, func(a, b Task) int { return strings.Compare(a.Description, b.Description) })
+	return plan
+}
+
+// underRoot reports whether the location of a comment lies under the root
+// of a scan, both given as seen from the working directory.
+func underRoot(location, root string) bool {
+	root = filepath.ToSlash(filepath.Clean(root))
+	if root == "." {
+		return !path.IsAbs(location) && !strings.HasPrefix(location, "../")
+	}
+	return strings.HasPrefix(location, strings.TrimSuffix(root, "/")+"/")
+}
+
+// scanTitle titles the task of a comment after its text, or after its
+// location when the comment has no text.
+func scanTitle(c Comment) string {
+	if c.Text == "" {
+		return fmt.Sprintf("%s in %s", c.Kind, c.Path)
+	}
+	return c.Kind + ": " + c.Text
+}
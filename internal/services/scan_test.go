@@ -0,0 +1,116 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeScanTree creates files under dir from a map of slash-separated paths
+// to contents.
+func writeScanTree(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("MkdirAll() error = %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("WriteFile() error = %v", err)
+		}
+	}
+}
+
+func TestScanComments(t *testing.T) {
+	dir := t.TempDir()
+	writeScanTree(t, dir, map[string]string{
+		".gitignore":      "vendor/\n",
+		"main.go":         "package main\n\n// TODO: handle errors\nfunc main() {} // FIXME(ann): slow\n/*\n * XXX remove hack */\n// TODO: handle errors\nvar s = \"TODO not a comment\"\n",
+		"tool/run.py":     "# TODO rewrite\nprint('x')\n",
+		"vendor/lib.go":   "// TODO ignored\n",
+		"notes.txt":       "TODO not source\n",
+		"Makefile":        "# FIXME flaky target\n",
+		".git/hooks/x.sh": "# TODO git internals\n",
+	})
+
+	comments, err := ScanComments(dir)
+	if err != nil {
+		t.Fatalf("ScanComments() error = %v", err)
+	}
+	var got []string
+	for _, c := range comments {
+		rel, _ := filepath.Rel(dir, filepath.FromSlash(c.Path))
+		got = append(got, filepath.ToSlash(rel)+":"+c.Kind+":"+c.Text+":"+c.FileType)
+	}
+	want := []string{
+		"Makefile:FIXME:flaky target:make",
+		"main.go:TODO:handle errors:go",
+		"main.go:FIXME:slow:go",
+		"main.go:XXX:remove hack:go",
+		"main.go:TODO:handle errors:go",
+		"tool/run.py:TODO:rewrite:python",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("ScanComments() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	if comments[1].Line != 3 || comments[3].Line != 6 {
+		t.Errorf("Expected lines 3 and 6, got %d and %d", comments[1].Line, comments[3].Line)
+	}
+	if comments[1].Fingerprint == comments[4].Fingerprint {
+		t.Errorf("Expected repeated comments to get distinct fingerprints")
+	}
+
+	// Moving comments down keeps their fingerprints
+	content, _ := os.ReadFile(filepath.Join(dir, "main.go"))
+	os.WriteFile(filepath.Join(dir, "main.go"), append([]byte("// header\n"), content...), 0644)
+	moved, err := ScanComments(dir)
+	if err != nil {
+		t.Fatalf("ScanComments() error = %v", err)
+	}
+	if moved[1].Fingerprint != comments[1].Fingerprint || moved[1].Line != 4 {
+		t.Errorf("Expected the same fingerprint at line 4, got %s at %d", moved[1].Fingerprint, moved[1].Line)
+	}
+
+	if _, err := ScanComments(filepath.Join(dir, "main.go")); err == nil {
+		t.Errorf("Expected an error for a file root")
+	}
+}
+
+func TestPlanScan(t *testing.T) {
+	comments := []Comment{
+		{Path: "src/a.go", Line: 3, Kind: "TODO", Text: "fix", FileType: "go", Fingerprint: "aaaaaaaaaaaa"},
+		{Path: "src/a.go", Line: 9, Kind: "FIXME", Text: "moved", FileType: "go", Fingerprint: "bbbbbbbbbbbb"},
+		{Path: "src/b.py", Line: 1, Kind: "TODO", Text: "taken", FileType: "python", Fingerprint: "cccccccccccc"},
+		{Path: "src/c.go", Line: 2, Kind: "XXX", FileType: "go", Fingerprint: "dddddddddddd"},
+	}
+	tasks := []Task{
+		{Title: "TODO: fix", Description: "src/a.go:3", Notes: []string{"fingerprint: aaaaaaaaaaaa"}, Status: TaskStatusPending},
+		{Title: "FIXME: moved", Description: "src/a.go:5", Notes: []string{"fingerprint: bbbbbbbbbbbb"}, Status: TaskStatusPending},
+		{Title: "TODO: taken", Status: TaskStatusPending},
+		{Title: "TODO: gone", Description: "src/old.go:1", Notes: []string{"fingerprint: eeeeeeeeeeee"}, Status: TaskStatusInProgress},
+		{Title: "TODO: done", Description: "src/old.go:2", Notes: []string{"fingerprint: ffffffffffff"}, Status: TaskStatusCompleted},
+		{Title: "TODO: elsewhere", Description: "other/x.go:1", Notes: []string{"fingerprint: 111111111111"}, Status: TaskStatusPending},
+	}
+
+	plan := PlanScan(tasks, comments, "src")
+	if plan.Unchanged != 1 {
+		t.Errorf("Unchanged = %d, want 1", plan.Unchanged)
+	}
+	if len(plan.Moved) != 1 || plan.Moved[0].Description != "src/a.go:9" {
+		t.Errorf("Expected FIXME: moved to move to src/a.go:9, got %+v", plan.Moved)
+	}
+	if len(plan.New) != 2 || plan.New[0].Title != "TODO: taken (ccccccc)" || plan.New[1].Title != "XXX in src/c.go" {
+		t.Fatalf("Unexpected new tasks %+v", plan.New)
+	}
+	if ScanFingerprint(plan.New[0]) != "cccccccccccc" || !plan.New[0].HasTag("python") || !plan.New[0].HasTag("scan") {
+		t.Errorf("Expected the fingerprint note and tags, got %+v", plan.New[0])
+	}
+	if len(plan.Missing) != 1 || plan.Missing[0].Title != "TODO: gone" {
+		t.Errorf("Expected only the open task under src to be missing, got %+v", plan.Missing)
+	}
+
+	if plan := PlanScan(tasks, comments, "."); len(plan.Missing) != 2 {
+		t.Errorf("Expected tasks anywhere below . to be missing, got %+v", plan.Missing)
+	}
+}
@@ -0,0 +1,173 @@
+package services
+
+import (
+	"errors"
+	"regexp"
+	"sort"
+	"unicode"
+	"unicode/utf8"
+)
+
+type SearchMode string
+
+const (
+	SearchSubstring SearchMode = "substring"
+	SearchRegex     SearchMode = "regex"
+	SearchFuzzy     SearchMode = "fuzzy"
+)
+
+// Searchable task fields. Title matches rank above description matches, which
+// rank above note matches.
+const (
+	FieldTitle       = "title"
+	FieldDescription = "description"
+	FieldNote        = "note"
+)
+
+var fieldWeights = map[string]float64{
+	FieldTitle:       3,
+	FieldDescription: 2,
+	FieldNote:        1,
+}
+
+// Span is a matched byte range [Start, End) within a field value.
+type Span struct {
+	Start int
+	End   int
+}
+
+// FieldMatch holds the matched spans within one field of a task. Index is the
+// position in Task.Notes for note matches and zero otherwise.
+type FieldMatch struct {
+	Field string
+	Index int
+	Text  string
+	Spans []Span
+}
+
+type SearchResult struct {
+	Task    Task
+	Score   float64
+	Matches []FieldMatch
+}
+
+// matcher finds the spans of a query in text and scores the match in (0, 1].
+type matcher func(text string) ([]Span, float64)
+
+// SearchTasks matches query case-insensitively against the titles,
+// descriptions and notes of tasks. Results are ordered best match first.
+func SearchTasks(tasks []Task, query string, mode SearchMode) ([]SearchResult, error) {
+	if query == "" {
+		return nil, errors.New("search query cannot be empty")
+	}
+
+	match, err := newMatcher(query, mode)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []SearchResult
+	for _, task := range tasks {
+		result := SearchResult{Task: task}
+
+		fields := []FieldMatch{
+			{Field: FieldTitle, Text: task.Title},
+			{Field: FieldDescription, Text: task.Description},
+		}
+		for i, note := range task.Notes {
+			fields = append(fields, FieldMatch{Field: FieldNote, Index: i, Text: note})
+		}
+
+		for _, field := range fields {
+			spans, score := match(field.Text)
+			if len(spans) == 0 {
+				continue
+			}
+			field.Spans = spans
+			result.Matches = append(result.Matches, field)
+			result.Score += score * fieldWeights[field.Field]
+		}
+
+		if len(result.Matches) > 0 {
+			results = append(results, result)
+		}
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].Task.Title < results[j].Task.Title
+	})
+	return results, nil
+}
+
+func newMatcher(query string, mode SearchMode) (matcher, error) {
+	switch mode {
+	case SearchSubstring, "":
+		return regexpMatcher(regexp.MustCompile("(?i)" + regexp.QuoteMeta(query))), nil
+	case SearchRegex:
+		re, err := regexp.Compile("(?i)" + query)
+		if err != nil {
+			return nil, errors.New("invalid regular expression: " + err.Error())
+		}
+		return regexpMatcher(re), nil
+	case SearchFuzzy:
+		return fuzzyMatcher(query), nil
+	default:
+		return nil, errors.New("unknown search mode: " + string(mode))
+	}
+}
+
+// regexpMatcher scores a match by how early it starts and how much of the
+// text it covers, so that exact and leading matches rank first.
+func regexpMatcher(re *regexp.Regexp) matcher {
+	return func(text string) ([]Span, float64) {
+		var spans []Span
+		covered := 0
+		for _, loc := range re.FindAllStringIndex(text, -1) {
+			if loc[0] == loc[1] {
+				continue
+			}
+			spans = append(spans, Span{Start: loc[0], End: loc[1]})
+			covered += loc[1] - loc[0]
+		}
+		if len(spans) == 0 {
+			return nil, 0
+		}
+		coverage := float64(covered) / float64(len(text))
+		position := 1 / float64(1+spans[0].Start)
+		return spans, (1 + coverage + position) / 3
+	}
+}
+
+// fuzzyMatcher matches the query runes as an in-order subsequence of the
+// text. Consecutive runs of matched runes score higher than scattered ones.
+func fuzzyMatcher(query string) matcher {
+	needle := []rune(query)
+	return func(text string) ([]Span, float64) {
+		var spans []Span
+		n := 0
+		for i, r := range text {
+			if n == len(needle) {
+				break
+			}
+			if unicode.ToLower(r) != unicode.ToLower(needle[n]) {
+				continue
+			}
+			end := i + utf8.RuneLen(r)
+			if len(spans) > 0 && spans[len(spans)-1].End == i {
+				spans[len(spans)-1].End = end
+			} else {
+				spans = append(spans, Span{Start: i, End: end})
+			}
+			n++
+		}
+		if n < len(needle) {
+			return nil, 0
+		}
+		compactness := 1 / float64(len(spans))
+		position := 1 / float64(1+spans[0].Start)
+		return spans, (compactness + position) / 2
+	}
+}
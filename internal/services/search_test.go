@@ -0,0 +1,117 @@
+package services
+
+import (
+	"maps"
+	"slices"
+	"testing"
+)
+
+func setupSearchService(t *testing.T) (*TaskService, func()) {
+	_, cleanup := createTempTaskFile(t)
+
+	service := loadService(t)
+	service.AddTask("Write release notes")
+	service.AddTask("Review dependencies")
+	service.UpdateTask("Review dependencies", func(task *Task) {
+		task.Description = "Check go.mod for outdated release versions"
+	})
+	service.AddTask("Fix login bug")
+	service.UpdateTask("Fix login bug", func(task *Task) {
+		task.Notes = []string{"Reported by QA", "Happens after RELEASE deploy"}
+	})
+	service.CompleteTask("Fix login bug")
+
+	return service, cleanup
+}
+
+func TestSearchTasksSubstring(t *testing.T) {
+	service, cleanup := setupSearchService(t)
+	defer cleanup()
+
+	results, err := SearchTasks(slices.Collect(maps.Values(service.Tasks)), "release", SearchSubstring)
+	if err != nil {
+		t.Fatalf("SearchTasks returned unexpected error: %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("Expected 3 results, got %d", len(results))
+	}
+
+	// Title matches rank above description matches, which rank above notes
+	want := []string{"Write release notes", "Review dependencies", "Fix login bug"}
+	for i, title := range want {
+		if results[i].Task.Title != title {
+			t.Errorf("Result %d: got %q, want %q", i, results[i].Task.Title, title)
+		}
+	}
+
+	note := results[2].Matches[0]
+	if note.Field != FieldNote || note.Index != 1 {
+		t.Errorf("Expected match in second note, got %+v", note)
+	}
+	if got := note.Text[note.Spans[0].Start:note.Spans[0].End]; got != "RELEASE" {
+		t.Errorf("Expected span to cover %q, got %q", "RELEASE", got)
+	}
+}
+
+func TestSearchTasksStatusFilter(t *testing.T) {
+	service, cleanup := setupSearchService(t)
+	defer cleanup()
+
+	results, err := SearchTasks(slices.Collect(maps.Values(service.GetTasks(TaskStatusPending))), "release", SearchSubstring)
+	if err != nil {
+		t.Fatalf("SearchTasks returned unexpected error: %v", err)
+	}
+	if len(results) != 2 {
+		t.Errorf("Expected 2 pending results, got %d", len(results))
+	}
+	for _, result := range results {
+		if result.Task.Status != TaskStatusPending {
+			t.Errorf("Task %q has status %s, want pending", result.Task.Title, result.Task.Status)
+		}
+	}
+}
+
+func TestSearchTasksRegex(t *testing.T) {
+	service, cleanup := setupSearchService(t)
+	defer cleanup()
+
+	results, err := SearchTasks(slices.Collect(maps.Values(service.Tasks)), `^(fix|write)\b`, SearchRegex)
+	if err != nil {
+		t.Fatalf("SearchTasks returned unexpected error: %v", err)
+	}
+	if len(results) != 2 {
+		t.Errorf("Expected 2 results, got %d", len(results))
+	}
+
+	_, err = SearchTasks(slices.Collect(maps.Values(service.Tasks)), `(unclosed`, SearchRegex)
+	if err == nil {
+		t.Errorf("Expected error for invalid regular expression")
+	}
+}
+
+func TestSearchTasksFuzzy(t *testing.T) {
+	service, cleanup := setupSearchService(t)
+	defer cleanup()
+
+	results, err := SearchTasks(slices.Collect(maps.Values(service.Tasks)), "rvwdps", SearchFuzzy)
+	if err != nil {
+		t.Fatalf("SearchTasks returned unexpected error: %v", err)
+	}
+	if len(results) != 1 || results[0].Task.Title != "Review dependencies" {
+		t.Fatalf("Expected fuzzy match on 'Review dependencies', got %+v", results)
+	}
+
+	spans := results[0].Matches[0].Spans
+	if len(spans) == 0 || spans[0].Start != 0 {
+		t.Errorf("Expected first span to start at 0, got %+v", spans)
+	}
+}
+
+func TestSearchTasksEmptyQuery(t *testing.T) {
+	service, cleanup := setupSearchService(t)
+	defer cleanup()
+
+	if _, err := SearchTasks(slices.Collect(maps.Values(service.Tasks)), "", SearchSubstring); err == nil {
+		t.Errorf("Expected error for empty query")
+	}
+}
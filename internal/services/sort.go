@@ -0,0 +1,89 @@
+package services
+
+import (
+	"errors"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// SortFields lists the keys accepted by SortTasks.
+var SortFields = []string{"created", "updated", "due", "priority", "status", "title"}
+
+// ValidateSortOrder checks a comma-separated list of sort keys. Each key may
+// be prefixed with "-" for descending order, e.g. "-priority,due".
+func ValidateSortOrder(order string) error {
+	for _, key := range splitSortOrder(order) {
+		field := strings.TrimPrefix(key, "-")
+		if !slices.Contains(SortFields, field) {
+			return errors.New("unknown sort field " + field + ", expected one of: " + strings.Join(SortFields, ", "))
+		}
+	}
+	return nil
+}
+
+// SortTasks returns tasks ordered by the given sort keys, falling back to
+// creation time and then title so that the order is stable.
+func SortTasks(tasks map[string]Task, order string) ([]Task, error) {
+	if err := ValidateSortOrder(order); err != nil {
+		return nil, err
+	}
+
+	keys := append(splitSortOrder(order), "created", "title")
+	sorted := make([]Task, 0, len(tasks))
+	for _, task := range tasks {
+		sorted = append(sorted, task)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		for _, key := range keys {
+			field := strings.TrimPrefix(key, "-")
+			c := compareTasks(sorted[i], sorted[j], field)
+			if c == 0 {
+				continue
+			}
+			if strings.HasPrefix(key, "-") {
+				return c > 0
+			}
+			return c < 0
+		}
+		return false
+	})
+	return sorted, nil
+}
+
+func compareTasks(a, b Task, field string) int {
+	switch field {
+	case "created":
+		return a.CreatedAt.Compare(b.CreatedAt)
+	case "updated":
+		return a.UpdatedAt.Compare(b.UpdatedAt)
+	case "due":
+		// Tasks without a due date sort last
+		switch {
+		case a.Due.IsZero() && b.Due.IsZero():
+			return 0
+		case a.Due.IsZero():
+			return 1
+		case b.Due.IsZero():
+			return -1
+		}
+		return a.Due.Compare(b.Due)
+	case "priority":
+		return a.Priority.Rank() - b.Priority.Rank()
+	case "status":
+		return strings.Compare(string(a.Status), string(b.Status))
+	case "title":
+		return strings.Compare(a.Title, b.Title)
+	}
+	return 0
+}
+
+func splitSortOrder(order string) []string {
+	var keys []string
+	for _, key := range strings.Split(order, ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
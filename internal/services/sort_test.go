@@ -0,0 +1,53 @@
+package services
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSortTasks(t *testing.T) {
+	base := time.Date(2026, 10, 1, 0, 0, 0, 0, time.Local)
+	tasks := map[string]Task{
+		"a": {Title: "a", CreatedAt: base, Priority: TaskPriorityLow, Due: base.AddDate(0, 0, 5)},
+		"b": {Title: "b", CreatedAt: base.Add(time.Hour), Priority: TaskPriorityCritical},
+		"c": {Title: "c", CreatedAt: base.Add(2 * time.Hour), Priority: TaskPriorityLow, Due: base.AddDate(0, 0, 1)},
+	}
+
+	tests := []struct {
+		order string
+		want  string
+	}{
+		{order: "", want: "a,b,c"},
+		{order: "-created", want: "c,b,a"},
+		{order: "-priority", want: "b,a,c"},
+		{order: "-priority,-created", want: "b,c,a"},
+		{order: "due", want: "c,a,b"},
+		{order: "title", want: "a,b,c"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.order, func(t *testing.T) {
+			sorted, err := SortTasks(tasks, tt.order)
+			if err != nil {
+				t.Fatalf("SortTasks returned unexpected error: %v", err)
+			}
+			var titles []string
+			for _, task := range sorted {
+				titles = append(titles, task.Title)
+			}
+			if got := strings.Join(titles, ","); got != tt.want {
+				t.Errorf("SortTasks(%q) = %s, want %s", tt.order, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateSortOrder(t *testing.T) {
+	if err := ValidateSortOrder("-priority, due"); err != nil {
+		t.Errorf("Unexpected error for valid order: %v", err)
+	}
+	if err := ValidateSortOrder("size"); err == nil {
+		t.Errorf("Expected error for unknown sort field")
+	}
+}
@@ -0,0 +1,163 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Stats periods used to bucket created and completed tasks.
+const (
+	PeriodDay  = "day"
+	PeriodWeek = "week"
+)
+
+// StatsOptions restricts the statistics to a time window. A zero Since or
+// Until leaves that side of the window open.
+type StatsOptions struct {
+	Since  time.Time
+	Until  time.Time
+	Period string
+	// Oldest is the number of oldest open tasks to report.
+	Oldest int
+}
+
+// PeriodCount is the number of tasks created and completed in one period.
+type PeriodCount struct {
+	Period    string `json:"period"`
+	Created   int    `json:"created"`
+	Completed int    `json:"completed"`
+}
+
+// Stats is a summary report of the task store. Lead time is measured from
+// creation to completion and cycle time from the first start to completion.
+type Stats struct {
+	Total            int                `json:"total"`
+	ByStatus         map[TaskStatus]int `json:"by_status"`
+	ByTag            map[string]int     `json:"by_tag"`
+	Throughput       []PeriodCount      `json:"throughput"`
+	AverageLeadTime  time.Duration      `json:"-"`
+	AverageCycleTime time.Duration      `json:"-"`
+	LeadTimeHours    float64            `json:"average_lead_time_hours"`
+	CycleTimeHours   float64            `json:"average_cycle_time_hours"`
+	OldestOpen       []Task             `json:"oldest_open"`
+	Effort           Effort             `json:"effort"`
+}
+
+// ComputeStats computes statistics over tasks. Status and tag counts and
+// effort totals cover tasks created within the window, throughput counts
+// creations and completions within it, and lead and cycle times cover tasks
+// completed within it.
+func ComputeStats(tasks []Task, opts StatsOptions) (Stats, error) {
+	if opts.Period == "" {
+		opts.Period = PeriodDay
+	}
+	if opts.Period != PeriodDay && opts.Period != PeriodWeek {
+		return Stats{}, errors.New("period must be one of: day, week")
+	}
+	if !opts.Since.IsZero() && !opts.Until.IsZero() && opts.Until.Before(opts.Since) {
+		return Stats{}, errors.New("until must not be before since")
+	}
+
+	stats := Stats{
+		ByStatus: map[TaskStatus]int{
+			TaskStatusPending:    0,
+			TaskStatusInProgress: 0,
+			TaskStatusCompleted:  0,
+		},
+		ByTag: make(map[string]int),
+	}
+
+	inWindow := func(t time.Time) bool {
+		if t.IsZero() {
+			return false
+		}
+		return (opts.Since.IsZero() || !t.Before(opts.Since)) && (opts.Until.IsZero() || t.Before(opts.Until))
+	}
+
+	buckets := make(map[string]*PeriodCount)
+	bucket := func(t time.Time) *PeriodCount {
+		key := periodKey(t, opts.Period)
+		if buckets[key] == nil {
+			buckets[key] = &PeriodCount{Period: key}
+		}
+		return buckets[key]
+	}
+
+	var leadTotal, cycleTotal time.Duration
+	var leadCount, cycleCount int
+	var open, counted []Task
+
+	for _, task := range tasks {
+		if inWindow(task.CreatedAt) {
+			counted = append(counted, task)
+			stats.Total++
+			stats.ByStatus[task.Status]++
+			for _, tag := range task.Tags {
+				stats.ByTag[tag]++
+			}
+			bucket(task.CreatedAt).Created++
+		}
+
+		if task.Status != TaskStatusCompleted {
+			if opts.Until.IsZero() || task.CreatedAt.Before(opts.Until) {
+				open = append(open, task)
+			}
+			continue
+		}
+
+		completedAt := task.CompletedAt
+		if completedAt.IsZero() {
+			// Tasks completed before completion times were recorded
+			completedAt = task.UpdatedAt
+		}
+		if !inWindow(completedAt) {
+			continue
+		}
+		bucket(completedAt).Completed++
+		leadTotal += completedAt.Sub(task.CreatedAt)
+		leadCount++
+		if !task.StartedAt.IsZero() {
+			cycleTotal += completedAt.Sub(task.StartedAt)
+			cycleCount++
+		}
+	}
+
+	if leadCount > 0 {
+		stats.AverageLeadTime = leadTotal / time.Duration(leadCount)
+	}
+	if cycleCount > 0 {
+		stats.AverageCycleTime = cycleTotal / time.Duration(cycleCount)
+	}
+	stats.LeadTimeHours = stats.AverageLeadTime.Hours()
+	stats.CycleTimeHours = stats.AverageCycleTime.Hours()
+
+	stats.Throughput = make([]PeriodCount, 0, len(buckets))
+	for _, count := range buckets {
+		stats.Throughput = append(stats.Throughput, *count)
+	}
+	sort.Slice(stats.Throughput, func(i, j int) bool {
+		return stats.Throughput[i].Period < stats.Throughput[j].Period
+	})
+
+	sort.Slice(open, func(i, j int) bool {
+		return open[i].CreatedAt.Before(open[j].CreatedAt)
+	})
+	if opts.Oldest >= 0 && len(open) > opts.Oldest {
+		open = open[:opts.Oldest]
+	}
+	stats.OldestOpen = open
+	stats.Effort = SummarizeEffort(counted, time.Now())
+
+	return stats, nil
+}
+
+// periodKey formats t as a day (2006-01-02) or an ISO week (2006-W01).
+func periodKey(t time.Time, period string) string {
+	if period == PeriodWeek {
+		year, week := t.ISOWeek()
+		return fmt.Sprintf("%d-W%02d", year, week)
+	}
+	return t.Format(DateLayout)
+}
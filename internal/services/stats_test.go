@@ -0,0 +1,118 @@
+package services
+
+import (
+	"testing"
+	"time"
+)
+
+func TestComputeStats(t *testing.T) {
+	day := func(d, h int) time.Time {
+		return time.Date(2026, 10, d, h, 0, 0, 0, time.Local)
+	}
+
+	tasks := []Task{
+		{Title: "old open", Status: TaskStatusPending, Tags: []string{"backend"}, CreatedAt: day(1, 9)},
+		{Title: "doing", Status: TaskStatusInProgress, Tags: []string{"backend", "ui"}, CreatedAt: day(5, 9), StartedAt: day(6, 9)},
+		{
+			Title: "done", Status: TaskStatusCompleted, CreatedAt: day(5, 9),
+			StartedAt: day(6, 9), CompletedAt: day(7, 9),
+		},
+		{
+			// Completed before completion times were recorded
+			Title: "legacy done", Status: TaskStatusCompleted, CreatedAt: day(6, 9), UpdatedAt: day(7, 21),
+		},
+		{Title: "out of window", Status: TaskStatusPending, CreatedAt: day(20, 9)},
+	}
+
+	stats, err := ComputeStats(tasks, StatsOptions{Since: day(1, 0), Until: day(10, 0), Oldest: 1})
+	if err != nil {
+		t.Fatalf("ComputeStats returned unexpected error: %v", err)
+	}
+
+	if stats.Total != 4 {
+		t.Errorf("Expected 4 tasks in window, got %d", stats.Total)
+	}
+	if stats.ByStatus[TaskStatusCompleted] != 2 || stats.ByStatus[TaskStatusPending] != 1 {
+		t.Errorf("Unexpected status counts: %v", stats.ByStatus)
+	}
+	if stats.ByTag["backend"] != 2 || stats.ByTag["ui"] != 1 {
+		t.Errorf("Unexpected tag counts: %v", stats.ByTag)
+	}
+
+	// done: 48h lead time, legacy done: 36h lead time
+	if stats.AverageLeadTime != 42*time.Hour {
+		t.Errorf("Expected average lead time 42h, got %v", stats.AverageLeadTime)
+	}
+	// Only "done" has a start time
+	if stats.AverageCycleTime != 24*time.Hour {
+		t.Errorf("Expected average cycle time 24h, got %v", stats.AverageCycleTime)
+	}
+
+	want := map[string]PeriodCount{
+		"2026-10-01": {Period: "2026-10-01", Created: 1},
+		"2026-10-05": {Period: "2026-10-05", Created: 2},
+		"2026-10-06": {Period: "2026-10-06", Created: 1},
+		"2026-10-07": {Period: "2026-10-07", Completed: 2},
+	}
+	if len(stats.Throughput) != len(want) {
+		t.Fatalf("Expected %d periods, got %+v", len(want), stats.Throughput)
+	}
+	for _, count := range stats.Throughput {
+		if count != want[count.Period] {
+			t.Errorf("Period %s: got %+v, want %+v", count.Period, count, want[count.Period])
+		}
+	}
+
+	if len(stats.OldestOpen) != 1 || stats.OldestOpen[0].Title != "old open" {
+		t.Errorf("Expected 'old open' as oldest open task, got %+v", stats.OldestOpen)
+	}
+}
+
+func TestComputeStatsWeekly(t *testing.T) {
+	tasks := []Task{
+		{Title: "a", Status: TaskStatusPending, CreatedAt: time.Date(2026, 10, 19, 9, 0, 0, 0, time.Local)},
+		{Title: "b", Status: TaskStatusPending, CreatedAt: time.Date(2026, 10, 25, 9, 0, 0, 0, time.Local)},
+	}
+
+	stats, err := ComputeStats(tasks, StatsOptions{Period: PeriodWeek})
+	if err != nil {
+		t.Fatalf("ComputeStats returned unexpected error: %v", err)
+	}
+	if len(stats.Throughput) != 1 || stats.Throughput[0] != (PeriodCount{Period: "2026-W43", Created: 2}) {
+		t.Errorf("Expected both tasks in 2026-W43, got %+v", stats.Throughput)
+	}
+}
+
+func TestComputeStatsInvalidOptions(t *testing.T) {
+	if _, err := ComputeStats(nil, StatsOptions{Period: "month"}); err == nil {
+		t.Errorf("Expected error for unknown period")
+	}
+
+	now := time.Now()
+	if _, err := ComputeStats(nil, StatsOptions{Since: now, Until: now.Add(-time.Hour)}); err == nil {
+		t.Errorf("Expected error when until is before since")
+	}
+}
+
+func TestMarkTaskRecordsTimes(t *testing.T) {
+	_, cleanup := createTempTaskFile(t)
+	defer cleanup()
+
+	service := loadService(t)
+	service.AddTask("Task")
+	service.InProgressTask("Task")
+	started := service.Tasks["Task"].StartedAt
+	if started.IsZero() {
+		t.Fatalf("Expected StartedAt to be set")
+	}
+
+	service.InProgressTask("Task")
+	if !service.Tasks["Task"].StartedAt.Equal(started) {
+		t.Errorf("Expected StartedAt to keep the first start time")
+	}
+
+	service.CompleteTask("Task")
+	if service.Tasks["Task"].CompletedAt.IsZero() {
+		t.Errorf("Expected CompletedAt to be set")
+	}
+}
@@ -0,0 +1,121 @@
+package services
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/savabush/taskTracker/pkg/tasktracker/model"
+)
+
+// Storage backends.
+const (
+	StorageJSON     = "json"
+	StorageTodoTxt  = "todotxt"
+	StorageMarkdown = "markdown"
+	StorageOpLog    = "oplog"
+)
+
+// StorageKinds lists the backends accepted by NewStorage.
+var StorageKinds = []string{StorageJSON, StorageTodoTxt, StorageMarkdown, StorageOpLog}
+
+// StoreData is the state a TaskService persists.
+type StoreData = model.StoreData
+
+// Storage loads and saves the state of a TaskService. Load returns nil maps
+// for state that has not been stored yet.
+type Storage = model.Storage
+
+var storage Storage = JSONStorage{}
+
+// SetStorage changes the backend used by TaskService.LoadTasks and
+// SaveTasks.
+func SetStorage(s Storage) {
+	storage = s
+}
+
+// GetStorage returns the backend used by TaskService.
+func GetStorage() Storage {
+	return storage
+}
+
+// StorageOptions are the locations used by the file-based backends. Empty
+// fields use their defaults.
+type StorageOptions struct {
+	// TodoFile is the todo.txt file of the todotxt backend; its done.txt
+	// lives in the same directory.
+	TodoFile string
+	// MarkdownFile is the file of the markdown backend.
+	MarkdownFile string
+	// LogDir is the operation log directory of the oplog backend, and
+	// Replica the name this machine writes its operations under, which
+	// defaults to the host name.
+	LogDir  string
+	Replica string
+}
+
+// NewStorage returns the backend of the given kind.
+func NewStorage(kind string, opts StorageOptions) (Storage, error) {
+	switch kind {
+	case "", StorageJSON:
+		return JSONStorage{}, nil
+	case StorageTodoTxt:
+		todoFile := cmp.Or(opts.TodoFile, "todo.txt")
+		return NewTodoTxtStorage(todoFile, filepath.Join(filepath.Dir(todoFile), "done.txt")), nil
+	case StorageMarkdown:
+		return NewMarkdownStorage(cmp.Or(opts.MarkdownFile, "TASKS.md")), nil
+	case StorageOpLog:
+		replica := opts.Replica
+		if replica == "" {
+			hostname, err := os.Hostname()
+			if err != nil {
+				return nil, err
+			}
+			replica = hostname
+		}
+		return NewOpLogStorage(cmp.Or(opts.LogDir, "tasks.oplog"), replica)
+	}
+	return nil, errors.New("storage must be one of: " + strings.Join(StorageKinds, ", "))
+}
+
+// storeMetaFile holds the state that text backends cannot express in their
+// task file. It lives next to the task file.
+const storeMetaFile = ".tasktracker.json"
+
+// storeMeta is the content of the metadata file.
+type storeMeta struct {
+	Views     map[string]View           `json:"views,omitempty"`
+	Reminders map[string]time.Time      `json:"reminders,omitempty"`
+	Details   map[string]todoTxtDetails `json:"details,omitempty"`
+}
+
+func loadStoreMeta(filename string) (storeMeta, error) {
+	var meta storeMeta
+	data, err := os.ReadFile(filename)
+	if os.IsNotExist(err) {
+		return meta, nil
+	}
+	if err != nil {
+		slog.Error("Failed to read metadata file", "error", err)
+		return meta, errors.New("failed to read tasks")
+	}
+	if err := json.Unmarshal(data, &meta); err != nil {
+		slog.Error("Failed to unmarshal metadata file", "error", err)
+		return meta, errors.New("failed to unmarshal tasks")
+	}
+	return meta, nil
+}
+
+func saveStoreMeta(filename string, meta storeMeta) error {
+	data, err := json.Marshal(meta)
+	if err != nil {
+		slog.Error("Failed to marshal metadata file", "error", err)
+		return errors.New("failed to marshal tasks")
+	}
+	return os.WriteFile(filename, data, 0644)
+}
@@ -0,0 +1,66 @@
+package services
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestNewStorage(t *testing.T) {
+	for _, kind := range []string{"", StorageJSON} {
+		storage, err := NewStorage(kind, StorageOptions{})
+		if _, ok := storage.(JSONStorage); err != nil || !ok {
+			t.Errorf("NewStorage(%q) = %T, %v, want JSONStorage", kind, storage, err)
+		}
+	}
+
+	storage, err := NewStorage(StorageTodoTxt, StorageOptions{TodoFile: filepath.Join("notes", "todo.txt")})
+	todo, ok := storage.(*TodoTxtStorage)
+	if err != nil || !ok {
+		t.Fatalf("NewStorage(todotxt) = %T, %v, want *TodoTxtStorage", storage, err)
+	}
+	if todo.DoneFile != filepath.Join("notes", "done.txt") || todo.MetaFile != filepath.Join("notes", storeMetaFile) {
+		t.Errorf("Expected done and metadata files next to todo.txt, got %q and %q", todo.DoneFile, todo.MetaFile)
+	}
+
+	storage, err = NewStorage(StorageMarkdown, StorageOptions{MarkdownFile: filepath.Join("repo", "TASKS.md")})
+	markdown, ok := storage.(*MarkdownStorage)
+	if err != nil || !ok {
+		t.Fatalf("NewStorage(markdown) = %T, %v, want *MarkdownStorage", storage, err)
+	}
+	if markdown.MetaFile != filepath.Join("repo", storeMetaFile) {
+		t.Errorf("Expected metadata file next to TASKS.md, got %q", markdown.MetaFile)
+	}
+
+	storage, err = NewStorage(StorageOpLog, StorageOptions{Replica: "laptop"})
+	oplog, ok := storage.(*OpLogStorage)
+	if err != nil || !ok || oplog.Dir != "tasks.oplog" || oplog.Replica != "laptop" {
+		t.Fatalf("NewStorage(oplog) = %+v, %v, want *OpLogStorage", storage, err)
+	}
+	if _, err := NewStorage(StorageOpLog, StorageOptions{Replica: "../other"}); err == nil {
+		t.Errorf("Expected error for invalid replica name")
+	}
+
+	if _, err := NewStorage("sqlite", StorageOptions{}); err == nil {
+		t.Errorf("Expected error for unknown storage")
+	}
+}
+
+func TestJSONStoragePath(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "other.json")
+	storage := JSONStorage{Path: path}
+
+	// A missing file is created empty
+	data, err := storage.Load()
+	if err != nil || len(data.Tasks) != 0 {
+		t.Fatalf("Load of missing file = %v, %v", data, err)
+	}
+
+	err = storage.Save(StoreData{Tasks: map[string]Task{"A": {Title: "A", Status: TaskStatusPending}}})
+	if err != nil {
+		t.Fatalf("Save returned unexpected error: %v", err)
+	}
+	data, err = storage.Load()
+	if err != nil || data.Tasks["A"].Title != "A" {
+		t.Errorf("Expected saved task to load, got %v (%v)", data.Tasks, err)
+	}
+}
@@ -0,0 +1,405 @@
+package services
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SyncRemote and SyncBranch are the remote and branch set up by
+// GitSync.Init.
+const (
+	SyncRemote = "origin"
+	SyncBranch = "main"
+)
+
+// syncConfigKey is the git config key set by GitSync.Init to opt the
+// repository in to committing the task store.
+const syncConfigKey = "tasktracker.sync"
+
+// GitSync keeps the directory holding the task store in a git repository and
+// exchanges it with a remote. TasksFile is the JSON task file within Dir,
+// which is merged per task on pull; it is empty for other backends. Files
+// lists the files of the store relative to Dir, the only ones committed.
+type GitSync struct {
+	Dir       string
+	TasksFile string
+	Files     []string
+}
+
+// NewGitSync returns a GitSync for the directory holding the files of s.
+func NewGitSync(s Storage) GitSync {
+	switch s := s.(type) {
+	case JSONStorage:
+		dir := filepath.Dir(s.path())
+		return GitSync{Dir: dir, TasksFile: filepath.Base(s.path()), Files: storeFiles(dir, s.path())}
+	case *TodoTxtStorage:
+		dir := filepath.Dir(s.TodoFile)
+		return GitSync{Dir: dir, Files: storeFiles(dir, s.TodoFile, s.DoneFile, s.MetaFile)}
+	case *MarkdownStorage:
+		dir := filepath.Dir(s.File)
+		return GitSync{Dir: dir, Files: storeFiles(dir, s.File, s.MetaFile)}
+	case *OpLogStorage:
+		// The log directory holds nothing but the store
+		return GitSync{Dir: s.Dir, Files: []string{"."}}
+	}
+	return GitSync{Dir: "."}
+}
+
+// storeFiles returns the paths of files relative to dir, leaving out unset
+// paths and files outside dir.
+func storeFiles(dir string, files ...string) []string {
+	var result []string
+	for _, file := range files {
+		if file == "" {
+			continue
+		}
+		rel, err := filepath.Rel(dir, file)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+		result = append(result, filepath.ToSlash(rel))
+	}
+	return result
+}
+
+func (g GitSync) git(args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = g.Dir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		message := strings.TrimSpace(stderr.String())
+		if message == "" {
+			message = err.Error()
+		}
+		return "", fmt.Errorf("git %s: %s", args[0], message)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// Enabled reports whether Dir has been set up with Init. Only the top level
+// of a repository counts, and only once Init has opted it in, so that a task
+// file inside another project's repository is not committed to it unasked.
+func (g GitSync) Enabled() bool {
+	if !g.isRepository() {
+		return false
+	}
+	value, err := g.git("config", "--local", "--bool", "--get", syncConfigKey)
+	return err == nil && value == "true"
+}
+
+// isRepository reports whether Dir is the top level of a git repository.
+func (g GitSync) isRepository() bool {
+	_, err := os.Stat(filepath.Join(g.Dir, ".git"))
+	return err == nil
+}
+
+// presentFiles returns the store files that exist or are tracked, so that
+// they can be given to git add without matching nothing.
+func (g GitSync) presentFiles() []string {
+	var present []string
+	for _, file := range g.Files {
+		if _, err := os.Stat(filepath.Join(g.Dir, filepath.FromSlash(file))); err == nil {
+			present = append(present, file)
+		} else if tracked, _ := g.git("ls-files", "--", file); tracked != "" {
+			present = append(present, file)
+		}
+	}
+	return present
+}
+
+// Init turns Dir into a git repository with an initial commit. If remote is
+// set it becomes the origin remote; a local path that does not exist yet is
+// created as a bare repository.
+func (g GitSync) Init(remote string) error {
+	if !g.isRepository() {
+		if _, err := g.git("init", "-q", "--initial-branch", SyncBranch); err != nil {
+			return err
+		}
+	}
+	if _, err := g.git("config", "--local", syncConfigKey, "true"); err != nil {
+		return err
+	}
+	// Commits need an identity; fall back to a local one
+	for key, value := range map[string]string{"user.name": "taskTracker", "user.email": "taskTracker@localhost"} {
+		if current, _ := g.git("config", key); current == "" {
+			if _, err := g.git("config", key, value); err != nil {
+				return err
+			}
+		}
+	}
+
+	if remote != "" {
+		if !strings.Contains(remote, "://") && !strings.Contains(remote, "@") {
+			// Local paths are relative to the working directory, not Dir
+			var err error
+			if remote, err = filepath.Abs(remote); err != nil {
+				return err
+			}
+			if _, err := os.Stat(remote); os.IsNotExist(err) {
+				if _, err := g.git("init", "-q", "--bare", "--initial-branch", SyncBranch, remote); err != nil {
+					return err
+				}
+			}
+		}
+		action := "add"
+		if _, err := g.git("remote", "get-url", SyncRemote); err == nil {
+			action = "set-url"
+		}
+		if _, err := g.git("remote", action, SyncRemote, remote); err != nil {
+			return err
+		}
+	}
+
+	if _, err := g.git("rev-parse", "--verify", "-q", "HEAD"); err == nil {
+		_, err := g.Commit("Initialize task sync")
+		return err
+	}
+	// Pull needs a commit to merge into, even in an empty directory
+	files := g.presentFiles()
+	if len(files) > 0 {
+		if _, err := g.git(append([]string{"add", "-A", "--"}, files...)...); err != nil {
+			return err
+		}
+	}
+	// --only keeps whatever else is staged out of the commit
+	_, err := g.git(append([]string{"commit", "-q", "--only", "--allow-empty", "-m", "Initialize task sync", "--"}, files...)...)
+	return err
+}
+
+// Commit records the changes to the store files and reports whether there
+// were any. Other files in Dir are neither staged nor committed.
+func (g GitSync) Commit(message string) (bool, error) {
+	files := g.presentFiles()
+	if len(files) == 0 {
+		return false, nil
+	}
+	if _, err := g.git(append([]string{"add", "-A", "--"}, files...)...); err != nil {
+		return false, err
+	}
+	status, err := g.git(append([]string{"diff", "--cached", "--name-only", "--"}, files...)...)
+	if err != nil || status == "" {
+		return false, err
+	}
+	if _, err := g.git(append([]string{"commit", "-q", "-m", message, "--"}, files...)...); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (g GitSync) branch() (string, error) {
+	return g.git("symbolic-ref", "--short", "HEAD")
+}
+
+// Pull fetches the current branch from the remote and merges it, reporting
+// whether anything changed. Local changes are committed first. Concurrent
+// changes to the JSON task file are merged with MergeStoreData; conflicts in
+// any other file abort the merge.
+func (g GitSync) Pull(remote string) (bool, error) {
+	branch, err := g.branch()
+	if err != nil {
+		return false, err
+	}
+	if _, err := g.Commit("Record local changes before pull"); err != nil {
+		return false, err
+	}
+	if _, err := g.git("fetch", "-q", remote, branch); err != nil {
+		return false, err
+	}
+	if _, err := g.git("merge-base", "--is-ancestor", "FETCH_HEAD", "HEAD"); err == nil {
+		return false, nil
+	}
+	if _, err := g.git("merge-base", "--is-ancestor", "HEAD", "FETCH_HEAD"); err == nil {
+		_, err := g.git("merge", "-q", "--ff-only", "FETCH_HEAD")
+		return err == nil, err
+	}
+
+	// Histories of separately initialized stores have no merge base
+	base, _ := g.git("merge-base", "HEAD", "FETCH_HEAD")
+	// Conflicts are expected here and resolved below
+	g.git("merge", "-q", "--no-ff", "--no-commit", "--allow-unrelated-histories", "FETCH_HEAD")
+	if g.TasksFile != "" {
+		if err := g.mergeTasksFile(base); err != nil {
+			g.git("merge", "--abort")
+			return false, err
+		}
+	}
+	if conflicts, _ := g.git("diff", "--name-only", "--diff-filter=U"); conflicts != "" {
+		g.git("merge", "--abort")
+		return false, errors.New("merge conflicts in " + strings.Join(strings.Fields(conflicts), ", "))
+	}
+	if _, err := g.git("commit", "-q", "-m", "Merge tasks from "+remote+"/"+branch); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// mergeTasksFile replaces the task file in the working tree with the
+// three-way merge of its versions at base, HEAD and FETCH_HEAD.
+func (g GitSync) mergeTasksFile(base string) error {
+	version := func(rev string) (StoreData, bool, error) {
+		if rev == "" {
+			return StoreData{}, false, nil
+		}
+		content, err := g.git("show", rev+":"+g.TasksFile)
+		if err != nil || content == "" {
+			// The file does not exist at rev
+			return StoreData{}, false, nil
+		}
+		data, err := decodeStoreData([]byte(content))
+		return data, true, err
+	}
+
+	baseData, _, err := version(base)
+	if err != nil {
+		return err
+	}
+	ours, inOurs, err := version("HEAD")
+	if err != nil {
+		return err
+	}
+	theirs, inTheirs, err := version("FETCH_HEAD")
+	if err != nil {
+		return err
+	}
+	if !inOurs && !inTheirs {
+		return nil
+	}
+
+	merged, err := encodeStoreData(MergeStoreData(baseData, ours, theirs))
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(g.Dir, g.TasksFile), merged, 0644); err != nil {
+		return err
+	}
+	_, err = g.git("add", "--", g.TasksFile)
+	return err
+}
+
+// Push sends the current branch to the remote.
+func (g GitSync) Push(remote string) error {
+	branch, err := g.branch()
+	if err != nil {
+		return err
+	}
+	if _, err := g.git("push", "-q", remote, "HEAD:"+branch); err != nil {
+		return fmt.Errorf("%w; pull first if the remote has changes", err)
+	}
+	return nil
+}
+
+// MergeStoreData merges two versions of the store that were both derived
+// from base. Tasks are matched by ID: a task changed on one side only takes
+// that side's version, a task changed on both sides takes the version with
+// the later UpdatedAt, and an edit wins over a deletion. Views are merged by
+// name with ours winning conflicts, and reminders keep the latest time sent.
+func MergeStoreData(base, ours, theirs StoreData) StoreData {
+	byID := func(tasks map[string]Task) map[string]Task {
+		result := make(map[string]Task, len(tasks))
+		for title, task := range tasks {
+			key := task.ID
+			if key == "" {
+				key = "title:" + title
+			}
+			result[key] = task
+		}
+		return result
+	}
+	tasks := mergeThreeWay(byID(base.Tasks), byID(ours.Tasks), byID(theirs.Tasks), func(ours, theirs Task) bool {
+		return theirs.UpdatedAt.After(ours.UpdatedAt)
+	})
+	views := mergeThreeWay(base.Views, ours.Views, theirs.Views, func(ours, theirs View) bool {
+		return false
+	})
+	reminders := mergeThreeWay(base.Reminders, ours.Reminders, theirs.Reminders, func(ours, theirs time.Time) bool {
+		return theirs.After(ours)
+	})
+
+	return StoreData{Tasks: retitleMerged(tasks), Views: views, Reminders: reminders}
+}
+
+// mergeThreeWay merges maps key by key. newer reports whether theirs should
+// replace ours when both sides changed a value.
+func mergeThreeWay[K comparable, V any](base, ours, theirs map[K]V, newer func(ours, theirs V) bool) map[K]V {
+	keys := make(map[K]bool)
+	for _, m := range []map[K]V{base, ours, theirs} {
+		for key := range m {
+			keys[key] = true
+		}
+	}
+
+	result := make(map[K]V)
+	for key := range keys {
+		b, inBase := base[key]
+		o, inOurs := ours[key]
+		t, inTheirs := theirs[key]
+		oursChanged := inOurs != inBase || (inOurs && !reflect.DeepEqual(o, b))
+		theirsChanged := inTheirs != inBase || (inTheirs && !reflect.DeepEqual(t, b))
+
+		switch {
+		case !theirsChanged:
+			if inOurs {
+				result[key] = o
+			}
+		case !oursChanged:
+			if inTheirs {
+				result[key] = t
+			}
+		case !inTheirs:
+			// Deleted there and edited here, or deleted on both sides
+			if inOurs {
+				result[key] = o
+			}
+		case !inOurs:
+			result[key] = t
+		case newer(o, t):
+			result[key] = t
+		default:
+			result[key] = o
+		}
+	}
+	return result
+}
+
+// retitleMerged keys merged tasks by title again. Different tasks that ended
+// up with the same title, such as tasks added on both sides, are all kept
+// with a numbered title.
+func retitleMerged(tasks map[string]Task) map[string]Task {
+	keys := make([]string, 0, len(tasks))
+	for key := range tasks {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := tasks[keys[i]], tasks[keys[j]]
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.Before(b.CreatedAt)
+		}
+		return keys[i] < keys[j]
+	})
+
+	result := make(map[string]Task, len(tasks))
+	for _, key := range keys {
+		task := tasks[key]
+		title := task.Title
+		for n := 2; ; n++ {
+			if _, exists := result[title]; !exists {
+				break
+			}
+			title = task.Title + " (" + strconv.Itoa(n) + ")"
+		}
+		task.Title = title
+		result[title] = task
+	}
+	return result
+}
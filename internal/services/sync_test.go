@@ -0,0 +1,242 @@
+package services
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMergeStoreData(t *testing.T) {
+	at := func(hour int) time.Time {
+		return time.Date(2026, 10, 1, hour, 0, 0, 0, time.UTC)
+	}
+	task := func(id, title string, priority TaskPriority, updated int) Task {
+		return Task{ID: id, Title: title, Priority: priority, Status: TaskStatusPending, CreatedAt: at(0), UpdatedAt: at(updated)}
+	}
+	tasks := func(list ...Task) map[string]Task {
+		result := make(map[string]Task)
+		for _, task := range list {
+			result[task.Title] = task
+		}
+		return result
+	}
+
+	base := StoreData{
+		Tasks: tasks(
+			task("1", "Both edit", "", 1),
+			task("2", "Ours edit", "", 1),
+			task("3", "Theirs delete", "", 1),
+			task("4", "Deleted and edited", "", 1),
+			task("5", "Renamed", "", 1),
+		),
+		Views:     map[string]View{"work": {Name: "work", Query: "tag:work"}},
+		Reminders: map[string]time.Time{"1@2026-10-20": at(1)},
+	}
+	ours := StoreData{
+		Tasks: tasks(
+			task("1", "Both edit", TaskPriorityLow, 3),
+			task("2", "Ours edit", TaskPriorityHigh, 2),
+			task("3", "Theirs delete", "", 1),
+			task("5", "Renamed", TaskPriorityMedium, 2),
+			task("6", "Added", "", 2),
+		),
+		Views:     map[string]View{"work": {Name: "work", Query: "tag:work"}, "home": {Name: "home"}},
+		Reminders: map[string]time.Time{"1@2026-10-20": at(2)},
+	}
+	theirs := StoreData{
+		Tasks: tasks(
+			task("1", "Both edit", TaskPriorityCritical, 2),
+			task("2", "Ours edit", "", 1),
+			task("4", "Deleted and edited", TaskPriorityHigh, 2),
+			task("5", "Renamed on the other side", "", 3),
+			task("7", "Added", "", 2),
+		),
+		Views:     map[string]View{},
+		Reminders: map[string]time.Time{"1@2026-10-20": at(3), "7@2026-10-21": at(3)},
+	}
+
+	merged := MergeStoreData(base, ours, theirs)
+
+	want := map[string]TaskPriority{
+		"Both edit":                 TaskPriorityLow,
+		"Ours edit":                 TaskPriorityHigh,
+		"Deleted and edited":        TaskPriorityHigh,
+		"Renamed on the other side": "",
+		"Added":                     "",
+		"Added (2)":                 "",
+	}
+	if len(merged.Tasks) != len(want) {
+		t.Errorf("Expected %d tasks, got %v", len(want), merged.Tasks)
+	}
+	for title, priority := range want {
+		task, ok := merged.Tasks[title]
+		if !ok {
+			t.Errorf("Expected task %q in %v", title, merged.Tasks)
+			continue
+		}
+		if task.Title != title || task.Priority != priority {
+			t.Errorf("Task %q = %+v, want priority %q", title, task, priority)
+		}
+	}
+	if merged.Tasks["Added"].ID != "6" || merged.Tasks["Added (2)"].ID != "7" {
+		t.Errorf("Expected tasks added on both sides to be kept, got %v", merged.Tasks)
+	}
+
+	if _, ok := merged.Views["home"]; !ok || len(merged.Views) != 1 {
+		t.Errorf("Expected view deleted on one side to go and view added to stay, got %v", merged.Views)
+	}
+	if !merged.Reminders["1@2026-10-20"].Equal(at(3)) || len(merged.Reminders) != 2 {
+		t.Errorf("Expected latest reminder times, got %v", merged.Reminders)
+	}
+}
+
+// newSyncedStore initializes a synced JSON store in a new directory.
+func newSyncedStore(t *testing.T, remote string) (GitSync, JSONStorage) {
+	t.Helper()
+	storage := JSONStorage{Path: filepath.Join(t.TempDir(), "tasks.json")}
+	gitSync := NewGitSync(storage)
+	if err := gitSync.Init(remote); err != nil {
+		t.Fatalf("Init returned unexpected error: %v", err)
+	}
+	return gitSync, storage
+}
+
+func TestGitSync(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	remote := filepath.Join(t.TempDir(), "remote.git")
+	laptop, laptopStore := newSyncedStore(t, remote)
+	if !laptop.Enabled() || laptop.TasksFile != "tasks.json" {
+		t.Fatalf("Unexpected sync %+v", laptop)
+	}
+	if _, err := os.Stat(filepath.Join(remote, "HEAD")); err != nil {
+		t.Fatalf("Expected bare remote to be created: %v", err)
+	}
+
+	created := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	laptopStore.Save(StoreData{Tasks: map[string]Task{
+		"Shared": {ID: "1", Title: "Shared", Status: TaskStatusPending, CreatedAt: created, UpdatedAt: created},
+	}})
+	if committed, err := laptop.Commit("add Shared"); err != nil || !committed {
+		t.Fatalf("Commit = %v, %v", committed, err)
+	}
+	if committed, _ := laptop.Commit("nothing"); committed {
+		t.Errorf("Expected no commit without changes")
+	}
+	if err := laptop.Push(SyncRemote); err != nil {
+		t.Fatalf("Push returned unexpected error: %v", err)
+	}
+
+	// A second, separately initialized store picks up the tasks
+	desktop, desktopStore := newSyncedStore(t, remote)
+	if changed, err := desktop.Pull(SyncRemote); err != nil || !changed {
+		t.Fatalf("Pull = %v, %v", changed, err)
+	}
+	data, _ := desktopStore.Load()
+	if _, ok := data.Tasks["Shared"]; !ok {
+		t.Fatalf("Expected pulled task, got %v", data.Tasks)
+	}
+	desktop.Push(SyncRemote)
+
+	// Concurrent edits of the same task are resolved by UpdatedAt
+	laptop.Pull(SyncRemote)
+	data, _ = laptopStore.Load()
+	shared := data.Tasks["Shared"]
+	shared.Priority, shared.UpdatedAt = TaskPriorityLow, created.Add(time.Hour)
+	data.Tasks["Shared"] = shared
+	laptopStore.Save(data)
+	laptop.Commit("edit Shared")
+	laptop.Push(SyncRemote)
+
+	data, _ = desktopStore.Load()
+	shared = data.Tasks["Shared"]
+	shared.Priority, shared.UpdatedAt = TaskPriorityHigh, created.Add(2*time.Hour)
+	data.Tasks["Shared"] = shared
+	data.Tasks["Desktop"] = Task{ID: "2", Title: "Desktop", Status: TaskStatusPending, CreatedAt: created, UpdatedAt: created}
+	desktopStore.Save(data)
+	if err := desktop.Push(SyncRemote); err == nil {
+		t.Fatalf("Expected push of diverged branch to fail")
+	}
+	if changed, err := desktop.Pull(SyncRemote); err != nil || !changed {
+		t.Fatalf("Pull = %v, %v", changed, err)
+	}
+	data, _ = desktopStore.Load()
+	if data.Tasks["Shared"].Priority != TaskPriorityHigh || data.Tasks["Desktop"].ID != "2" {
+		t.Errorf("Unexpected merge result %v", data.Tasks)
+	}
+	if status, _ := desktop.git("status", "--porcelain"); status != "" {
+		t.Errorf("Expected merge to be committed, got status %q", status)
+	}
+	if err := desktop.Push(SyncRemote); err != nil {
+		t.Fatalf("Push after merge returned unexpected error: %v", err)
+	}
+	if changed, err := laptop.Pull(SyncRemote); err != nil || !changed {
+		t.Fatalf("Pull = %v, %v", changed, err)
+	}
+	data, _ = laptopStore.Load()
+	if len(data.Tasks) != 2 || data.Tasks["Shared"].Priority != TaskPriorityHigh {
+		t.Errorf("Expected stores to converge, got %v", data.Tasks)
+	}
+	log, _ := laptop.git("log", "--format=%s")
+	if !strings.Contains(log, "Merge tasks from origin/main") {
+		t.Errorf("Expected merge commit in log:\n%s", log)
+	}
+}
+
+func TestGitSync_CommitsOnlyStoreFiles(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	// A project repository that happens to hold the task file
+	dir := t.TempDir()
+	project := GitSync{Dir: dir}
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"config", "user.name", "dev"},
+		{"config", "user.email", "dev@localhost"},
+	} {
+		if _, err := project.git(args...); err != nil {
+			t.Fatalf("git %v: %v", args, err)
+		}
+	}
+	os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n"), 0644)
+	project.git("add", "main.go")
+	project.git("commit", "-q", "-m", "project")
+	os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main // edited\n"), 0644)
+	os.WriteFile(filepath.Join(dir, ".env"), []byte("SECRET=1\n"), 0644)
+	os.WriteFile(filepath.Join(dir, "staged.txt"), []byte("staged\n"), 0644)
+	project.git("add", "staged.txt")
+
+	storage := JSONStorage{Path: filepath.Join(dir, "tasks.json")}
+	storage.Save(StoreData{Tasks: map[string]Task{"Task": {ID: "1", Title: "Task", Status: TaskStatusPending}}})
+	gitSync := NewGitSync(storage)
+	if gitSync.Enabled() {
+		t.Fatalf("Expected a repository without sync init not to be enabled")
+	}
+
+	if err := gitSync.Init(""); err != nil {
+		t.Fatalf("Init returned unexpected error: %v", err)
+	}
+	if !gitSync.Enabled() {
+		t.Fatalf("Expected sync to be enabled after init")
+	}
+	storage.Save(StoreData{Tasks: map[string]Task{"Other": {ID: "2", Title: "Other", Status: TaskStatusPending}}})
+	if committed, err := gitSync.Commit("add Other"); err != nil || !committed {
+		t.Fatalf("Commit = %v, %v", committed, err)
+	}
+
+	files, _ := gitSync.git("log", "--name-only", "--format=", "-2")
+	if strings.Join(strings.Fields(files), ",") != "tasks.json,tasks.json" {
+		t.Errorf("Expected only tasks.json to be committed, got %q", files)
+	}
+	status, _ := gitSync.git("status", "--porcelain")
+	for _, want := range []string{"M main.go", "A  staged.txt", "?? .env"} {
+		if !strings.Contains(status, want) {
+			t.Errorf("Expected %q to be left alone, got status:\n%s", want, status)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package services
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/savabush/taskTracker/pkg/tasktracker/model"
+)
+
+// TimeEntry is a span of time logged against a task. A zero End means the
+// timer is still running.
+type TimeEntry = model.TimeEntry
+
+// RunningTimer returns the task whose timer is currently running.
+func (s *TaskService) RunningTimer() (Task, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.runningTimer()
+}
+
+// runningTimer must be called with s.mu held.
+func (s *TaskService) runningTimer() (Task, bool) {
+	for _, task := range s.Tasks {
+		if task.RunningEntry() >= 0 {
+			return task, true
+		}
+	}
+	return Task{}, false
+}
+
+// StartTimer starts a time entry on the task. Only one timer may run at a
+// time across all tasks.
+func (s *TaskService) StartTimer(title string, now time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if running, ok := s.runningTimer(); ok {
+		return errors.New("timer already running for task: " + running.Title)
+	}
+
+	task, ok := s.Tasks[title]
+	if !ok {
+		return notFound(title)
+	}
+	task.TimeEntries = append(task.TimeEntries, TimeEntry{Start: now})
+	task.UpdatedAt = now
+	s.Tasks[title] = task
+	return nil
+}
+
+// StopTimer stops the running timer and returns the task it belonged to and
+// the length of the stopped entry.
+func (s *TaskService) StopTimer(now time.Time) (Task, time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	task, ok := s.runningTimer()
+	if !ok {
+		return Task{}, 0, errors.New("no timer is running")
+	}
+
+	i := task.RunningEntry()
+	task.TimeEntries[i].End = now
+	task.UpdatedAt = now
+	s.Tasks[task.Title] = task
+	return task, task.TimeEntries[i].Duration(now), nil
+}
+
+// TimeTotal is the time logged for one task, tag or day.
+type TimeTotal struct {
+	Key      string        `json:"key"`
+	Duration time.Duration `json:"-"`
+	Hours    float64       `json:"hours"`
+}
+
+// TimeLog summarizes logged time per task, tag and day.
+type TimeLog struct {
+	Total      time.Duration `json:"-"`
+	TotalHours float64       `json:"total_hours"`
+	ByTask     []TimeTotal   `json:"by_task"`
+	ByTag      []TimeTotal   `json:"by_tag"`
+	ByDay      []TimeTotal   `json:"by_day"`
+}
+
+// SummarizeTimeLog sums the time entries of tasks that started within
+// [since, until). A zero since or until leaves that side open. Entries are
+// attributed to the day they started on, and running entries count up to
+// now.
+func SummarizeTimeLog(tasks []Task, since, until, now time.Time) TimeLog {
+	byTask := make(map[string]time.Duration)
+	byTag := make(map[string]time.Duration)
+	byDay := make(map[string]time.Duration)
+	var log TimeLog
+
+	for _, task := range tasks {
+		for _, entry := range task.TimeEntries {
+			if (!since.IsZero() && entry.Start.Before(since)) || (!until.IsZero() && !entry.Start.Before(until)) {
+				continue
+			}
+			d := entry.Duration(now)
+			log.Total += d
+			byTask[task.Title] += d
+			byDay[entry.Start.Format(DateLayout)] += d
+			for _, tag := range task.Tags {
+				byTag[tag] += d
+			}
+		}
+	}
+
+	log.TotalHours = log.Total.Hours()
+	log.ByTask = timeTotals(byTask)
+	log.ByTag = timeTotals(byTag)
+	log.ByDay = timeTotals(byDay)
+	// Days read best in calendar order
+	sort.Slice(log.ByDay, func(i, j int) bool {
+		return log.ByDay[i].Key < log.ByDay[j].Key
+	})
+	return log
+}
+
+// timeTotals converts totals to a slice ordered by descending duration.
+func timeTotals(totals map[string]time.Duration) []TimeTotal {
+	result := make([]TimeTotal, 0, len(totals))
+	for key, d := range totals {
+		result = append(result, TimeTotal{Key: key, Duration: d, Hours: d.Hours()})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Duration != result[j].Duration {
+			return result[i].Duration > result[j].Duration
+		}
+		return result[i].Key < result[j].Key
+	})
+	return result
+}
@@ -0,0 +1,87 @@
+package services
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStartAndStopTimer(t *testing.T) {
+	_, cleanup := createTempTaskFile(t)
+	defer cleanup()
+
+	service := loadService(t)
+	service.AddTask("Task1")
+	service.AddTask("Task2")
+
+	start := time.Date(2026, 10, 19, 9, 0, 0, 0, time.Local)
+	if err := service.StartTimer("Task1", start); err != nil {
+		t.Fatalf("StartTimer returned unexpected error: %v", err)
+	}
+
+	running, ok := service.RunningTimer()
+	if !ok || running.Title != "Task1" {
+		t.Errorf("Expected running timer on Task1, got %+v", running)
+	}
+
+	// Only one timer can run at a time
+	if err := service.StartTimer("Task2", start); err == nil {
+		t.Errorf("Expected error starting a second timer")
+	}
+
+	task, elapsed, err := service.StopTimer(start.Add(90 * time.Minute))
+	if err != nil {
+		t.Fatalf("StopTimer returned unexpected error: %v", err)
+	}
+	if task.Title != "Task1" || elapsed != 90*time.Minute {
+		t.Errorf("Expected 90m on Task1, got %v on %s", elapsed, task.Title)
+	}
+	if got := service.Tasks["Task1"].LoggedTime(time.Now()); got != 90*time.Minute {
+		t.Errorf("Expected 90m logged, got %v", got)
+	}
+
+	if _, _, err := service.StopTimer(time.Now()); err == nil {
+		t.Errorf("Expected error stopping without a running timer")
+	}
+	if err := service.StartTimer("Missing", start); err == nil {
+		t.Errorf("Expected error starting timer on non-existent task")
+	}
+}
+
+func TestSummarizeTimeLog(t *testing.T) {
+	at := func(day, hour int) time.Time {
+		return time.Date(2026, 10, day, hour, 0, 0, 0, time.Local)
+	}
+
+	tasks := []Task{
+		{Title: "api", Tags: []string{"backend"}, TimeEntries: []TimeEntry{
+			{Start: at(18, 9), End: at(18, 11)},
+			{Start: at(19, 9), End: at(19, 10)},
+		}},
+		{Title: "ui", Tags: []string{"frontend"}, TimeEntries: []TimeEntry{
+			{Start: at(19, 13), End: at(19, 16)},
+			// Still running
+			{Start: at(19, 17)},
+		}},
+		{Title: "old", TimeEntries: []TimeEntry{{Start: at(1, 9), End: at(1, 17)}}},
+	}
+
+	timeLog := SummarizeTimeLog(tasks, at(18, 0), time.Time{}, at(19, 18))
+
+	if timeLog.Total != 7*time.Hour {
+		t.Errorf("Expected 7h total, got %v", timeLog.Total)
+	}
+
+	want := []TimeTotal{
+		{Key: "ui", Duration: 4 * time.Hour, Hours: 4},
+		{Key: "api", Duration: 3 * time.Hour, Hours: 3},
+	}
+	if len(timeLog.ByTask) != len(want) || timeLog.ByTask[0] != want[0] || timeLog.ByTask[1] != want[1] {
+		t.Errorf("ByTask = %+v, want %+v", timeLog.ByTask, want)
+	}
+	if len(timeLog.ByTag) != 2 || timeLog.ByTag[0].Key != "frontend" {
+		t.Errorf("Unexpected ByTag: %+v", timeLog.ByTag)
+	}
+	if len(timeLog.ByDay) != 2 || timeLog.ByDay[0].Key != "2026-10-18" || timeLog.ByDay[1].Duration != 5*time.Hour {
+		t.Errorf("Unexpected ByDay: %+v", timeLog.ByDay)
+	}
+}
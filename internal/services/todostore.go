@@ -0,0 +1,287 @@
+package services
+
+import (
+	"bufio"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TodoTxtStorage uses a todo.txt/done.txt pair as the source of truth for
+// tasks. Priorities, projects, contexts, creation and completion dates and
+// the due:, est: and rec: extensions map to task fields; lines that did not
+// change since they were loaded are written back byte for byte, so unknown
+// content survives. Descriptions, notes, time entries, the in-progress
+// state, views and reminder state are kept in a .tasktracker.json file in
+// the same directory, keyed by task title, which also restores titles whose
+// repeated spaces the lines collapse.
+type TodoTxtStorage struct {
+	TodoFile string
+	DoneFile string
+	MetaFile string
+
+	mu      sync.Mutex
+	entries map[string][]todoTxtEntry
+}
+
+// todoTxtEntry is a line as it was loaded. Lines that are not tasks have an
+// empty title and are kept as they are. letter is the priority letter of the
+// line, so that letters after D survive changes to the task.
+type todoTxtEntry struct {
+	raw    string
+	title  string
+	task   Task
+	letter byte
+}
+
+// todoTxtDetails are the fields of a task that have no todo.txt syntax.
+type todoTxtDetails struct {
+	ID          string      `json:"id"`
+	Description string      `json:"description,omitempty"`
+	Notes       []string    `json:"notes,omitempty"`
+	TimeEntries []TimeEntry `json:"time_entries,omitempty"`
+	InProgress  bool        `json:"in_progress,omitempty"`
+	StartedAt   time.Time   `json:"started_at,omitzero"`
+	UpdatedAt   time.Time   `json:"updated_at,omitzero"`
+}
+
+func NewTodoTxtStorage(todoFile, doneFile string) *TodoTxtStorage {
+	return &TodoTxtStorage{
+		TodoFile: todoFile,
+		DoneFile: doneFile,
+		MetaFile: filepath.Join(filepath.Dir(todoFile), storeMetaFile),
+	}
+}
+
+func (t *TodoTxtStorage) Load() (StoreData, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	meta, err := loadStoreMeta(t.MetaFile)
+	if err != nil {
+		return StoreData{}, err
+	}
+	data := StoreData{
+		Tasks:     make(map[string]Task),
+		Views:     meta.Views,
+		Reminders: meta.Reminders,
+	}
+
+	t.entries = make(map[string][]todoTxtEntry)
+	for _, file := range []string{t.TodoFile, t.DoneFile} {
+		lines, modTime, err := readLines(file)
+		if err != nil {
+			return StoreData{}, err
+		}
+		slog.Debug("Loading todo.txt file", "filename", file, "lines", len(lines))
+
+		t.entries[file] = parseTodoTxtLines(lines, meta.Details, data.Tasks, modTime)
+	}
+	return data, nil
+}
+
+// withTodoTxtDetails fills in the fields that are kept in the metadata file.
+// Tasks without any recorded time are considered updated at modTime.
+func withTodoTxtDetails(task Task, details todoTxtDetails, modTime time.Time) Task {
+	task.ID = details.ID
+	if task.ID == "" {
+		// Derive a stable ID for tasks created by other todo.txt tools
+		task.ID = uuid.NewSHA1(uuid.NameSpaceOID, []byte(task.Title)).String()
+	}
+	task.Description = details.Description
+	task.Notes = details.Notes
+	task.TimeEntries = details.TimeEntries
+	task.StartedAt = details.StartedAt
+	if details.InProgress && task.Status != TaskStatusCompleted {
+		task.Status = TaskStatusInProgress
+	}
+	task.UpdatedAt = details.UpdatedAt
+	if task.UpdatedAt.IsZero() {
+		task.UpdatedAt = task.CreatedAt
+		if task.CompletedAt.After(task.UpdatedAt) {
+			task.UpdatedAt = task.CompletedAt
+		}
+		if task.UpdatedAt.IsZero() {
+			task.UpdatedAt = modTime
+		}
+	}
+	return task
+}
+
+func (t *TodoTxtStorage) Save(data StoreData) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	letters := make(map[string]byte)
+	for _, entries := range t.entries {
+		for _, entry := range entries {
+			if entry.title != "" {
+				letters[entry.title] = entry.letter
+			}
+		}
+	}
+	written := make(map[string]bool, len(data.Tasks))
+	output := make(map[string][]string)
+	for _, file := range []string{t.TodoFile, t.DoneFile} {
+		var lines []string
+		for _, entry := range t.entries[file] {
+			if entry.title == "" {
+				lines = append(lines, entry.raw)
+				continue
+			}
+			task, ok := data.Tasks[entry.title]
+			if !ok {
+				continue
+			}
+			// Tasks reopened after being archived move back to todo.txt
+			if file == t.DoneFile && task.Status != TaskStatusCompleted {
+				continue
+			}
+			written[task.Title] = true
+			if line := formatTodoTxtLine(task, entry.letter); line == formatTodoTxtLine(entry.task, entry.letter) {
+				lines = append(lines, entry.raw)
+			} else {
+				lines = append(lines, line)
+			}
+		}
+		output[file] = lines
+	}
+
+	var added []Task
+	for title, task := range data.Tasks {
+		if !written[title] {
+			added = append(added, task)
+		}
+	}
+	sort.Slice(added, func(i, j int) bool {
+		if !added[i].CreatedAt.Equal(added[j].CreatedAt) {
+			return added[i].CreatedAt.Before(added[j].CreatedAt)
+		}
+		return added[i].Title < added[j].Title
+	})
+	for _, task := range added {
+		output[t.TodoFile] = append(output[t.TodoFile], formatTodoTxtLine(task, letters[task.Title]))
+	}
+
+	for _, file := range []string{t.TodoFile, t.DoneFile} {
+		// Only create done.txt once there is something to archive
+		if _, err := os.Stat(file); file == t.DoneFile && len(output[file]) == 0 && os.IsNotExist(err) {
+			continue
+		}
+		slog.Debug("Writing todo.txt file", "filename", file, "lines", len(output[file]))
+		if err := writeLines(file, output[file]); err != nil {
+			return err
+		}
+	}
+
+	meta := storeMeta{
+		Views:     data.Views,
+		Reminders: data.Reminders,
+		Details:   make(map[string]todoTxtDetails, len(data.Tasks)),
+	}
+	for title, task := range data.Tasks {
+		meta.Details[title] = todoTxtDetails{
+			ID:          task.ID,
+			Description: task.Description,
+			Notes:       task.Notes,
+			TimeEntries: task.TimeEntries,
+			InProgress:  task.Status == TaskStatusInProgress,
+			StartedAt:   task.StartedAt,
+			UpdatedAt:   task.UpdatedAt,
+		}
+	}
+	if err := saveStoreMeta(t.MetaFile, meta); err != nil {
+		return err
+	}
+
+	// Later saves compare against what was just written
+	tasks := make(map[string]Task)
+	now := time.Now()
+	for _, file := range []string{t.TodoFile, t.DoneFile} {
+		t.entries[file] = parseTodoTxtLines(output[file], meta.Details, tasks, now)
+	}
+	return nil
+}
+
+// parseTodoTxtLines turns lines into entries and adds their tasks to tasks.
+// Lines that are not valid tasks or repeat an earlier title are kept as
+// plain lines.
+func parseTodoTxtLines(lines []string, details map[string]todoTxtDetails, tasks map[string]Task, modTime time.Time) []todoTxtEntry {
+	// Lines collapse runs of whitespace in titles; the metadata file has
+	// them as they were
+	spaced := make(map[string][]string)
+	for title := range details {
+		if collapsed := strings.Join(strings.Fields(title), " "); collapsed != title {
+			spaced[collapsed] = append(spaced[collapsed], title)
+		}
+	}
+	for _, titles := range spaced {
+		sort.Strings(titles)
+	}
+
+	entries := make([]todoTxtEntry, 0, len(lines))
+	for _, line := range lines {
+		entry := todoTxtEntry{raw: line}
+		task, letter, err := parseTodoTxtLine(line)
+		_, recorded := details[task.Title]
+		if _, exists := tasks[task.Title]; err == nil && (!recorded || exists) {
+			for _, title := range spaced[task.Title] {
+				if _, exists := tasks[title]; !exists {
+					task.Title = title
+					break
+				}
+			}
+		}
+		if _, exists := tasks[task.Title]; err == nil && !exists {
+			task = withTodoTxtDetails(task, details[task.Title], modTime)
+			entry.title, entry.task, entry.letter = task.Title, task, letter
+			tasks[task.Title] = task
+		} else if strings.TrimSpace(line) != "" {
+			slog.Debug("Keeping todo.txt line as is", "line", line, "error", err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// readLines returns the lines of a file and its modification time, or no
+// lines if it does not exist.
+func readLines(filename string) ([]string, time.Time, error) {
+	file, err := os.Open(filename)
+	if os.IsNotExist(err) {
+		return nil, time.Time{}, nil
+	}
+	if err != nil {
+		slog.Error("Failed to read tasks file", "error", err)
+		return nil, time.Time{}, errors.New("failed to read tasks")
+	}
+	defer file.Close()
+	var modTime time.Time
+	if info, err := file.Stat(); err == nil {
+		modTime = info.ModTime()
+	}
+
+	var lines []string
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		lines = append(lines, strings.TrimSuffix(scanner.Text(), "\r"))
+	}
+	return lines, modTime, scanner.Err()
+}
+
+func writeLines(filename string, lines []string) error {
+	var b strings.Builder
+	for _, line := range lines {
+		b.WriteString(line)
+		b.WriteByte('\n')
+	}
+	return os.WriteFile(filename, []byte(b.String()), 0644)
+}
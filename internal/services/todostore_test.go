@@ -0,0 +1,211 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func useTodoTxtStorage(t *testing.T, todo, done string) *TodoTxtStorage {
+	t.Helper()
+	dir := t.TempDir()
+	storage := NewTodoTxtStorage(filepath.Join(dir, "todo.txt"), filepath.Join(dir, "done.txt"))
+	if todo != "" {
+		os.WriteFile(storage.TodoFile, []byte(todo), 0644)
+	}
+	if done != "" {
+		os.WriteFile(storage.DoneFile, []byte(done), 0644)
+	}
+
+	original := GetStorage()
+	SetStorage(storage)
+	t.Cleanup(func() { SetStorage(original) })
+	return storage
+}
+
+func readFile(t *testing.T, name string) string {
+	t.Helper()
+	data, err := os.ReadFile(name)
+	if err != nil {
+		t.Fatalf("Failed to read %s: %v", name, err)
+	}
+	return string(data)
+}
+
+func TestTodoTxtStorageLoad(t *testing.T) {
+	useTodoTxtStorage(t,
+		"(A) 2026-10-01 Call +family mom @phone due:2026-10-20 see:TICKET-1\n"+
+			"\n"+
+			"x 2026-10-10 2026-10-02 Buy milk pri:C\n"+
+			"Buy milk\n",
+		"x 2026-09-01 Old thing +archive\n")
+
+	service := loadService(t)
+	if len(service.Tasks) != 3 {
+		t.Fatalf("Expected 3 tasks, got %d: %v", len(service.Tasks), service.Tasks)
+	}
+
+	call, err := service.GetTask("Call mom see:TICKET-1")
+	if err != nil {
+		t.Fatalf("Expected task with unknown extension in title: %v", err)
+	}
+	if call.Priority != TaskPriorityCritical || call.Status != TaskStatusPending || strings.Join(call.Tags, ",") != "family,@phone" {
+		t.Errorf("Unexpected task %+v", call)
+	}
+	if !call.CreatedAt.Equal(time.Date(2026, 10, 1, 0, 0, 0, 0, time.Local)) || call.ID == "" {
+		t.Errorf("Expected creation date and ID to be set, got %+v", call)
+	}
+
+	milk, _ := service.GetTask("Buy milk")
+	if milk.Status != TaskStatusCompleted || milk.Priority != TaskPriorityMedium || milk.CompletedAt.IsZero() {
+		t.Errorf("Unexpected completed task %+v", milk)
+	}
+	if old, err := service.GetTask("Old thing"); err != nil || old.Status != TaskStatusCompleted {
+		t.Errorf("Expected task from done.txt, got %+v (%v)", old, err)
+	}
+
+	// IDs of tasks created by other tools are stable across loads
+	if again, _ := loadService(t).GetTask("Call mom see:TICKET-1"); again.ID != call.ID {
+		t.Errorf("Expected stable ID, got %s and %s", call.ID, again.ID)
+	}
+}
+
+func TestTodoTxtStorageRoundTrip(t *testing.T) {
+	todo := "(A) 2026-10-01 Call +family mom @phone due:2026-10-20 see:TICKET-1\n" +
+		"\n" +
+		"Water plants rec:+1w\n" +
+		"x 2026-10-10 Buy milk\n" +
+		"Buy milk\n" +
+		"(B) +home\n"
+	done := "x 2026-09-01 Old thing +archive\n"
+	storage := useTodoTxtStorage(t, todo, done)
+
+	// Saving without changes leaves both files byte for byte identical
+	service := loadService(t)
+	if err := service.SaveTasks(); err != nil {
+		t.Fatalf("SaveTasks returned unexpected error: %v", err)
+	}
+	if got := readFile(t, storage.TodoFile); got != todo {
+		t.Errorf("todo.txt changed on save:\n%s\nwant:\n%s", got, todo)
+	}
+	if got := readFile(t, storage.DoneFile); got != done {
+		t.Errorf("done.txt changed on save:\n%s", got)
+	}
+
+	// Changed tasks are rewritten in place, deleted ones removed and new
+	// ones appended
+	service = loadService(t)
+	service.UpdateTask("Water plants rec:+1w", func(task *Task) { task.Priority = TaskPriorityHigh })
+	service.DeleteTask("Buy milk")
+	service.AddTask("New task")
+	service.UpdateTask("New task", func(task *Task) {
+		task.Tags = []string{"work"}
+		task.Description = "Kept in the metadata file"
+	})
+	service.InProgressTask("New task")
+	service.SaveTasks()
+
+	lines := strings.Split(readFile(t, storage.TodoFile), "\n")
+	want := []string{
+		"(A) 2026-10-01 Call +family mom @phone due:2026-10-20 see:TICKET-1",
+		"",
+		"(B) Water plants rec:+1w",
+		"Buy milk",
+		"(B) +home",
+		time.Now().Format(DateLayout) + " New task +work",
+		"",
+	}
+	if strings.Join(lines, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Unexpected todo.txt:\n%s\nwant:\n%s", strings.Join(lines, "\n"), strings.Join(want, "\n"))
+	}
+
+	reloaded, err := loadService(t).GetTask("New task")
+	if err != nil {
+		t.Fatalf("Expected new task after reload: %v", err)
+	}
+	if reloaded.Description != "Kept in the metadata file" || reloaded.Status != TaskStatusInProgress || reloaded.StartedAt.IsZero() {
+		t.Errorf("Expected metadata to survive reload, got %+v", reloaded)
+	}
+	added, _ := service.GetTask("New task")
+	if reloaded.ID != added.ID {
+		t.Errorf("Expected ID to survive reload, got %s, want %s", reloaded.ID, added.ID)
+	}
+}
+
+func TestTodoTxtStorageDone(t *testing.T) {
+	storage := useTodoTxtStorage(t, "Write report\n", "x 2026-09-01 Old thing\n")
+
+	service := loadService(t)
+	service.CompleteTask("Write report")
+	service.InProgressTask("Old thing")
+	service.SaveTasks()
+
+	// Completed tasks stay in todo.txt until archived; reopened tasks move
+	// back from done.txt
+	todo := readFile(t, storage.TodoFile)
+	if !strings.HasPrefix(todo, "x "+time.Now().Format(DateLayout)+" Write report\n") || !strings.Contains(todo, "Old thing") {
+		t.Errorf("Unexpected todo.txt:\n%s", todo)
+	}
+	if done := readFile(t, storage.DoneFile); done != "" {
+		t.Errorf("Expected reopened task to leave done.txt, got:\n%s", done)
+	}
+}
+
+func TestTodoTxtStorageMeta(t *testing.T) {
+	storage := useTodoTxtStorage(t, "", "")
+
+	service := loadService(t)
+	service.SaveView(View{Name: "work", Query: "tag:work"})
+	service.SaveTasks()
+
+	if _, err := os.Stat(storage.DoneFile); !os.IsNotExist(err) {
+		t.Errorf("Expected done.txt not to be created while empty")
+	}
+	if _, err := loadService(t).GetView("work"); err != nil {
+		t.Errorf("Expected view to be kept in the metadata file: %v", err)
+	}
+}
+
+func TestTodoTxtStorageTitles(t *testing.T) {
+	storage := useTodoTxtStorage(t, "(E) Read docs\n", "")
+
+	titles := []string{
+		"Fix @home and +work bugs",
+		"Ask about due:friday and est:soon",
+		"Set rec:weekly pri:A",
+		`Use \n in C`,
+		"x marks the spot",
+		"(A) team sync",
+		"2026-10-01 retro",
+		"Two  spaces",
+		"Two spaces",
+	}
+	service := loadService(t)
+	for _, title := range titles {
+		service.AddTask(title)
+	}
+	service.UpdateTask("Read docs", func(task *Task) { task.Tags = []string{"work"} })
+	service.SaveTasks()
+
+	reloaded := loadService(t)
+	for _, title := range titles {
+		task, err := reloaded.GetTask(title)
+		if err != nil {
+			t.Errorf("Expected %q after reload: %v", title, err)
+			continue
+		}
+		if len(task.Tags) != 0 || !task.Due.IsZero() || task.Priority != "" || task.Status != TaskStatusPending {
+			t.Errorf("Expected the title of %q not to set fields, got %+v", title, task)
+		}
+	}
+	if len(reloaded.Tasks) != len(titles)+1 {
+		t.Errorf("Expected %d tasks, got %d", len(titles)+1, len(reloaded.Tasks))
+	}
+
+	// Priority letters after D are kept when the line is rewritten
+	if todo := readFile(t, storage.TodoFile); !strings.HasPrefix(todo, "(E) Read docs +work\n") {
+		t.Errorf("Unexpected todo.txt:\n%s", todo)
+	}
+}
@@ -0,0 +1,217 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// todoTxtPriorities maps todo.txt priority letters to task priorities.
+// Letters after C are treated as low.
+var todoTxtPriorities = map[byte]TaskPriority{
+	'A': TaskPriorityCritical,
+	'B': TaskPriorityHigh,
+	'C': TaskPriorityMedium,
+}
+
+// ParseTodoTxtLine parses one line in todo.txt format, e.g.
+//
+//	x 2026-10-19 2026-10-01 (A) Call mom +family @phone due:2026-10-20
+//
+// Projects (+) become tags without their sigil and contexts (@) become tags
+// starting with @. The due:, est:, rec: and pri: extensions set the due
+// date, estimate, recurrence and priority. Other key:value pairs, including
+// rec: values in other tools' formats, are kept in the title, as are words
+// escaped with a backslash, which is removed.
+func ParseTodoTxtLine(line string) (Task, error) {
+	task, _, err := parseTodoTxtLine(line)
+	return task, err
+}
+
+// parseTodoTxtLine parses a line like ParseTodoTxtLine and also returns its
+// priority letter.
+func parseTodoTxtLine(line string) (Task, byte, error) {
+	task := Task{Status: TaskStatusPending}
+	var letter byte
+	fields := strings.Fields(line)
+
+	if len(fields) > 0 && fields[0] == "x" {
+		task.Status = TaskStatusCompleted
+		fields = fields[1:]
+		if date, ok := parseTodoTxtDate(fields); ok {
+			task.CompletedAt = date
+			fields = fields[1:]
+		}
+	}
+	if len(fields) > 0 && task.Status != TaskStatusCompleted {
+		if p, ok := parseTodoTxtPriority(fields[0]); ok {
+			task.Priority, letter = p, fields[0][1]
+			fields = fields[1:]
+		}
+	}
+	if date, ok := parseTodoTxtDate(fields); ok {
+		task.CreatedAt = date
+		fields = fields[1:]
+	}
+
+	var words []string
+	for _, field := range fields {
+		if len(field) > 1 && field[0] == '\\' {
+			words = append(words, field[1:])
+			continue
+		}
+		ok, err := parseTodoTxtField(&task, field)
+		if err != nil {
+			return Task{}, 0, err
+		}
+		if !ok {
+			words = append(words, field)
+		} else if strings.HasPrefix(field, "pri:") {
+			letter = field[len("pri:")]
+		}
+	}
+
+	task.Title = strings.Join(words, " ")
+	if task.Title == "" {
+		return Task{}, 0, errors.New("task title cannot be empty")
+	}
+	return task, letter, nil
+}
+
+// parseTodoTxtField sets the task field that field stands for and reports
+// whether it was one. Fields that are part of the title are left alone.
+func parseTodoTxtField(task *Task, field string) (bool, error) {
+	switch {
+	case len(field) > 1 && field[0] == '+':
+		task.Tags = append(task.Tags, field[1:])
+	case len(field) > 1 && field[0] == '@':
+		task.Tags = append(task.Tags, field)
+	case strings.HasPrefix(field, "due:"):
+		due, err := time.ParseInLocation(DateLayout, field[len("due:"):], time.Local)
+		if err != nil {
+			return false, errors.New("invalid due date " + field)
+		}
+		task.Due = due
+	case strings.HasPrefix(field, "est:"):
+		estimate, err := ParseEstimate(field[len("est:"):])
+		if err != nil {
+			return false, err
+		}
+		task.Estimate = estimate
+	case strings.HasPrefix(field, "rec:"):
+		rule, err := ParseRecurrence(field[len("rec:"):])
+		if err != nil {
+			return false, nil
+		}
+		task.Recurrence = rule.String()
+	case strings.HasPrefix(field, "pri:"):
+		// Completed tasks keep their priority as a pri: extension
+		p, ok := parseTodoTxtPriority("(" + field[len("pri:"):] + ")")
+		if !ok {
+			return false, nil
+		}
+		task.Priority = p
+	default:
+		return false, nil
+	}
+	return true, nil
+}
+
+// escapeTodoTxtTitle escapes the words of title that would not be read back
+// as part of the title: projects, contexts, extensions, words starting with
+// a backslash and, at the start, a completion mark, priority or date.
+func escapeTodoTxtTitle(title string) []string {
+	words := strings.Fields(title)
+	for i, word := range words {
+		_, dated := parseTodoTxtDate([]string{word})
+		_, prioritized := parseTodoTxtPriority(word)
+		ok, err := parseTodoTxtField(&Task{}, word)
+		if ok || err != nil || word[0] == '\\' || (i == 0 && (word == "x" || dated || prioritized)) {
+			words[i] = "\\" + word
+		}
+	}
+	return words
+}
+
+func parseTodoTxtPriority(field string) (TaskPriority, bool) {
+	if len(field) != 3 || field[0] != '(' || field[2] != ')' || field[1] < 'A' || field[1] > 'Z' {
+		return "", false
+	}
+	if p, ok := todoTxtPriorities[field[1]]; ok {
+		return p, true
+	}
+	return TaskPriorityLow, true
+}
+
+func parseTodoTxtDate(fields []string) (time.Time, bool) {
+	if len(fields) == 0 {
+		return time.Time{}, false
+	}
+	date, err := time.ParseInLocation(DateLayout, fields[0], time.Local)
+	return date, err == nil
+}
+
+// FormatTodoTxtLine formats a task as a todo.txt line that ParseTodoTxtLine
+// reads back. Tags starting with @ are written as contexts and other tags
+// as +projects, and completed tasks keep their priority as a pri: extension.
+// Title words that would be read as todo.txt syntax are escaped with a
+// backslash; runs of whitespace in the title are written as single spaces.
+func FormatTodoTxtLine(task Task) string {
+	return formatTodoTxtLine(task, 0)
+}
+
+// formatTodoTxtLine formats a line like FormatTodoTxtLine, writing the
+// priority as letter if it stands for the priority of the task.
+func formatTodoTxtLine(task Task, letter byte) string {
+	var parts []string
+	priority := todoTxtPriority(task.Priority)
+	if p, ok := parseTodoTxtPriority("(" + string(letter) + ")"); ok && p == task.Priority {
+		priority = string(letter)
+	}
+	if task.Status == TaskStatusCompleted {
+		parts = append(parts, "x")
+		if !task.CompletedAt.IsZero() {
+			parts = append(parts, task.CompletedAt.Format(DateLayout))
+		}
+	} else if priority != "" {
+		parts = append(parts, "("+priority+")")
+	}
+	// A creation date is only allowed after a completion date
+	if !task.CreatedAt.IsZero() && (task.Status != TaskStatusCompleted || !task.CompletedAt.IsZero()) {
+		parts = append(parts, task.CreatedAt.Format(DateLayout))
+	}
+
+	parts = append(parts, escapeTodoTxtTitle(task.Title)...)
+	for _, tag := range task.Tags {
+		if strings.HasPrefix(tag, "@") {
+			parts = append(parts, tag)
+		} else {
+			parts = append(parts, "+"+tag)
+		}
+	}
+	if !task.Due.IsZero() {
+		parts = append(parts, "due:"+task.Due.Format(DateLayout))
+	}
+	if !task.Estimate.IsZero() {
+		parts = append(parts, "est:"+task.Estimate.String())
+	}
+	if rule, err := ParseRecurrence(task.Recurrence); task.Recurrence != "" && err == nil {
+		parts = append(parts, "rec:"+rule.String())
+	}
+	if task.Status == TaskStatusCompleted && priority != "" {
+		parts = append(parts, "pri:"+priority)
+	}
+	return strings.Join(parts, " ")
+}
+
+func todoTxtPriority(p TaskPriority) string {
+	for letter, priority := range todoTxtPriorities {
+		if priority == p {
+			return string(letter)
+		}
+	}
+	if p == TaskPriorityLow {
+		return "D"
+	}
+	return ""
+}
@@ -0,0 +1,121 @@
+package services
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseTodoTxtLine(t *testing.T) {
+	date := func(day int) time.Time {
+		return time.Date(2026, 10, day, 0, 0, 0, 0, time.Local)
+	}
+	tests := []struct {
+		line    string
+		want    Task
+		wantErr bool
+	}{
+		{
+			line: "Buy milk",
+			want: Task{Title: "Buy milk", Status: TaskStatusPending},
+		},
+		{
+			line: "(A) 2026-10-01 Call mom +family @phone due:2026-10-20",
+			want: Task{Title: "Call mom", Status: TaskStatusPending, Priority: TaskPriorityCritical, CreatedAt: date(1), Tags: []string{"family", "@phone"}, Due: date(20)},
+		},
+		{
+			line: "x 2026-10-19 2026-10-01 Call mom pri:B",
+			want: Task{Title: "Call mom", Status: TaskStatusCompleted, Priority: TaskPriorityHigh, CompletedAt: date(19), CreatedAt: date(1)},
+		},
+		{
+			line: "(E) Read docs est:1h see http://example.com",
+			want: Task{Title: "Read docs see http://example.com", Status: TaskStatusPending, Priority: TaskPriorityLow, Estimate: Estimate{Duration: time.Hour}},
+		},
+		{
+			// rec: values in other tools' formats stay in the title
+			line: "Water plants rec:weekly rec:+1w",
+			want: Task{Title: "Water plants rec:+1w", Status: TaskStatusPending, Recurrence: "FREQ=WEEKLY"},
+		},
+		{
+			// Lowercase or unbracketed letters are part of the title
+			line: "(a) x marks the spot",
+			want: Task{Title: "(a) x marks the spot", Status: TaskStatusPending},
+		},
+		{
+			// Escaped words are part of the title
+			line: `(B) \x \@home \due:tomorrow \\n`,
+			want: Task{Title: `x @home due:tomorrow \n`, Status: TaskStatusPending, Priority: TaskPriorityHigh},
+		},
+		{line: "Pay rent due:tomorrow", wantErr: true},
+		{line: "(A) +home", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			got, err := ParseTodoTxtLine(tt.line)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseTodoTxtLine() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got.Title != tt.want.Title || got.Status != tt.want.Status || got.Priority != tt.want.Priority {
+				t.Errorf("got %q %s %q, want %q %s %q", got.Title, got.Status, got.Priority, tt.want.Title, tt.want.Status, tt.want.Priority)
+			}
+			if !got.Due.Equal(tt.want.Due) || !got.CreatedAt.Equal(tt.want.CreatedAt) || !got.CompletedAt.Equal(tt.want.CompletedAt) {
+				t.Errorf("got dates due=%v created=%v completed=%v, want %v %v %v", got.Due, got.CreatedAt, got.CompletedAt, tt.want.Due, tt.want.CreatedAt, tt.want.CompletedAt)
+			}
+			if strings.Join(got.Tags, ",") != strings.Join(tt.want.Tags, ",") {
+				t.Errorf("got tags %v, want %v", got.Tags, tt.want.Tags)
+			}
+			if got.Recurrence != tt.want.Recurrence {
+				t.Errorf("got recurrence %q, want %q", got.Recurrence, tt.want.Recurrence)
+			}
+			if got.Estimate != tt.want.Estimate {
+				t.Errorf("got estimate %v, want %v", got.Estimate, tt.want.Estimate)
+			}
+		})
+	}
+}
+
+func TestFormatTodoTxtLine(t *testing.T) {
+	created := time.Date(2026, 10, 1, 9, 0, 0, 0, time.Local)
+	completed := time.Date(2026, 10, 19, 9, 0, 0, 0, time.Local)
+	tests := []struct {
+		task Task
+		want string
+	}{
+		{
+			task: Task{Title: "Buy milk", Status: TaskStatusPending},
+			want: "Buy milk",
+		},
+		{
+			task: Task{Title: "Call mom", Status: TaskStatusPending, Priority: TaskPriorityCritical, CreatedAt: created, Tags: []string{"family", "@phone"}, Due: completed, Estimate: Estimate{Duration: 30 * time.Minute}, Recurrence: "every 2 weeks"},
+			want: "(A) 2026-10-01 Call mom +family @phone due:2026-10-19 est:30m rec:FREQ=WEEKLY;INTERVAL=2",
+		},
+		{
+			task: Task{Title: "Call mom", Status: TaskStatusCompleted, Priority: TaskPriorityLow, CreatedAt: created, CompletedAt: completed},
+			want: "x 2026-10-19 2026-10-01 Call mom pri:D",
+		},
+		{
+			task: Task{Title: `2026-10-01 fix +work @home due:tomorrow rec:weekly rec:+1w \n`, Status: TaskStatusPending},
+			want: `\2026-10-01 fix \+work \@home \due:tomorrow \rec:weekly rec:+1w \\n`,
+		},
+		{
+			// Without a completion date the creation date cannot be written
+			task: Task{Title: "Old task", Status: TaskStatusCompleted, CreatedAt: created},
+			want: "x Old task",
+		},
+	}
+
+	for _, tt := range tests {
+		got := FormatTodoTxtLine(tt.task)
+		if got != tt.want {
+			t.Errorf("FormatTodoTxtLine() = %q, want %q", got, tt.want)
+		}
+		parsed, err := ParseTodoTxtLine(got)
+		if err != nil || parsed.Title != tt.task.Title || parsed.Status != tt.task.Status || parsed.Priority != tt.task.Priority {
+			t.Errorf("Round trip of %q gave %+v (%v)", got, parsed, err)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/savabush/taskTracker/pkg/tasktracker/model"
+)
+
+var viewNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]*$`)
+
+// View is a saved list filter together with its sort order and output
+// format, so that long queries do not have to be retyped.
+// View is a saved list filter together with its sort order and output
+// format, so that long queries do not have to be retyped.
+type View = model.View
+
+// SaveView validates the view's name, query and sort order and stores it,
+// replacing any existing view with the same name.
+func (s *TaskService) SaveView(view View) error {
+	if !viewNamePattern.MatchString(view.Name) {
+		return errors.New("view name must start with a letter or digit and contain only letters, digits, '-' and '_'")
+	}
+	if _, err := ParseQuery(view.Query); err != nil {
+		return err
+	}
+	if err := ValidateSortOrder(view.Sort); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Views == nil {
+		s.Views = make(map[string]View)
+	}
+	s.Views[view.Name] = view
+	return nil
+}
+
+func (s *TaskService) GetView(name string) (View, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	view, ok := s.Views[name]
+	if !ok {
+		return View{}, fmt.Errorf("%w: %q", ErrViewNotFound, name)
+	}
+	return view, nil
+}
+
+// GetViews returns all saved views ordered by name.
+func (s *TaskService) GetViews() []View {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	views := make([]View, 0, len(s.Views))
+	for _, view := range s.Views {
+		views = append(views, view)
+	}
+	sort.Slice(views, func(i, j int) bool {
+		return views[i].Name < views[j].Name
+	})
+	return views
+}
+
+func (s *TaskService) DeleteView(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.Views[name]; !ok {
+		return fmt.Errorf("%w: %q", ErrViewNotFound, name)
+	}
+	delete(s.Views, name)
+	return nil
+}
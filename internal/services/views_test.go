@@ -0,0 +1,82 @@
+package services
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSaveAndGetView(t *testing.T) {
+	_, cleanup := createTempTaskFile(t)
+	defer cleanup()
+
+	service := loadService(t)
+	view := View{Name: "urgent", Query: "priority>=high", Sort: "-priority", Output: "json"}
+	if err := service.SaveView(view); err != nil {
+		t.Fatalf("SaveView returned unexpected error: %v", err)
+	}
+	if err := service.SaveTasks(); err != nil {
+		t.Fatalf("SaveTasks returned unexpected error: %v", err)
+	}
+
+	// Views are persisted alongside tasks
+	reloaded := loadService(t)
+	got, err := reloaded.GetView("urgent")
+	if err != nil {
+		t.Fatalf("GetView returned unexpected error: %v", err)
+	}
+	if got != view {
+		t.Errorf("GetView() = %+v, want %+v", got, view)
+	}
+
+	if _, err := reloaded.GetView("missing"); err == nil {
+		t.Errorf("Expected error getting non-existent view")
+	}
+}
+
+func TestSaveViewValidation(t *testing.T) {
+	_, cleanup := createTempTaskFile(t)
+	defer cleanup()
+
+	service := loadService(t)
+	tests := []struct {
+		name string
+		view View
+	}{
+		{name: "Empty name", view: View{Name: ""}},
+		{name: "Invalid name", view: View{Name: "my view"}},
+		{name: "Invalid query", view: View{Name: "broken", Query: "status:"}},
+		{name: "Invalid sort", view: View{Name: "sorted", Sort: "size"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := service.SaveView(tt.view); err == nil {
+				t.Errorf("Expected error saving view %+v", tt.view)
+			}
+		})
+	}
+}
+
+func TestGetAndDeleteViews(t *testing.T) {
+	_, cleanup := createTempTaskFile(t)
+	defer cleanup()
+
+	service := loadService(t)
+	service.SaveView(View{Name: "zeta"})
+	service.SaveView(View{Name: "alpha", Query: "pending"})
+
+	views := service.GetViews()
+	if len(views) != 2 || views[0].Name != "alpha" || views[1].Name != "zeta" {
+		t.Errorf("Expected views sorted by name, got %+v", views)
+	}
+
+	if err := service.DeleteView("alpha"); err != nil {
+		t.Errorf("DeleteView returned unexpected error: %v", err)
+	}
+	if err := service.DeleteView("alpha"); !errors.Is(err, ErrViewNotFound) {
+		t.Errorf("Expected ErrViewNotFound deleting non-existent view, got %v", err)
+	}
+	if len(service.GetViews()) != 1 {
+		t.Errorf("Expected 1 view after deletion, got %d", len(service.GetViews()))
+	}
+}
@@ -0,0 +1,97 @@
+package tui
+
+import (
+	"bufio"
+	"unicode"
+)
+
+// Key is a key press: either a printable character such as "a" or the name
+// of a special key such as "up" or "ctrl+c".
+type Key string
+
+const (
+	KeyUp        Key = "up"
+	KeyDown      Key = "down"
+	KeyLeft      Key = "left"
+	KeyRight     Key = "right"
+	KeyHome      Key = "home"
+	KeyEnd       Key = "end"
+	KeyPageUp    Key = "pgup"
+	KeyPageDown  Key = "pgdown"
+	KeyEnter     Key = "enter"
+	KeyEscape    Key = "esc"
+	KeyBackspace Key = "backspace"
+	KeyDelete    Key = "delete"
+	KeyTab       Key = "tab"
+	KeyBackTab   Key = "shift+tab"
+	KeyCtrlC     Key = "ctrl+c"
+	KeyCtrlU     Key = "ctrl+u"
+	// keyUnknown is an escape sequence or control character without a
+	// binding.
+	keyUnknown Key = ""
+)
+
+// escapeSequences maps the final bytes of CSI ("\x1b[") and SS3 ("\x1bO")
+// sequences to keys.
+var escapeSequences = map[string]Key{
+	"A": KeyUp, "B": KeyDown, "C": KeyRight, "D": KeyLeft,
+	"H": KeyHome, "F": KeyEnd, "Z": KeyBackTab,
+	"1~": KeyHome, "7~": KeyHome, "4~": KeyEnd, "8~": KeyEnd,
+	"3~": KeyDelete, "5~": KeyPageUp, "6~": KeyPageDown,
+}
+
+// ReadKey reads a single key press from a terminal in raw mode. An escape
+// byte on its own is the Escape key; one that arrived together with more
+// input starts an escape sequence.
+func ReadKey(r *bufio.Reader) (Key, error) {
+	c, _, err := r.ReadRune()
+	if err != nil {
+		return keyUnknown, err
+	}
+	switch c {
+	case '\r', '\n':
+		return KeyEnter, nil
+	case '\t':
+		return KeyTab, nil
+	case 0x7f, '\b':
+		return KeyBackspace, nil
+	case 0x03:
+		return KeyCtrlC, nil
+	case 0x15:
+		return KeyCtrlU, nil
+	case 0x1b:
+		if r.Buffered() == 0 {
+			return KeyEscape, nil
+		}
+		return readEscapeSequence(r)
+	}
+	if unicode.IsControl(c) {
+		return keyUnknown, nil
+	}
+	return Key(string(c)), nil
+}
+
+func readEscapeSequence(r *bufio.Reader) (Key, error) {
+	introducer, err := r.ReadByte()
+	if err != nil {
+		return keyUnknown, err
+	}
+	if introducer != '[' && introducer != 'O' {
+		// Alt+key: ignore the modifier
+		r.UnreadByte()
+		return ReadKey(r)
+	}
+	// Parameters and intermediates run up to the final byte in 0x40-0x7e
+	var sequence []byte
+	for {
+		b, err := r.ReadByte()
+		if err != nil {
+			return keyUnknown, err
+		}
+		sequence = append(sequence, b)
+		if b >= 0x40 && b <= 0x7e {
+			break
+		}
+	}
+	return escapeSequences[string(sequence)], nil
+}
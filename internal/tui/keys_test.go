@@ -0,0 +1,45 @@
+package tui
+
+import (
+	"bufio"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestReadKey(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []Key
+	}{
+		{name: "Characters", input: "aé ", want: []Key{"a", "é", " "}},
+		{name: "Control keys", input: "\r\t\x7f\x03\x15", want: []Key{KeyEnter, KeyTab, KeyBackspace, KeyCtrlC, KeyCtrlU}},
+		{name: "Arrows", input: "\x1b[A\x1b[B\x1b[C\x1b[D\x1bOA", want: []Key{KeyUp, KeyDown, KeyRight, KeyLeft, KeyUp}},
+		{name: "Editing keys", input: "\x1b[3~\x1b[5~\x1b[6~\x1b[H\x1b[4~\x1b[Z", want: []Key{KeyDelete, KeyPageUp, KeyPageDown, KeyHome, KeyEnd, KeyBackTab}},
+		{name: "Unknown sequence", input: "\x1b[1;5Ax", want: []Key{keyUnknown, "x"}},
+		{name: "Alt modifier", input: "\x1bx", want: []Key{"x"}},
+		{name: "Lone escape", input: "\x1b", want: []Key{KeyEscape}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader := bufio.NewReader(strings.NewReader(tt.input))
+			var got []Key
+			for {
+				key, err := ReadKey(reader)
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					t.Fatalf("ReadKey returned unexpected error: %v", err)
+				}
+				got = append(got, key)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("ReadKey(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
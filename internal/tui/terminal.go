@@ -0,0 +1,78 @@
+package tui
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/savabush/taskTracker/internal/utils"
+)
+
+// resizeInterval is how often the terminal size is checked between key
+// presses.
+const resizeInterval = 250 * time.Millisecond
+
+// Run runs m on the terminal in and out until the user quits. The screen is
+// switched to the alternate buffer and restored on return.
+func Run(in, out *os.File, m *Model) error {
+	restore, err := utils.MakeRaw(in, out)
+	if err != nil {
+		return err
+	}
+	defer restore()
+	io.WriteString(out, "\x1b[?1049h\x1b[?25l")
+	defer io.WriteString(out, "\x1b[?25h\x1b[?1049l")
+
+	type keyPress struct {
+		key Key
+		err error
+	}
+	keys := make(chan keyPress)
+	go func() {
+		reader := bufio.NewReader(in)
+		for {
+			key, err := ReadKey(reader)
+			keys <- keyPress{key, err}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	ticker := time.NewTicker(resizeInterval)
+	defer ticker.Stop()
+	width, height := 0, 0
+	for {
+		if w, h := utils.TerminalWidth(out), utils.TerminalHeight(out); w != width || h != height {
+			width, height = w, h
+			m.Resize(width, height)
+		}
+		// Raw mode does not translate newlines, and every line is cleared
+		// after its content so that nothing of the previous frame remains
+		frame := strings.ReplaceAll(m.View(), "\n", "\x1b[K\r\n")
+		if _, err := io.WriteString(out, "\x1b[H"+frame+"\x1b[K\x1b[J"); err != nil {
+			return err
+		}
+
+		select {
+		case press := <-keys:
+			if errors.Is(press.err, io.EOF) {
+				return nil
+			}
+			if press.err != nil {
+				return press.err
+			}
+			if m.Update(press.key) {
+				return nil
+			}
+		case <-ticker.C:
+			if w, h := utils.TerminalWidth(out), utils.TerminalHeight(out); w == width && h == height {
+				// Nothing to redraw
+				continue
+			}
+		}
+	}
+}
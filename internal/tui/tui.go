@@ -0,0 +1,699 @@
+// Package tui implements the full-screen terminal interface of the tui
+// command: a board with a column per status, a detail pane and prompts for
+// adding, editing, deleting and filtering tasks.
+package tui
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/fatih/color"
+	"github.com/savabush/taskTracker/internal/services"
+	"github.com/savabush/taskTracker/internal/utils"
+	"github.com/savabush/taskTracker/pkg/tasktracker"
+)
+
+type mode int
+
+const (
+	modeBrowse mode = iota
+	modeFilter
+	modeAdd
+	modeEdit
+	modeDelete
+)
+
+// columns are the board columns, one per status.
+var columns = []struct {
+	status services.TaskStatus
+	title  string
+}{
+	{services.TaskStatusPending, "Pending"},
+	{services.TaskStatusInProgress, "In Progress"},
+	{services.TaskStatusCompleted, "Completed"},
+}
+
+// sortOrder orders the tasks within a column, as on the web board.
+const sortOrder = "-priority,due,title"
+
+// minDetailsWidth is the narrowest terminal that still gets a detail pane.
+const minDetailsWidth = 72
+
+var (
+	statusColors = map[services.TaskStatus]*color.Color{
+		services.TaskStatusPending:    color.New(color.FgYellow, color.Bold),
+		services.TaskStatusInProgress: color.New(color.FgCyan, color.Bold),
+		services.TaskStatusCompleted:  color.New(color.FgGreen, color.Bold),
+	}
+	selectedColor = color.New(color.ReverseVideo)
+	overdueColor  = color.New(color.FgRed)
+	faintColor    = color.New(color.Faint)
+)
+
+var helpLines = map[mode]string{
+	modeBrowse: "q quit  ←→↑↓ move  a add  e edit  x delete  s start  c complete  p pending  / filter  r reload",
+	modeFilter: "enter apply  esc cancel",
+	modeAdd:    "enter add  esc cancel",
+	modeEdit:   "tab/↑↓ field  enter save  esc cancel",
+	modeDelete: "y delete  any other key cancel",
+}
+
+// formField is a field of the edit form.
+type formField struct {
+	label string
+	value []rune
+}
+
+// Model is the state of the terminal UI. Update changes it in response to key
+// presses and View renders it, so that it can be driven without a terminal.
+// Every change is applied to the task store through the client right away,
+// on the tasks as they are stored then.
+type Model struct {
+	// Color enables colors. The selection is marked without them too.
+	Color bool
+
+	client        *tasktracker.Client
+	all           []services.Task
+	details       func(io.Writer, services.Task)
+	width, height int
+
+	mode     mode
+	filter   string
+	query    services.Query
+	tasks    [][]services.Task
+	column   int
+	selected []int
+	top      []int
+
+	input       []rune
+	savedFilter string
+	form        []formField
+	field       int
+	message     string
+}
+
+// NewModel creates a model showing all tasks of the task store of client.
+// details writes the details of the selected task to the detail pane.
+func NewModel(client *tasktracker.Client, details func(io.Writer, services.Task)) (*Model, error) {
+	m := &Model{
+		client:   client,
+		details:  details,
+		width:    80,
+		height:   24,
+		tasks:    make([][]services.Task, len(columns)),
+		selected: make([]int, len(columns)),
+		top:      make([]int, len(columns)),
+	}
+	m.query, _ = services.ParseQuery("")
+	if err := m.load(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Resize sets the size of the terminal.
+func (m *Model) Resize(width, height int) {
+	m.width, m.height = width, height
+}
+
+// Selected returns the selected task, if any.
+func (m *Model) Selected() (services.Task, bool) {
+	tasks := m.tasks[m.column]
+	if len(tasks) == 0 {
+		return services.Task{}, false
+	}
+	return tasks[m.selected[m.column]], true
+}
+
+// load reloads the tasks from the task store.
+func (m *Model) load() error {
+	tasks, err := m.client.List(context.Background(), tasktracker.ListOptions{})
+	if err != nil {
+		return err
+	}
+	m.all = tasks
+	m.refresh()
+	return nil
+}
+
+// refresh fills the columns with the loaded tasks, keeping the selection on
+// the same tasks where they are still shown.
+func (m *Model) refresh() {
+	selectedIDs := make([]string, len(columns))
+	for i, tasks := range m.tasks {
+		if len(tasks) > 0 {
+			selectedIDs[i] = tasks[m.selected[i]].ID
+		}
+	}
+
+	for i, column := range columns {
+		byStatus := make(map[string]services.Task)
+		for _, task := range m.all {
+			if task.Status == column.status && m.query.Match(task) {
+				byStatus[task.Title] = task
+			}
+		}
+		m.tasks[i], _ = services.SortTasks(byStatus, sortOrder)
+		m.selected[i] = min(m.selected[i], max(len(m.tasks[i])-1, 0))
+		for j, task := range m.tasks[i] {
+			if task.ID == selectedIDs[i] {
+				m.selected[i] = j
+			}
+		}
+	}
+}
+
+// focus selects the task with the given ID in whichever column shows it.
+func (m *Model) focus(id string) {
+	for i, tasks := range m.tasks {
+		for j, task := range tasks {
+			if task.ID == id {
+				m.column, m.selected[i] = i, j
+				return
+			}
+		}
+	}
+}
+
+// apply runs change on the task store as it is stored now, so that changes
+// made elsewhere since the tasks were loaded are kept, and selects the task
+// change returns. The board shows the tasks as saved, or as loaded again if
+// the change failed.
+func (m *Model) apply(change func(b *tasktracker.Batch) (services.Task, error), message string) error {
+	var task services.Task
+	var tasks []services.Task
+	err := m.client.Batch(context.Background(), func(b *tasktracker.Batch) (err error) {
+		if task, err = change(b); err != nil {
+			return err
+		}
+		tasks, err = b.List(tasktracker.ListOptions{})
+		return err
+	})
+	if err != nil {
+		m.load()
+		return err
+	}
+	m.all = tasks
+	m.refresh()
+	m.focus(task.ID)
+	m.message = message
+	return nil
+}
+
+// reportError shows err, naming the task for the errors the user can fix.
+func (m *Model) reportError(title string, err error) {
+	var invalidErr *tasktracker.InvalidTaskError
+	switch {
+	case errors.Is(err, tasktracker.ErrDuplicateTitle):
+		m.message = fmt.Sprintf("Task %q already exists", title)
+	case errors.Is(err, tasktracker.ErrTaskNotFound):
+		m.message = fmt.Sprintf("Task %q no longer exists", title)
+	case errors.Is(err, tasktracker.ErrInvalidTransition):
+		m.message = fmt.Sprintf("%q already has that status", title)
+	case errors.As(err, &invalidErr):
+		m.message = "Cannot save: " + err.Error()
+	default:
+		m.message = "Failed to save tasks: " + err.Error()
+	}
+}
+
+// transition changes the status of task with change. A task that already
+// has the status is left alone.
+func (m *Model) transition(task services.Task, change func(b *tasktracker.Batch, key string) (services.Task, error), message string) {
+	err := m.apply(func(b *tasktracker.Batch) (services.Task, error) {
+		return change(b, task.ID)
+	}, message)
+	if err != nil {
+		m.reportError(task.Title, err)
+	}
+}
+
+// Update handles a key press and reports whether the UI should quit.
+func (m *Model) Update(key Key) (quit bool) {
+	if key == KeyCtrlC {
+		return true
+	}
+	m.message = ""
+	switch m.mode {
+	case modeFilter:
+		m.updateFilter(key)
+	case modeAdd:
+		m.updateAdd(key)
+	case modeEdit:
+		m.updateEdit(key)
+	case modeDelete:
+		m.updateDelete(key)
+	default:
+		return m.updateBrowse(key)
+	}
+	return false
+}
+
+func (m *Model) updateBrowse(key Key) (quit bool) {
+	selected := &m.selected[m.column]
+	count := len(m.tasks[m.column])
+	task, ok := m.Selected()
+
+	switch key {
+	case "q":
+		return true
+	case KeyLeft, "h":
+		m.column = max(m.column-1, 0)
+	case KeyRight, "l":
+		m.column = min(m.column+1, len(columns)-1)
+	case KeyUp, "k":
+		*selected = max(*selected-1, 0)
+	case KeyDown, "j":
+		*selected = max(min(*selected+1, count-1), 0)
+	case KeyPageUp:
+		*selected = max(*selected-m.listHeight(), 0)
+	case KeyPageDown:
+		*selected = max(min(*selected+m.listHeight(), count-1), 0)
+	case KeyHome, "g":
+		*selected = 0
+	case KeyEnd, "G":
+		*selected = max(count-1, 0)
+	case "a":
+		m.mode, m.input = modeAdd, nil
+	case "/":
+		m.mode, m.input, m.savedFilter = modeFilter, []rune(m.filter), m.filter
+	case KeyEscape:
+		if m.filter != "" {
+			m.setFilter("")
+		}
+	case "r":
+		if err := m.load(); err != nil {
+			m.message = "Failed to load tasks: " + err.Error()
+		} else {
+			m.message = "Reloaded tasks"
+		}
+	case "e", KeyEnter:
+		if ok {
+			m.openForm(task)
+		}
+	case "x", KeyDelete:
+		if ok {
+			m.mode = modeDelete
+		}
+	case "s":
+		if ok {
+			m.transition(task, (*tasktracker.Batch).Start, fmt.Sprintf("Marked %q as in progress", task.Title))
+		}
+	case "c":
+		if ok {
+			m.transition(task, (*tasktracker.Batch).Complete, fmt.Sprintf("Marked %q as completed", task.Title))
+		}
+	case "p":
+		if ok {
+			m.transition(task, (*tasktracker.Batch).Reopen, fmt.Sprintf("Marked %q as pending", task.Title))
+		}
+	}
+	return false
+}
+
+// editInput applies a key to a line of text and reports whether it was one
+// of the editing keys.
+func editInput(input *[]rune, key Key) bool {
+	switch {
+	case key == KeyBackspace:
+		if len(*input) > 0 {
+			*input = (*input)[:len(*input)-1]
+		}
+	case key == KeyCtrlU:
+		*input = nil
+	case utf8.RuneCountInString(string(key)) == 1:
+		*input = append(*input, []rune(string(key))...)
+	default:
+		return false
+	}
+	return true
+}
+
+// setFilter filters the board by filter, keeping the previous query while
+// filter does not parse.
+func (m *Model) setFilter(filter string) error {
+	m.filter = filter
+	query, err := services.ParseQuery(filter)
+	if err != nil {
+		m.message = "Invalid filter: " + err.Error()
+		return err
+	}
+	m.query = query
+	m.refresh()
+	return nil
+}
+
+func (m *Model) updateFilter(key Key) {
+	switch key {
+	case KeyEnter:
+		if err := m.setFilter(string(m.input)); err == nil {
+			m.mode = modeBrowse
+		}
+	case KeyEscape:
+		m.setFilter(m.savedFilter)
+		m.mode = modeBrowse
+	default:
+		if editInput(&m.input, key) {
+			m.setFilter(string(m.input))
+		}
+	}
+}
+
+func (m *Model) updateAdd(key Key) {
+	switch key {
+	case KeyEnter:
+		title := strings.TrimSpace(string(m.input))
+		err := m.apply(func(b *tasktracker.Batch) (services.Task, error) {
+			return b.Add(services.Task{Title: title})
+		}, fmt.Sprintf("Added %q", title))
+		if err != nil {
+			m.reportError(title, err)
+			return
+		}
+		m.mode = modeBrowse
+	case KeyEscape:
+		m.mode = modeBrowse
+	default:
+		editInput(&m.input, key)
+	}
+}
+
+func (m *Model) updateDelete(key Key) {
+	m.mode = modeBrowse
+	task, ok := m.Selected()
+	if key != "y" || !ok {
+		return
+	}
+	err := m.apply(func(b *tasktracker.Batch) (services.Task, error) {
+		return services.Task{}, b.Delete(task.ID)
+	}, fmt.Sprintf("Deleted %q", task.Title))
+	if err != nil {
+		m.reportError(task.Title, err)
+	}
+}
+
+// openForm starts editing task.
+func (m *Model) openForm(task services.Task) {
+	due := ""
+	if !task.Due.IsZero() {
+		due = task.Due.Format(services.DateLayout)
+	}
+	m.form = []formField{
+		{"Title", []rune(task.Title)},
+		{"Description", []rune(task.Description)},
+		{"Tags", []rune(strings.Join(task.Tags, ", "))},
+		{"Priority", []rune(string(task.Priority))},
+		{"Due", []rune(due)},
+		{"Recurrence", []rune(task.Recurrence)},
+		{"Estimate", []rune(task.Estimate.String())},
+	}
+	m.mode, m.field = modeEdit, 0
+}
+
+func (m *Model) updateEdit(key Key) {
+	switch key {
+	case KeyTab, KeyDown:
+		m.field = (m.field + 1) % len(m.form)
+	case KeyBackTab, KeyUp:
+		m.field = (m.field + len(m.form) - 1) % len(m.form)
+	case KeyEscape:
+		m.mode = modeBrowse
+	case KeyEnter:
+		task, ok := m.Selected()
+		if !ok {
+			m.mode = modeBrowse
+			return
+		}
+		update, err := m.parseForm()
+		if err != nil {
+			m.message = "Cannot save: " + err.Error()
+			return
+		}
+		title := strings.TrimSpace(string(m.form[0].value))
+		err = m.apply(func(b *tasktracker.Batch) (services.Task, error) {
+			return b.Update(task.ID, update)
+		}, fmt.Sprintf("Updated %q", title))
+		if err != nil {
+			m.reportError(title, err)
+			return
+		}
+		m.mode = modeBrowse
+	default:
+		editInput(&m.form[m.field].value, key)
+	}
+}
+
+// parseForm parses the due date, estimate and tags of the edit form and
+// returns the update that sets the form on a task. The values are checked
+// when the task is saved.
+func (m *Model) parseForm() (func(task *services.Task), error) {
+	value := func(i int) string { return strings.TrimSpace(string(m.form[i].value)) }
+
+	var due time.Time
+	if dueText := value(4); dueText != "" {
+		var err error
+		if due, err = time.ParseInLocation(services.DateLayout, dueText, time.Local); err != nil {
+			return nil, errors.New("due date must be in YYYY-MM-DD format")
+		}
+	}
+	estimate, err := services.ParseEstimate(value(6))
+	if err != nil {
+		return nil, err
+	}
+	var tags []string
+	for _, tag := range strings.Split(value(2), ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return func(task *services.Task) {
+		task.Title = value(0)
+		task.Description = value(1)
+		task.Tags = tags
+		task.Priority = services.TaskPriority(value(3))
+		task.Due = due
+		task.Recurrence = value(5)
+		task.Estimate = estimate
+	}, nil
+}
+
+// listHeight is the number of task rows shown per column.
+func (m *Model) listHeight() int {
+	return max(m.height-5, 1)
+}
+
+// View renders the whole screen as m.height lines of at most m.width
+// characters.
+func (m *Model) View() string {
+	if m.width < 20 || m.height < 6 {
+		return utils.Truncate("Terminal too small", m.width)
+	}
+
+	detailsWidth := 0
+	if m.width >= minDetailsWidth {
+		detailsWidth = m.width / 3
+	}
+	boardWidth := m.width
+	if detailsWidth > 0 {
+		boardWidth -= detailsWidth + 1
+	}
+	columnWidths := make([]int, len(columns))
+	for i := range columns {
+		columnWidths[i] = (boardWidth - len(columns) + 1) / len(columns)
+	}
+	columnWidths[len(columns)-1] += (boardWidth - len(columns) + 1) % len(columns)
+
+	var lines []string
+	lines = append(lines, m.headerLine())
+
+	var headings, rules []string
+	for i, column := range columns {
+		marker := "  "
+		if i == m.column {
+			marker = "> "
+		}
+		heading := pad(fmt.Sprintf("%s%s (%d)", marker, column.title, len(m.tasks[i])), columnWidths[i])
+		headings = append(headings, m.colorize(statusColors[column.status], heading))
+		rules = append(rules, strings.Repeat("─", columnWidths[i]))
+	}
+	pane := m.paneLines(detailsWidth)
+	if detailsWidth > 0 {
+		title := "Details"
+		if m.mode == modeEdit {
+			title = "Edit task"
+		}
+		headings = append(headings, m.colorize(faintColor, pad(" "+title, detailsWidth)))
+		rules = append(rules, strings.Repeat("─", detailsWidth))
+	}
+	lines = append(lines, strings.Join(headings, "│"), strings.Join(rules, "┼"))
+
+	rows := m.listHeight()
+	for i := range columns {
+		if m.selected[i] < m.top[i] {
+			m.top[i] = m.selected[i]
+		}
+		if m.selected[i] >= m.top[i]+rows {
+			m.top[i] = m.selected[i] - rows + 1
+		}
+		m.top[i] = max(min(m.top[i], len(m.tasks[i])-rows), 0)
+	}
+	now := time.Now()
+	for row := range rows {
+		var cells []string
+		for i := range columns {
+			cells = append(cells, m.cell(i, m.top[i]+row, columnWidths[i], now))
+		}
+		if detailsWidth > 0 {
+			line := ""
+			if row < len(pane) {
+				line = pane[row]
+			}
+			cells = append(cells, pad(" "+line, detailsWidth))
+		}
+		lines = append(lines, strings.Join(cells, "│"))
+	}
+
+	lines = append(lines, pad(m.statusLine(), m.width))
+	lines = append(lines, m.colorize(faintColor, pad(helpLines[m.mode], m.width)))
+	return strings.Join(lines, "\n")
+}
+
+func (m *Model) headerLine() string {
+	filter := " Filter: " + m.filter
+	switch {
+	case m.mode == modeFilter:
+		filter = " Filter: " + string(m.input) + "█"
+	case m.filter == "":
+		filter = " All tasks (/ to filter)"
+	}
+	total := 0
+	for _, tasks := range m.tasks {
+		total += len(tasks)
+	}
+	count := fmt.Sprintf("%d tasks ", total)
+	gap := m.width - utf8.RuneCountInString(count)
+	return utils.Truncate(pad(filter, gap), gap) + count
+}
+
+func (m *Model) statusLine() string {
+	switch m.mode {
+	case modeAdd:
+		return " Add task: " + string(m.input) + "█"
+	case modeDelete:
+		if task, ok := m.Selected(); ok {
+			return fmt.Sprintf(" Delete %q? (y/n)", task.Title)
+		}
+	}
+	if m.message != "" {
+		return " " + m.message
+	}
+	return ""
+}
+
+// cell renders row of a column.
+func (m *Model) cell(column, row, width int, now time.Time) string {
+	tasks := m.tasks[column]
+	if row >= len(tasks) {
+		return strings.Repeat(" ", width)
+	}
+	task := tasks[row]
+	marker := "  "
+	selected := column == m.column && row == m.selected[column]
+	if selected {
+		marker = "> "
+	}
+	text := pad(marker+task.Title, width)
+	switch {
+	case selected:
+		return m.colorize(selectedColor, text)
+	case task.IsOverdue(now):
+		return m.colorize(overdueColor, text)
+	}
+	return text
+}
+
+// paneLines renders the detail pane: the edit form while editing, the
+// details of the selected task otherwise.
+func (m *Model) paneLines(width int) []string {
+	if width == 0 {
+		return nil
+	}
+	width--
+	if m.mode == modeEdit {
+		var lines []string
+		for i, field := range m.form {
+			marker := "  "
+			value := string(field.value)
+			if i == m.field {
+				marker, value = "> ", value+"█"
+			}
+			lines = append(lines, wrap(fmt.Sprintf("%s%-12s %s", marker, field.label+":", value), width)...)
+		}
+		return lines
+	}
+
+	task, ok := m.Selected()
+	if !ok {
+		return []string{"No task selected"}
+	}
+	var buf bytes.Buffer
+	m.details(&buf, task)
+	var lines []string
+	for _, line := range strings.Split(strings.TrimRight(buf.String(), "\n"), "\n") {
+		lines = append(lines, wrap(line, width)...)
+	}
+	return lines
+}
+
+func (m *Model) colorize(c *color.Color, s string) string {
+	if !m.Color {
+		return s
+	}
+	return utils.Colorize(c, s)
+}
+
+// pad truncates or pads s with spaces to exactly width columns.
+func pad(s string, width int) string {
+	s = utils.Truncate(s, width)
+	return s + strings.Repeat(" ", width-utils.DisplayWidth(s))
+}
+
+// wrap breaks s into lines of at most width characters at spaces where
+// possible. Continuation lines of a "Label:  value" line are indented to the
+// value.
+func wrap(s string, width int) []string {
+	runes := []rune(s)
+	if width <= 0 || len(runes) <= width {
+		return []string{s}
+	}
+	indent := 0
+	if label := strings.Index(s, ": "); label > 0 {
+		indent = utf8.RuneCountInString(s[:label+1])
+		for indent < len(runes) && runes[indent] == ' ' {
+			indent++
+		}
+	}
+	if width-indent < 10 {
+		indent = 0
+	}
+
+	var lines []string
+	for len(runes) > width {
+		cut := width
+		for i := width; i > indent; i-- {
+			if runes[i] == ' ' {
+				cut = i
+				break
+			}
+		}
+		lines = append(lines, strings.TrimRight(string(runes[:cut]), " "))
+		runes = append([]rune(strings.Repeat(" ", indent)), []rune(strings.TrimLeft(string(runes[cut:]), " "))...)
+	}
+	return append(lines, string(runes))
+}
@@ -0,0 +1,294 @@
+package tui
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/savabush/taskTracker/internal/services"
+	"github.com/savabush/taskTracker/pkg/tasktracker"
+)
+
+func useTempTaskFile(t *testing.T) string {
+	t.Helper()
+	tmpFile, err := os.CreateTemp(t.TempDir(), "tasks_test*.json")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	tmpFile.Write([]byte(`{"tasks":{}}`))
+	tmpFile.Close()
+
+	origFileName := services.GetTasksFileName()
+	services.SetTasksFileName(tmpFile.Name())
+	t.Cleanup(func() { services.SetTasksFileName(origFileName) })
+	return tmpFile.Name()
+}
+
+// loadService loads the task store, failing the test if it cannot be loaded.
+func loadService(t *testing.T) *services.TaskService {
+	t.Helper()
+	service, err := services.NewTaskService()
+	if err != nil {
+		t.Fatalf("Failed to load tasks: %v", err)
+	}
+	return service
+}
+
+func newTestModel(t *testing.T) *Model {
+	t.Helper()
+	useTempTaskFile(t)
+	service := loadService(t)
+	service.AddTask("Write report")
+	service.UpdateTask("Write report", func(task *services.Task) {
+		task.Priority = services.TaskPriorityHigh
+		task.Tags = []string{"work"}
+	})
+	service.AddTask("Buy milk")
+	service.SaveTasks()
+
+	return newModel(t)
+}
+
+func newModel(t *testing.T) *Model {
+	t.Helper()
+	client, err := tasktracker.New()
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	m, err := NewModel(client, func(w io.Writer, task services.Task) {
+		fmt.Fprintf(w, "Title: %s\nStatus: %s\n", task.Title, task.Status)
+	})
+	if err != nil {
+		t.Fatalf("NewModel returned unexpected error: %v", err)
+	}
+	return m
+}
+
+// press sends keys to m, typing strings that are not key names character by
+// character.
+func press(m *Model, keys ...string) (quit bool) {
+	for _, key := range keys {
+		if utf8.RuneCountInString(key) > 1 && !strings.HasPrefix(key, "=") {
+			quit = m.Update(Key(key))
+			continue
+		}
+		for _, r := range strings.TrimPrefix(key, "=") {
+			quit = m.Update(Key(string(r)))
+		}
+	}
+	return quit
+}
+
+func savedTask(t *testing.T, title string) services.Task {
+	t.Helper()
+	task, err := loadService(t).GetTask(title)
+	if err != nil {
+		t.Fatalf("Expected saved task %q: %v", title, err)
+	}
+	return task
+}
+
+func TestModel_Actions(t *testing.T) {
+	m := newTestModel(t)
+	if task, _ := m.Selected(); task.Title != "Write report" {
+		t.Fatalf("Expected the high-priority task to be selected first, got %q", task.Title)
+	}
+
+	press(m, "a", "=Call mom", "enter")
+	if task, _ := m.Selected(); task.Title != "Call mom" || savedTask(t, "Call mom").Status != services.TaskStatusPending {
+		t.Errorf("Expected added task to be saved and selected, got %+v", task)
+	}
+	press(m, "a", "=Call mom", "enter")
+	if m.mode != modeAdd || !strings.Contains(m.message, "already exists") {
+		t.Errorf("Expected duplicate title to be rejected, got %q", m.message)
+	}
+	press(m, "esc")
+
+	press(m, "s")
+	if m.column != 1 || savedTask(t, "Call mom").Status != services.TaskStatusInProgress {
+		t.Errorf("Expected started task in column 1, got column %d", m.column)
+	}
+	press(m, "c")
+	if m.column != 2 || savedTask(t, "Call mom").Status != services.TaskStatusCompleted {
+		t.Errorf("Expected completed task in column 2, got column %d", m.column)
+	}
+	press(m, "p")
+	if task := savedTask(t, "Call mom"); m.column != 0 || task.Status != services.TaskStatusPending || !task.CompletedAt.IsZero() {
+		t.Errorf("Expected reopened task in column 0, got column %d, %+v", m.column, task)
+	}
+	press(m, "p")
+	if !strings.Contains(m.message, "already has that status") {
+		t.Errorf("Expected reopening a pending task to be reported, got %q", m.message)
+	}
+
+	press(m, "e", "ctrl+u", "=Call dad", "tab", "tab", "=errand, home", "tab", "=high", "tab", "=2026-11-01", "enter")
+	task := savedTask(t, "Call dad")
+	if task.Priority != services.TaskPriorityHigh || strings.Join(task.Tags, ",") != "errand,home" || task.Due.Format(services.DateLayout) != "2026-11-01" {
+		t.Errorf("Unexpected edited task %+v", task)
+	}
+	if _, err := loadService(t).GetTask("Call mom"); err == nil {
+		t.Errorf("Expected old title to be gone after rename")
+	}
+
+	press(m, "e", "shift+tab", "shift+tab", "shift+tab", "shift+tab", "ctrl+u", "=urgent", "enter")
+	if m.mode != modeEdit || !strings.Contains(m.message, "invalid priority") {
+		t.Errorf("Expected invalid priority to be rejected, got mode %d, %q", m.mode, m.message)
+	}
+	press(m, "esc")
+	if savedTask(t, "Call dad").Priority != services.TaskPriorityHigh {
+		t.Errorf("Expected cancelled edit to change nothing")
+	}
+
+	press(m, "x", "n")
+	savedTask(t, "Call dad")
+	press(m, "x", "y")
+	if _, err := loadService(t).GetTask("Call dad"); err == nil {
+		t.Errorf("Expected deleted task to be gone")
+	}
+
+	if !press(m, "q") {
+		t.Errorf("Expected q to quit")
+	}
+	if !press(m, "a", "ctrl+c") {
+		t.Errorf("Expected ctrl+c to quit while typing")
+	}
+}
+
+func TestModel_ConcurrentChanges(t *testing.T) {
+	m := newTestModel(t)
+
+	// A task added by the CLI while the board is open survives a change
+	service := loadService(t)
+	service.AddTask("Added elsewhere")
+	service.SaveTasks()
+	press(m, "s")
+	if savedTask(t, "Write report").Status != services.TaskStatusInProgress {
+		t.Errorf("Expected the selected task to be started")
+	}
+	savedTask(t, "Added elsewhere")
+	if len(m.tasks[0]) != 2 {
+		t.Errorf("Expected the board to show the saved tasks, got %v", m.tasks[0])
+	}
+
+	// A task deleted elsewhere is reported, not saved again
+	service = loadService(t)
+	service.DeleteTask("Buy milk")
+	service.SaveTasks()
+	m.column, m.selected[0] = 0, 1
+	press(m, "c")
+	if !strings.Contains(m.message, "no longer exists") {
+		t.Errorf("Expected the missing task to be reported, got %q", m.message)
+	}
+	if _, err := loadService(t).GetTask("Buy milk"); err == nil {
+		t.Errorf("Expected the deleted task to stay deleted")
+	}
+}
+
+func TestNewModel_LoadError(t *testing.T) {
+	path := useTempTaskFile(t)
+	os.WriteFile(path, []byte(`{"tasks":`), 0644)
+	client, _ := tasktracker.New()
+	if _, err := NewModel(client, nil); err == nil {
+		t.Errorf("Expected an unreadable task store to be reported")
+	}
+}
+
+func TestModel_Filter(t *testing.T) {
+	m := newTestModel(t)
+
+	press(m, "/", "=tag:wor")
+	if len(m.tasks[0]) != 0 {
+		t.Errorf("Expected live filtering while typing, got %v", m.tasks[0])
+	}
+	press(m, "=k", "enter")
+	if m.mode != modeBrowse || len(m.tasks[0]) != 1 || m.tasks[0][0].Title != "Write report" {
+		t.Errorf("Expected only the work task, got %v", m.tasks[0])
+	}
+
+	// An incomplete query keeps the last valid one
+	press(m, "/", "=  and (")
+	if len(m.tasks[0]) != 1 || !strings.Contains(m.message, "Invalid filter") {
+		t.Errorf("Expected previous filter to stay applied, got %v, %q", m.tasks[0], m.message)
+	}
+	press(m, "enter")
+	if m.mode != modeFilter {
+		t.Errorf("Expected invalid filter not to be applied")
+	}
+	press(m, "esc")
+	if m.filter != "tag:work" {
+		t.Errorf("Expected cancel to restore the filter, got %q", m.filter)
+	}
+
+	press(m, "esc")
+	if m.filter != "" || len(m.tasks[0]) != 2 {
+		t.Errorf("Expected esc to clear the filter, got %q, %v", m.filter, m.tasks[0])
+	}
+}
+
+func TestModel_View(t *testing.T) {
+	m := newTestModel(t)
+	m.Resize(90, 12)
+
+	lines := strings.Split(m.View(), "\n")
+	if len(lines) != 12 {
+		t.Fatalf("Expected 12 lines, got %d", len(lines))
+	}
+	for i, line := range lines {
+		if width := utf8.RuneCountInString(line); width != 90 {
+			t.Errorf("Line %d is %d characters wide: %q", i, width, line)
+		}
+	}
+	view := m.View()
+	for _, want := range []string{"> Pending (2)", "In Progress (0)", "> Write report", "  Buy milk", "Details", "Title: Write report", "2 tasks", "q quit"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected view to contain %q:\n%s", want, view)
+		}
+	}
+
+	press(m, "e")
+	if view := m.View(); !strings.Contains(view, "Edit task") || !strings.Contains(view, "> Title:       Write report█") {
+		t.Errorf("Expected edit form in the detail pane:\n%s", view)
+	}
+	press(m, "esc", "x")
+	if view := m.View(); !strings.Contains(view, `Delete "Write report"? (y/n)`) {
+		t.Errorf("Expected delete prompt:\n%s", view)
+	}
+
+	// Narrow terminals leave out the detail pane; long lists scroll
+	press(m, "n")
+	for i := range 10 {
+		press(m, "a", fmt.Sprintf("=Task %02d", i), "enter")
+	}
+	m.Resize(40, 8)
+	press(m, "end")
+	view = m.View()
+	if strings.Contains(view, "Details") || !strings.Contains(view, "> Task 09") || strings.Contains(view, "Buy milk") {
+		t.Errorf("Unexpected narrow view:\n%s", view)
+	}
+}
+
+func TestWrap(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		width int
+		want  []string
+	}{
+		{name: "Fits", input: "Title: Short", width: 20, want: []string{"Title: Short"}},
+		{name: "Words", input: "Description: buy milk and bread", width: 24, want: []string{"Description: buy milk", "             and bread"}},
+		{name: "Long word", input: "ID:  0123456789abcdef", width: 16, want: []string{"ID:  0123456789a", "     bcdef"}},
+		{name: "Narrow", input: "Tags: a, b, c", width: 8, want: []string{"Tags: a,", "b, c"}},
+		{name: "No label", input: "one two three", width: 8, want: []string{"one two", "three"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := wrap(tt.input, tt.width); strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("wrap(%q, %d) = %q, want %q", tt.input, tt.width, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,235 @@
+package utils
+
+import (
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/fatih/color"
+	"github.com/mattn/go-isatty"
+	"golang.org/x/text/width"
+)
+
+const (
+	columnGap     = "  "
+	ellipsis      = "…"
+	defaultWidth  = 80
+	defaultHeight = 24
+)
+
+// Column describes a single table column. Flexible columns absorb the space
+// left over after the fixed columns are sized and are the ones truncated when
+// the table does not fit into the terminal.
+type Column struct {
+	Header   string
+	Flexible bool
+}
+
+// Cell is a single table value with an optional color.
+type Cell struct {
+	Text  string
+	Color *color.Color
+}
+
+// Table renders rows as aligned columns.
+type Table struct {
+	writer  io.Writer
+	columns []Column
+	rows    [][]Cell
+
+	// Width is the maximum line width. Zero disables truncation.
+	Width int
+	// Color enables colored cells.
+	Color bool
+}
+
+// NewTable creates a table writing to w. Colors and terminal-width truncation
+// are enabled only when w is a terminal and NO_COLOR is not set.
+func NewTable(w io.Writer, columns ...Column) *Table {
+	t := &Table{
+		writer:  w,
+		columns: columns,
+	}
+	if f, ok := w.(*os.File); ok && IsTerminal(f) {
+		t.Width = TerminalWidth(f)
+		t.Color = ColorEnabled(f)
+	}
+	return t
+}
+
+// ColorEnabled reports whether colored output should be written to f: it must
+// be a terminal and NO_COLOR must be unset or empty, as https://no-color.org
+// asks.
+func ColorEnabled(f *os.File) bool {
+	return os.Getenv("NO_COLOR") == "" && IsTerminal(f)
+}
+
+// IsTerminal reports whether f is attached to a terminal.
+func IsTerminal(f *os.File) bool {
+	return isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd())
+}
+
+// TerminalWidth returns the width of the terminal f is attached to, falling
+// back to $COLUMNS and then to 80 columns.
+func TerminalWidth(f *os.File) int {
+	if width, _ := terminalSize(f); width > 0 {
+		return width
+	}
+	if width, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && width > 0 {
+		return width
+	}
+	return defaultWidth
+}
+
+// TerminalHeight returns the height of the terminal f is attached to, falling
+// back to $LINES and then to 24 lines.
+func TerminalHeight(f *os.File) int {
+	if _, height := terminalSize(f); height > 0 {
+		return height
+	}
+	if height, err := strconv.Atoi(os.Getenv("LINES")); err == nil && height > 0 {
+		return height
+	}
+	return defaultHeight
+}
+
+// AddRow appends a row. Missing cells are rendered empty and extra cells are
+// ignored.
+func (t *Table) AddRow(cells ...Cell) {
+	row := make([]Cell, len(t.columns))
+	copy(row, cells)
+	t.rows = append(t.rows, row)
+}
+
+// Render writes the header and all rows.
+func (t *Table) Render() error {
+	widths := t.columnWidths()
+
+	header := make([]Cell, len(t.columns))
+	bold := color.New(color.Bold)
+	for i, column := range t.columns {
+		header[i] = Cell{Text: column.Header, Color: bold}
+	}
+
+	if err := t.renderRow(header, widths); err != nil {
+		return err
+	}
+	for _, row := range t.rows {
+		if err := t.renderRow(row, widths); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *Table) renderRow(row []Cell, widths []int) error {
+	var line strings.Builder
+	for i, cell := range row {
+		text := Truncate(cell.Text, widths[i])
+		padding := ""
+		if i < len(row)-1 {
+			padding = strings.Repeat(" ", widths[i]-DisplayWidth(text)) + columnGap
+		}
+		if t.Color && cell.Color != nil {
+			text = Colorize(cell.Color, text)
+		}
+		line.WriteString(text)
+		line.WriteString(padding)
+	}
+	line.WriteString("\n")
+	_, err := io.WriteString(t.writer, line.String())
+	return err
+}
+
+// columnWidths sizes every column to its widest cell and then, if the table
+// is wider than t.Width, shrinks the flexible columns evenly until it fits or
+// every flexible column is down to the width of its header.
+func (t *Table) columnWidths() []int {
+	widths := make([]int, len(t.columns))
+	for i, column := range t.columns {
+		widths[i] = DisplayWidth(column.Header)
+	}
+	for _, row := range t.rows {
+		for i, cell := range row {
+			widths[i] = max(widths[i], DisplayWidth(cell.Text))
+		}
+	}
+
+	if t.Width <= 0 {
+		return widths
+	}
+
+	overflow := len(columnGap)*(len(widths)-1) - t.Width
+	for _, width := range widths {
+		overflow += width
+	}
+	for overflow > 0 {
+		var shrinkable []int
+		for i, column := range t.columns {
+			if column.Flexible && widths[i] > DisplayWidth(column.Header) {
+				shrinkable = append(shrinkable, i)
+			}
+		}
+		if len(shrinkable) == 0 {
+			break
+		}
+		share := (overflow + len(shrinkable) - 1) / len(shrinkable)
+		for _, i := range shrinkable {
+			shrink := min(share, widths[i]-DisplayWidth(t.columns[i].Header), overflow)
+			widths[i] -= shrink
+			overflow -= shrink
+		}
+	}
+	return widths
+}
+
+// DisplayWidth returns the number of terminal columns s takes up: wide East
+// Asian characters and emoji take two, combining marks and format characters
+// none.
+func DisplayWidth(s string) int {
+	total := 0
+	for _, r := range s {
+		total += runeWidth(r)
+	}
+	return total
+}
+
+func runeWidth(r rune) int {
+	if unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf) {
+		return 0
+	}
+	switch width.LookupRune(r).Kind() {
+	case width.EastAsianWide, width.EastAsianFullwidth:
+		return 2
+	}
+	return 1
+}
+
+// Truncate shortens s to at most width terminal columns, replacing the tail
+// with an ellipsis when something was cut off.
+func Truncate(s string, width int) string {
+	if DisplayWidth(s) <= width {
+		return s
+	}
+	if width <= 0 {
+		return ""
+	}
+	used := DisplayWidth(ellipsis)
+	for i, r := range s {
+		if used+runeWidth(r) > width {
+			return s[:i] + ellipsis
+		}
+		used += runeWidth(r)
+	}
+	return s
+}
+
+// Colorize forces c on regardless of the global color.NoColor setting, which
+// is derived from os.Stdout at start-up rather than from the actual writer.
+func Colorize(c *color.Color, s string) string {
+	colored := *c
+	colored.EnableColor()
+	return colored.Sprint(s)
+}
@@ -0,0 +1,136 @@
+package utils
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/fatih/color"
+)
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		width int
+		want  string
+	}{
+		{name: "Fits", input: "short", width: 10, want: "short"},
+		{name: "Exact", input: "exact", width: 5, want: "exact"},
+		{name: "Too long", input: "a long title", width: 6, want: "a lon…"},
+		{name: "Multibyte", input: "задача дня", width: 4, want: "зад…"},
+		{name: "Zero width", input: "anything", width: 0, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Truncate(tt.input, tt.width); got != tt.want {
+				t.Errorf("Truncate(%q, %d) = %q, want %q", tt.input, tt.width, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTableAlignment(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewTable(&buf, Column{Header: "STATUS"}, Column{Header: "TITLE", Flexible: true})
+	table.AddRow(Cell{Text: "pending"}, Cell{Text: "First"})
+	table.AddRow(Cell{Text: "completed"}, Cell{Text: "Second"})
+
+	if err := table.Render(); err != nil {
+		t.Fatalf("Render returned unexpected error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("Expected header and 2 rows, got %d lines: %q", len(lines), buf.String())
+	}
+
+	// The title column must start at the same offset on every line
+	offset := strings.Index(lines[0], "TITLE")
+	for _, line := range lines[1:] {
+		if got := strings.IndexAny(line, "FS"); got != offset {
+			t.Errorf("Title column misaligned: got offset %d, want %d in %q", got, offset, line)
+		}
+	}
+}
+
+func TestTableTruncatesFlexibleColumn(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewTable(&buf, Column{Header: "STATUS"}, Column{Header: "TITLE", Flexible: true})
+	table.Width = 20
+	table.AddRow(Cell{Text: "pending"}, Cell{Text: "A very long task title that does not fit"})
+
+	if err := table.Render(); err != nil {
+		t.Fatalf("Render returned unexpected error: %v", err)
+	}
+
+	for _, line := range strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n") {
+		if n := DisplayWidth(line); n > table.Width {
+			t.Errorf("Line exceeds width %d (%d): %q", table.Width, n, line)
+		}
+	}
+	if !strings.Contains(buf.String(), "…") {
+		t.Errorf("Expected truncated title with ellipsis, got: %q", buf.String())
+	}
+}
+
+func TestTableColors(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewTable(&buf, Column{Header: "STATUS"})
+	table.AddRow(Cell{Text: "pending", Color: color.New(color.FgYellow)})
+
+	// A bytes.Buffer is not a terminal, so colors must be off by default
+	if table.Color {
+		t.Fatalf("Expected colors to be disabled for non-terminal writers")
+	}
+	table.Render()
+	if strings.Contains(buf.String(), "\x1b[") {
+		t.Errorf("Expected no escape sequences, got: %q", buf.String())
+	}
+
+	buf.Reset()
+	table.Color = true
+	table.Render()
+	if !strings.Contains(buf.String(), "\x1b[33mpending") {
+		t.Errorf("Expected yellow status cell, got: %q", buf.String())
+	}
+}
+
+func TestDisplayWidth(t *testing.T) {
+	tests := map[string]int{
+		"":         0,
+		"title":    5,
+		"задача":   6,
+		"日本語":      6,
+		"e\u0301t": 2,
+		"ok 👍":     5,
+	}
+	for input, want := range tests {
+		if got := DisplayWidth(input); got != want {
+			t.Errorf("DisplayWidth(%q) = %d, want %d", input, got, want)
+		}
+	}
+}
+
+func TestTableFitsWideCharacters(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewTable(&buf,
+		Column{Header: "ID"},
+		Column{Header: "TITLE", Flexible: true},
+		Column{Header: "TAGS", Flexible: true},
+	)
+	table.Width = 30
+	table.AddRow(Cell{Text: "1"}, Cell{Text: "日本語のとても長いタスクのタイトル"}, Cell{Text: "x"})
+	table.AddRow(Cell{Text: "2"}, Cell{Text: "Short"}, Cell{Text: "many,different,tags,here"})
+
+	if err := table.Render(); err != nil {
+		t.Fatalf("Render returned unexpected error: %v", err)
+	}
+
+	for _, line := range strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n") {
+		if n := DisplayWidth(line); n > table.Width {
+			t.Errorf("Line exceeds width %d (%d): %q", table.Width, n, line)
+		}
+	}
+}
@@ -0,0 +1,19 @@
+//go:build !aix && !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd && !solaris && !windows && !zos
+
+package utils
+
+import (
+	"errors"
+	"os"
+	"runtime"
+)
+
+// terminalSize is unknown on other systems, so tables fall back to $COLUMNS.
+func terminalSize(f *os.File) (width, height int) {
+	return 0, 0
+}
+
+// MakeRaw is not supported on other systems.
+func MakeRaw(in, out *os.File) (restore func(), err error) {
+	return nil, errors.New("raw terminal mode is not supported on " + runtime.GOOS)
+}
@@ -0,0 +1,149 @@
+// Package taskclient is a Go client for the taskTracker gRPC API, served by
+// "taskTracker serve --grpc-addr".
+//
+//	client, err := taskclient.Dial("localhost:9090")
+//	if err != nil {
+//		return err
+//	}
+//	defer client.Close()
+//	tasks, err := client.List(ctx, "tag:work", "-priority")
+//
+// Errors are gRPC status errors; IsNotFound and IsAlreadyExists check for the
+// common ones.
+package taskclient
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	pb "github.com/savabush/taskTracker/pkg/tasktrackerpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// Client calls the TaskTracker service. Tasks are identified by ID or title.
+type Client struct {
+	conn *grpc.ClientConn
+	rpc  pb.TaskTrackerClient
+}
+
+// Dial connects to the server at target. Without options the connection is
+// not encrypted, as served by the serve command.
+func Dial(target string, opts ...grpc.DialOption) (*Client, error) {
+	if len(opts) == 0 {
+		opts = []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	}
+	conn, err := grpc.NewClient(target, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{conn: conn, rpc: pb.NewTaskTrackerClient(conn)}, nil
+}
+
+// New creates a client on an existing connection, which Close leaves open.
+func New(conn grpc.ClientConnInterface) *Client {
+	return &Client{rpc: pb.NewTaskTrackerClient(conn)}
+}
+
+// Close closes the connection opened by Dial.
+func (c *Client) Close() error {
+	if c.conn == nil {
+		return nil
+	}
+	return c.conn.Close()
+}
+
+// List returns the tasks matching filter, sorted by the comma-separated sort
+// fields. Both use the syntax of the list command and may be empty.
+func (c *Client) List(ctx context.Context, filter, sort string) ([]*pb.Task, error) {
+	resp, err := c.rpc.ListTasks(ctx, &pb.ListTasksRequest{Filter: filter, Sort: sort})
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetTasks(), nil
+}
+
+// Get returns a task.
+func (c *Client) Get(ctx context.Context, task string) (*pb.Task, error) {
+	return c.rpc.GetTask(ctx, &pb.GetTaskRequest{Task: task})
+}
+
+// Create creates a pending task from the title, description, notes, tags,
+// priority, due date, recurrence and estimate of task.
+func (c *Client) Create(ctx context.Context, task *pb.Task) (*pb.Task, error) {
+	return c.rpc.CreateTask(ctx, &pb.CreateTaskRequest{Task: task})
+}
+
+// Update sets the given fields of a task, such as "title" or "due", to their
+// values in fields; fields that are unset there are cleared. Without paths,
+// the fields set in fields are changed.
+func (c *Client) Update(ctx context.Context, task string, fields *pb.Task, paths ...string) (*pb.Task, error) {
+	req := &pb.UpdateTaskRequest{Task: task, Fields: fields}
+	if len(paths) > 0 {
+		req.UpdateMask = &fieldmaskpb.FieldMask{Paths: paths}
+	}
+	return c.rpc.UpdateTask(ctx, req)
+}
+
+// Delete deletes a task.
+func (c *Client) Delete(ctx context.Context, task string) error {
+	_, err := c.rpc.DeleteTask(ctx, &pb.DeleteTaskRequest{Task: task})
+	return err
+}
+
+// Start marks a task as in progress.
+func (c *Client) Start(ctx context.Context, task string) (*pb.Task, error) {
+	return c.rpc.StartTask(ctx, &pb.StartTaskRequest{Task: task})
+}
+
+// Complete marks a task as completed.
+func (c *Client) Complete(ctx context.Context, task string) (*pb.Task, error) {
+	return c.rpc.CompleteTask(ctx, &pb.CompleteTaskRequest{Task: task})
+}
+
+// Reopen marks a task as pending again.
+func (c *Client) Reopen(ctx context.Context, task string) (*pb.Task, error) {
+	return c.rpc.ReopenTask(ctx, &pb.ReopenTaskRequest{Task: task})
+}
+
+// Watch calls handle with every change to the tasks matching filter until ctx
+// is cancelled, which returns nil, or handle returns an error, which is
+// returned. With sendInitial the matching tasks are first reported as
+// created.
+func (c *Client) Watch(ctx context.Context, filter string, sendInitial bool, handle func(*pb.TaskEvent) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := c.rpc.WatchTasks(ctx, &pb.WatchTasksRequest{Filter: filter, SendInitial: sendInitial})
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil
+		}
+		return err
+	}
+	for {
+		event, err := stream.Recv()
+		if err != nil {
+			if ctx.Err() != nil || errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		if err := handle(event); err != nil {
+			return err
+		}
+	}
+}
+
+// IsNotFound reports whether err is the error for an unknown task.
+func IsNotFound(err error) bool {
+	return status.Code(err) == codes.NotFound
+}
+
+// IsAlreadyExists reports whether err is the error for a duplicate title.
+func IsAlreadyExists(err error) bool {
+	return status.Code(err) == codes.AlreadyExists
+}
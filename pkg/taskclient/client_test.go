@@ -0,0 +1,116 @@
+package taskclient
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/savabush/taskTracker/internal/grpcserver"
+	"github.com/savabush/taskTracker/internal/services"
+	pb "github.com/savabush/taskTracker/pkg/tasktrackerpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// startClient serves the task store in a temporary file on an in-process
+// listener and returns a client for it.
+func startClient(t *testing.T) *Client {
+	t.Helper()
+	tmpFile, err := os.CreateTemp(t.TempDir(), "tasks_test*.json")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	tmpFile.Write([]byte(`{"tasks":{}}`))
+	tmpFile.Close()
+	origFileName := services.GetTasksFileName()
+	services.SetTasksFileName(tmpFile.Name())
+	t.Cleanup(func() { services.SetTasksFileName(origFileName) })
+
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	pb.RegisterTaskTrackerServer(server, grpcserver.NewServer())
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	client, err := Dial("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("Dial returned unexpected error: %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+func TestClient(t *testing.T) {
+	client := startClient(t)
+	ctx := context.Background()
+
+	created, err := client.Create(ctx, &pb.Task{Title: "Write report", Description: "draft", Tags: []string{"work"}})
+	if err != nil {
+		t.Fatalf("Create returned unexpected error: %v", err)
+	}
+	if _, err := client.Create(ctx, &pb.Task{Title: "Write report"}); !IsAlreadyExists(err) {
+		t.Errorf("Expected already exists error, got %v", err)
+	}
+
+	if tasks, err := client.List(ctx, "tag:work", "-priority"); err != nil || len(tasks) != 1 {
+		t.Errorf("List = %v, %v", tasks, err)
+	}
+	if task, err := client.Update(ctx, created.GetId(), &pb.Task{Estimate: "3pt"}, "estimate", "description"); err != nil || task.GetEstimate() != "3pt" || task.GetDescription() != "" {
+		t.Errorf("Update = %v, %v", task, err)
+	}
+	if task, err := client.Start(ctx, "Write report"); err != nil || task.GetStatus() != pb.TaskStatus_TASK_STATUS_IN_PROGRESS {
+		t.Errorf("Start = %v, %v", task, err)
+	}
+	if task, err := client.Complete(ctx, "Write report"); err != nil || task.GetStatus() != pb.TaskStatus_TASK_STATUS_COMPLETED {
+		t.Errorf("Complete = %v, %v", task, err)
+	}
+	if task, err := client.Reopen(ctx, "Write report"); err != nil || task.GetStatus() != pb.TaskStatus_TASK_STATUS_PENDING {
+		t.Errorf("Reopen = %v, %v", task, err)
+	}
+	if err := client.Delete(ctx, created.GetId()); err != nil {
+		t.Errorf("Delete returned unexpected error: %v", err)
+	}
+	if _, err := client.Get(ctx, created.GetId()); !IsNotFound(err) {
+		t.Errorf("Expected not found error, got %v", err)
+	}
+}
+
+func TestClient_Watch(t *testing.T) {
+	client := startClient(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	client.Create(ctx, &pb.Task{Title: "First"})
+
+	var events []*pb.TaskEvent
+	errDone := errors.New("done")
+	go func() {
+		// Changes made after the watch started arrive after the initial tasks
+		time.Sleep(50 * time.Millisecond)
+		client.Create(ctx, &pb.Task{Title: "Second"})
+	}()
+	err := client.Watch(ctx, "", true, func(event *pb.TaskEvent) error {
+		events = append(events, event)
+		if len(events) == 2 {
+			return errDone
+		}
+		return nil
+	})
+	if !errors.Is(err, errDone) {
+		t.Fatalf("Expected the handler's error, got %v", err)
+	}
+	if events[0].GetTask().GetTitle() != "First" || events[1].GetTask().GetTitle() != "Second" || events[1].GetType() != pb.TaskEventType_TASK_EVENT_TYPE_CREATED {
+		t.Errorf("Unexpected events %v", events)
+	}
+
+	cancelled, cancelWatch := context.WithCancel(ctx)
+	cancelWatch()
+	if err := client.Watch(cancelled, "", false, func(*pb.TaskEvent) error { return nil }); err != nil {
+		t.Errorf("Expected nil error after cancel, got %v", err)
+	}
+}
@@ -0,0 +1,301 @@
+package tasktracker
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/savabush/taskTracker/internal/services"
+)
+
+// Batch is a loaded task store that changes are applied to in memory. It is
+// only valid inside the function passed to Client.Batch, and is safe for
+// concurrent use there.
+type Batch struct {
+	mu      sync.Mutex
+	service *services.TaskService
+	changed bool
+}
+
+func newBatch(data StoreData) *Batch {
+	service := &services.TaskService{Tasks: data.Tasks, Views: data.Views, Reminders: data.Reminders}
+	if service.Tasks == nil {
+		service.Tasks = make(map[string]Task)
+	}
+	if service.Views == nil {
+		service.Views = make(map[string]View)
+	}
+	if service.Reminders == nil {
+		service.Reminders = make(map[string]time.Time)
+	}
+	return &Batch{service: service}
+}
+
+func (b *Batch) data() StoreData {
+	return StoreData{Tasks: b.service.Tasks, Views: b.service.Views, Reminders: b.service.Reminders}
+}
+
+// find looks a task up by ID, then by title, then by a unique ID prefix. It
+// must be called with b.mu held.
+func (b *Batch) find(key string) (Task, error) {
+	return services.FindTask(b.service.Tasks, key)
+}
+
+// findID returns the task with the given ID after a change that may have
+// renamed it. It must be called with b.mu held.
+func (b *Batch) findID(id string) Task {
+	task, _ := b.find(id)
+	return task
+}
+
+// validate checks the editable fields of task.
+func validate(task Task) error {
+	if strings.TrimSpace(task.Title) == "" {
+		return &InvalidTaskError{Field: "title", Err: errors.New("cannot be empty")}
+	}
+	if task.Priority != "" && !task.Priority.IsValid() {
+		return &InvalidTaskError{Field: "priority", Err: errors.New("must be one of: low, medium, high, critical")}
+	}
+	if task.Recurrence != "" {
+		if _, err := services.ParseRecurrence(task.Recurrence); err != nil {
+			return &InvalidTaskError{Field: "recurrence", Err: err}
+		}
+	}
+	return nil
+}
+
+// setEditable copies the editable fields other than the title from source.
+func setEditable(source Task) func(task *Task) {
+	return func(task *Task) {
+		task.Description = source.Description
+		task.Notes = slices.Clone(source.Notes)
+		task.Tags = slices.Clone(source.Tags)
+		task.Priority = source.Priority
+		task.Due = source.Due
+		task.Recurrence = source.Recurrence
+		task.Estimate = source.Estimate
+	}
+}
+
+// List returns the tasks selected by opts.
+func (b *Batch) List(opts ListOptions) ([]Task, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	query, err := services.ParseQuery(opts.Filter)
+	if err != nil {
+		return nil, err
+	}
+	return services.SortTasks(b.service.QueryTasks(query), opts.Sort)
+}
+
+// Get returns a task.
+func (b *Batch) Get(key string) (Task, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.find(key)
+}
+
+// Add creates a pending task from the title, description, notes, tags,
+// priority, due date, recurrence and estimate of task.
+func (b *Batch) Add(task Task) (Task, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err := validate(task); err != nil {
+		return Task{}, err
+	}
+	if _, exists := b.service.Tasks[task.Title]; exists {
+		return Task{}, fmt.Errorf("%w: %q", ErrDuplicateTitle, task.Title)
+	}
+	b.service.AddTask(task.Title)
+	b.service.UpdateTask(task.Title, setEditable(task))
+	b.changed = true
+	return b.service.Tasks[task.Title], nil
+}
+
+// Update calls update with a copy of a task and saves the changes it made to
+// the title, description, notes, tags, priority, due date, recurrence and
+// estimate. The ID, status and timestamps are kept.
+func (b *Batch) Update(key string, update func(task *Task)) (Task, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	task, err := b.find(key)
+	if err != nil {
+		return Task{}, err
+	}
+	changed := task
+	changed.Notes = slices.Clone(task.Notes)
+	changed.Tags = slices.Clone(task.Tags)
+	changed.TimeEntries = slices.Clone(task.TimeEntries)
+	update(&changed)
+	if err := validate(changed); err != nil {
+		return Task{}, err
+	}
+
+	if changed.Title != task.Title {
+		if _, exists := b.service.Tasks[changed.Title]; exists {
+			return Task{}, fmt.Errorf("%w: %q", ErrDuplicateTitle, changed.Title)
+		}
+		b.service.RenameTask(task.Title, changed.Title)
+	}
+	b.service.UpdateTask(changed.Title, setEditable(changed))
+	b.changed = true
+	return b.service.Tasks[changed.Title], nil
+}
+
+// Delete deletes a task.
+func (b *Batch) Delete(key string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	task, err := b.find(key)
+	if err != nil {
+		return err
+	}
+	b.service.DeleteTask(task.Title)
+	b.changed = true
+	return nil
+}
+
+// Start marks a task as in progress.
+func (b *Batch) Start(key string) (Task, error) {
+	return b.transition(key, b.service.InProgressTask)
+}
+
+// Complete marks a task as completed. Completing a recurring task keeps it
+// under a dated title and adds the next occurrence under the original one.
+func (b *Batch) Complete(key string) (Task, error) {
+	return b.transition(key, b.service.CompleteTask)
+}
+
+// Reopen marks a task as pending again.
+func (b *Batch) Reopen(key string) (Task, error) {
+	return b.transition(key, b.service.ReopenTask)
+}
+
+func (b *Batch) transition(key string, change func(title string) error) (Task, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	task, err := b.find(key)
+	if err != nil {
+		return Task{}, err
+	}
+	if err := change(task.Title); err != nil {
+		return Task{}, err
+	}
+	b.changed = true
+	return b.findID(task.ID), nil
+}
+
+// View returns a saved view.
+func (b *Batch) View(name string) (View, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.service.GetView(name)
+}
+
+// Views returns the saved views ordered by name.
+func (b *Batch) Views() []View {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.service.GetViews()
+}
+
+// SaveView saves a view, replacing a view of the same name. Names start with
+// a letter or digit and contain only letters, digits, '-' and '_'; the
+// filter and sort order are checked as in List.
+func (b *Batch) SaveView(view View) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err := b.service.SaveView(view); err != nil {
+		return err
+	}
+	b.changed = true
+	return nil
+}
+
+// DeleteView deletes a saved view.
+func (b *Batch) DeleteView(name string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err := b.service.DeleteView(name); err != nil {
+		return err
+	}
+	b.changed = true
+	return nil
+}
+
+// StartTimer starts tracking time on a task and marks it as in progress.
+// Only one timer runs at a time.
+func (b *Batch) StartTimer(key string) (Task, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	task, err := b.find(key)
+	if err != nil {
+		return Task{}, err
+	}
+	if err := b.service.StartTimer(task.Title, time.Now()); err != nil {
+		return Task{}, err
+	}
+	if task.Status != StatusInProgress {
+		if err := b.service.InProgressTask(task.Title); err != nil {
+			return Task{}, err
+		}
+	}
+	b.changed = true
+	return b.service.Tasks[task.Title], nil
+}
+
+// StopTimer stops the running timer and returns its task and the length of
+// the time entry it recorded.
+func (b *Batch) StopTimer() (Task, time.Duration, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	task, elapsed, err := b.service.StopTimer(time.Now())
+	if err != nil {
+		return Task{}, 0, err
+	}
+	b.changed = true
+	return task, elapsed, nil
+}
+
+// Import adds a task exported from another tool. Unlike Add it keeps the
+// status and the creation, start and completion times of task, setting the
+// ones it lacks to the current time. The task gets a new ID.
+func (b *Batch) Import(task Task) (Task, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if task.Status == "" {
+		task.Status = StatusPending
+	}
+	if err := validate(task); err != nil {
+		return Task{}, err
+	}
+	if !task.Status.IsValid() {
+		return Task{}, &InvalidTaskError{Field: "status", Err: errors.New("must be one of: pending, inProgress, completed")}
+	}
+	if _, exists := b.service.Tasks[task.Title]; exists {
+		return Task{}, fmt.Errorf("%w: %q", ErrDuplicateTitle, task.Title)
+	}
+	b.changed = true
+	return b.service.ImportTask(task), nil
+}
+
+// Reminded reports whether a reminder about the current due date of a task
+// was sent to sink, a name such as "webhook".
+func (b *Batch) Reminded(task Task, sink string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.service.Reminded(services.Reminder{Task: task}, sink)
+}
+
+// MarkReminded records that a reminder about the current due date of a task
+// was sent to sink at t. Records of tasks that were since completed, deleted
+// or rescheduled are dropped.
+func (b *Batch) MarkReminded(task Task, sink string, t time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.service.MarkReminded(services.Reminder{Task: task}, sink, t)
+	b.changed = true
+}
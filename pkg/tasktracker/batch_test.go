@@ -0,0 +1,181 @@
+package tasktracker
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestClient_Batch(t *testing.T) {
+	ctx := context.Background()
+	client, _ := newTestClient(t)
+
+	errStop := errors.New("stop")
+	err := client.Batch(ctx, func(b *Batch) error {
+		if _, err := b.Add(Task{Title: "discarded"}); err != nil {
+			return err
+		}
+		return errStop
+	})
+	if !errors.Is(err, errStop) {
+		t.Fatalf("Batch() error = %v, want the function's error", err)
+	}
+	if _, err := client.Get(ctx, "discarded"); !errors.Is(err, ErrTaskNotFound) {
+		t.Error("Expected a failed batch to save nothing")
+	}
+
+	err = client.Batch(ctx, func(b *Batch) error {
+		var wg sync.WaitGroup
+		for _, title := range []string{"one", "two", "three"} {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				b.Add(Task{Title: title})
+			}()
+		}
+		wg.Wait()
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Batch() error = %v", err)
+	}
+	if tasks, _ := client.List(ctx, ListOptions{}); len(tasks) != 3 {
+		t.Errorf("Expected 3 tasks after the batch, got %d", len(tasks))
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := client.Add(cancelled, Task{Title: "late"}); !errors.Is(err, context.Canceled) {
+		t.Errorf("Add() with a cancelled context error = %v", err)
+	}
+}
+
+func TestClient_Update(t *testing.T) {
+	ctx := context.Background()
+	client, _ := newTestClient(t)
+	added, err := client.Add(ctx, Task{Title: "Draft", Tags: []string{"docs"}})
+	if err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if _, err := client.Add(ctx, Task{Title: "Taken"}); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	client.Start(ctx, added.ID)
+
+	due := time.Date(2026, 11, 1, 0, 0, 0, 0, time.Local)
+	updated, err := client.Update(ctx, "Draft", func(task *Task) {
+		task.Title = "Final"
+		task.Due = due
+		task.Tags = append(task.Tags, "review")
+		task.Status = StatusCompleted
+	})
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if updated.ID != added.ID || updated.Title != "Final" || !updated.Due.Equal(due) || len(updated.Tags) != 2 {
+		t.Errorf("Update() = %+v", updated)
+	}
+	if updated.Status != StatusInProgress {
+		t.Errorf("Expected Update() to keep the status, got %s", updated.Status)
+	}
+
+	if _, err := client.Update(ctx, "Final", func(task *Task) { task.Title = "Taken" }); !errors.Is(err, ErrDuplicateTitle) {
+		t.Errorf("Update(rename to existing) error = %v, want ErrDuplicateTitle", err)
+	}
+	if _, err := client.Update(ctx, "Draft", func(*Task) {}); !errors.Is(err, ErrTaskNotFound) {
+		t.Errorf("Update(old title) error = %v, want ErrTaskNotFound", err)
+	}
+}
+
+func TestClient_ViewsAndTimers(t *testing.T) {
+	ctx := context.Background()
+	client, _ := newTestClient(t)
+	added, err := client.Add(ctx, Task{Title: "Write docs"})
+	if err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	if err := client.SaveView(ctx, View{Name: "docs", Query: "tag:docs", Sort: "-due"}); err != nil {
+		t.Fatalf("SaveView() error = %v", err)
+	}
+	if err := client.SaveView(ctx, View{Name: "bad name"}); err == nil {
+		t.Error("Expected SaveView() to reject an invalid name")
+	}
+	if views, err := client.Views(ctx); err != nil || len(views) != 1 || views[0].Sort != "-due" {
+		t.Errorf("Views() = %+v, %v", views, err)
+	}
+	if err := client.DeleteView(ctx, "docs"); err != nil {
+		t.Fatalf("DeleteView() error = %v", err)
+	}
+	if err := client.DeleteView(ctx, "docs"); !errors.Is(err, ErrViewNotFound) {
+		t.Errorf("DeleteView(deleted) error = %v, want ErrViewNotFound", err)
+	}
+
+	started, err := client.StartTimer(ctx, added.ShortID())
+	if err != nil {
+		t.Fatalf("StartTimer() error = %v", err)
+	}
+	if started.Status != StatusInProgress || len(started.TimeEntries) != 1 || !started.TimeEntries[0].End.IsZero() {
+		t.Errorf("Expected a running timer on an in-progress task, got %+v", started)
+	}
+	stopped, _, err := client.StopTimer(ctx)
+	if err != nil {
+		t.Fatalf("StopTimer() error = %v", err)
+	}
+	if stopped.ID != added.ID || stopped.TimeEntries[0].End.IsZero() {
+		t.Errorf("Expected the timer to be stopped, got %+v", stopped)
+	}
+	if _, _, err := client.StopTimer(ctx); err == nil {
+		t.Error("Expected StopTimer() to fail without a running timer")
+	}
+}
+
+func TestBatch_ImportAndReminders(t *testing.T) {
+	ctx := context.Background()
+	client, _ := newTestClient(t)
+	created := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	due := time.Date(2026, 11, 1, 0, 0, 0, 0, time.Local)
+
+	var imported Task
+	err := client.Batch(ctx, func(b *Batch) (err error) {
+		imported, err = b.Import(Task{ID: "foreign", Title: "Ship it", Status: StatusCompleted, CreatedAt: created, Due: due})
+		if err != nil {
+			return err
+		}
+		if _, err := b.Import(Task{Title: "Ship it"}); !errors.Is(err, ErrDuplicateTitle) {
+			t.Errorf("Import(duplicate) error = %v, want ErrDuplicateTitle", err)
+		}
+		if _, err := b.Import(Task{Title: "Odd", Status: "archived"}); err == nil {
+			t.Error("Expected Import() to reject an unknown status")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Batch() error = %v", err)
+	}
+	if imported.ID == "foreign" || imported.Status != StatusCompleted || !imported.CreatedAt.Equal(created) || imported.CompletedAt.IsZero() {
+		t.Errorf("Import() = %+v", imported)
+	}
+
+	open, err := client.Add(ctx, Task{Title: "Pay rent", Due: due})
+	if err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	client.Batch(ctx, func(b *Batch) error {
+		b.MarkReminded(open, "webhook", time.Now())
+		return nil
+	})
+	client.Batch(ctx, func(b *Batch) error {
+		if !b.Reminded(open, "webhook") || b.Reminded(open, "terminal") {
+			t.Error("Expected the reminder to be saved for the webhook only")
+		}
+		moved := open
+		moved.Due = due.AddDate(0, 0, 1)
+		if b.Reminded(moved, "webhook") {
+			t.Error("Expected a new due date to need a new reminder")
+		}
+		return nil
+	})
+}
@@ -0,0 +1,86 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// WorkDay is the length of a "d" unit in duration estimates.
+const WorkDay = 8 * time.Hour
+
+// Estimate is the expected effort for a task, either as a duration or in
+// story points. It is stored as text such as "2h30m" or "5pt".
+type Estimate struct {
+	Duration time.Duration
+	Points   float64
+}
+
+// ParseEstimate parses a duration estimate like "90m", "1.5h" or "2d" (a day
+// being WorkDay long), or a story point estimate like "5pt" or "3sp".
+func ParseEstimate(value string) (Estimate, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if value == "" {
+		return Estimate{}, nil
+	}
+
+	for _, suffix := range []string{"points", "point", "pts", "pt", "sp"} {
+		if number, ok := strings.CutSuffix(value, suffix); ok {
+			points, err := strconv.ParseFloat(strings.TrimSpace(number), 64)
+			if err != nil || points <= 0 {
+				return Estimate{}, fmt.Errorf("invalid story points %q", value)
+			}
+			return Estimate{Points: points}, nil
+		}
+	}
+
+	if number, ok := strings.CutSuffix(value, "d"); ok {
+		days, err := strconv.ParseFloat(number, 64)
+		if err != nil || days <= 0 {
+			return Estimate{}, fmt.Errorf("invalid estimate %q", value)
+		}
+		return Estimate{Duration: time.Duration(days * float64(WorkDay))}, nil
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		return Estimate{}, errors.New("estimate must be a duration like 90m, 1.5h or 2d, or story points like 5pt")
+	}
+	return Estimate{Duration: d}, nil
+}
+
+func (e Estimate) IsZero() bool {
+	return e.Duration == 0 && e.Points == 0
+}
+
+func (e Estimate) String() string {
+	switch {
+	case e.Points > 0:
+		return strconv.FormatFloat(e.Points, 'f', -1, 64) + "pt"
+	case e.Duration > 0:
+		s := e.Duration.String()
+		if strings.HasSuffix(s, "m0s") {
+			s = strings.TrimSuffix(s, "0s")
+		}
+		if strings.HasSuffix(s, "h0m") {
+			s = strings.TrimSuffix(s, "0m")
+		}
+		return s
+	}
+	return ""
+}
+
+func (e Estimate) MarshalText() ([]byte, error) {
+	return []byte(e.String()), nil
+}
+
+func (e *Estimate) UnmarshalText(text []byte) error {
+	parsed, err := ParseEstimate(string(text))
+	if err != nil {
+		return err
+	}
+	*e = parsed
+	return nil
+}
@@ -0,0 +1,193 @@
+// Package model defines the task types shared by the tasktracker package and
+// the taskTracker command. The tasktracker package re-exports them, so most
+// programs do not need to import this package.
+package model
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TaskStatus is the state of a task.
+type TaskStatus string
+
+const (
+	StatusPending    TaskStatus = "pending"
+	StatusInProgress TaskStatus = "inProgress"
+	StatusCompleted  TaskStatus = "completed"
+)
+
+// IsValid reports whether s is one of the known statuses.
+func (s TaskStatus) IsValid() bool {
+	switch s {
+	case StatusPending, StatusInProgress, StatusCompleted:
+		return true
+	}
+	return false
+}
+
+// TaskPriority is the priority of a task. Tasks without a priority have the
+// empty priority.
+type TaskPriority string
+
+const (
+	PriorityLow      TaskPriority = "low"
+	PriorityMedium   TaskPriority = "medium"
+	PriorityHigh     TaskPriority = "high"
+	PriorityCritical TaskPriority = "critical"
+)
+
+// Rank orders priorities from low (1) to critical (4). Tasks without a
+// priority rank 0.
+func (p TaskPriority) Rank() int {
+	switch p {
+	case PriorityLow:
+		return 1
+	case PriorityMedium:
+		return 2
+	case PriorityHigh:
+		return 3
+	case PriorityCritical:
+		return 4
+	}
+	return 0
+}
+
+// IsValid reports whether p is one of the known priorities.
+func (p TaskPriority) IsValid() bool {
+	return p.Rank() > 0
+}
+
+// TimeEntry is a span of time logged against a task. A zero End means the
+// timer is still running.
+type TimeEntry struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end,omitzero"`
+}
+
+// Duration returns the length of the entry, counting a running entry up to
+// now.
+func (e TimeEntry) Duration(now time.Time) time.Duration {
+	if e.End.IsZero() {
+		return now.Sub(e.Start)
+	}
+	return e.End.Sub(e.Start)
+}
+
+// Task is a task of a task store.
+type Task struct {
+	ID          string       `json:"id"`
+	Title       string       `json:"title"`
+	Description string       `json:"description,omitempty"`
+	Notes       []string     `json:"notes,omitempty"`
+	Tags        []string     `json:"tags,omitempty"`
+	Priority    TaskPriority `json:"priority,omitempty"`
+	Due         time.Time    `json:"due,omitzero"`
+	Recurrence  string       `json:"recurrence,omitempty"`
+	Estimate    Estimate     `json:"estimate,omitzero"`
+	TimeEntries []TimeEntry  `json:"time_entries,omitempty"`
+	Status      TaskStatus   `json:"status"`
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+	StartedAt   time.Time    `json:"started_at,omitzero"`
+	CompletedAt time.Time    `json:"completed_at,omitzero"`
+}
+
+// HasTag reports whether the task is tagged with tag, ignoring case.
+func (t Task) HasTag(tag string) bool {
+	for _, existing := range t.Tags {
+		if strings.EqualFold(existing, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// ShortIDLength is the number of characters of a task ID shown by list, and
+// MinIDPrefixLength the number a prefix needs to select a task.
+const (
+	ShortIDLength     = 8
+	MinIDPrefixLength = 4
+)
+
+// ShortID returns the start of the task ID shown by the list command. Any
+// unique prefix of an ID identifies its task.
+func (t Task) ShortID() string {
+	if len(t.ID) <= ShortIDLength {
+		return t.ID
+	}
+	return t.ID[:ShortIDLength]
+}
+
+// IsOverdue reports whether the task has a due date before today and is not
+// completed yet.
+func (t Task) IsOverdue(now time.Time) bool {
+	if t.Due.IsZero() || t.Status == StatusCompleted {
+		return false
+	}
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	return t.Due.Before(today)
+}
+
+// LoggedTime sums the time entries of the task, counting a running entry up
+// to now.
+func (t Task) LoggedTime(now time.Time) time.Duration {
+	var total time.Duration
+	for _, entry := range t.TimeEntries {
+		total += entry.Duration(now)
+	}
+	return total
+}
+
+// RunningEntry returns the index of the task's running time entry or -1.
+func (t Task) RunningEntry() int {
+	for i, entry := range t.TimeEntries {
+		if entry.End.IsZero() {
+			return i
+		}
+	}
+	return -1
+}
+
+// View is a filter, sort order and output format saved under a name with the
+// view command.
+type View struct {
+	Name   string `json:"name"`
+	Query  string `json:"query,omitempty"`
+	Sort   string `json:"sort,omitempty"`
+	Output string `json:"output,omitempty"`
+}
+
+// ParseError describes a malformed filter. Column is the 1-based position
+// of the offending character.
+type ParseError struct {
+	Query  string
+	Column int
+	Msg    string
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("column %d: %s", e.Column, e.Msg)
+}
+
+// Pointer renders the query with a caret under the offending column.
+func (e *ParseError) Pointer() string {
+	return e.Query + "\n" + strings.Repeat(" ", e.Column-1) + "^"
+}
+
+// StoreData is the content of a task store: tasks keyed by title, saved
+// views keyed by name and the times reminders were sent. Storage
+// implementations save it as they get it.
+type StoreData struct {
+	Tasks     map[string]Task
+	Views     map[string]View
+	Reminders map[string]time.Time
+}
+
+// Storage loads and saves a task store. Load returns nil maps for state that
+// has not been stored yet.
+type Storage interface {
+	Load() (StoreData, error)
+	Save(data StoreData) error
+}
@@ -0,0 +1,50 @@
+package tasktracker
+
+import "github.com/savabush/taskTracker/pkg/tasktracker/model"
+
+// The task types are defined in the model package, which the CLI shares;
+// see there for their methods.
+type (
+	// Task is a task of a task store.
+	Task = model.Task
+	// TaskStatus is the state of a task.
+	TaskStatus = model.TaskStatus
+	// TaskPriority is the priority of a task. Tasks without a priority
+	// have the empty priority.
+	TaskPriority = model.TaskPriority
+	// Estimate is the expected effort of a task, either a duration or a
+	// number of story points.
+	Estimate = model.Estimate
+	// TimeEntry is a period of work logged on a task. A running entry has
+	// no end.
+	TimeEntry = model.TimeEntry
+	// View is a filter, sort order and output format saved under a name
+	// with the view command.
+	View = model.View
+	// ParseError reports a malformed filter; see ListOptions.
+	ParseError = model.ParseError
+	// StoreData is the content of a task store: tasks keyed by title,
+	// saved views keyed by name and the times reminders were sent.
+	StoreData = model.StoreData
+	// Storage loads and saves a task store. WithStorage accepts custom
+	// implementations.
+	Storage = model.Storage
+)
+
+const (
+	StatusPending    = model.StatusPending
+	StatusInProgress = model.StatusInProgress
+	StatusCompleted  = model.StatusCompleted
+)
+
+const (
+	PriorityLow      = model.PriorityLow
+	PriorityMedium   = model.PriorityMedium
+	PriorityHigh     = model.PriorityHigh
+	PriorityCritical = model.PriorityCritical
+)
+
+// ParseEstimate parses an estimate like "90m", "1.5h", "2d" or "5pt".
+func ParseEstimate(value string) (Estimate, error) {
+	return model.ParseEstimate(value)
+}
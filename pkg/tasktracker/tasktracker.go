@@ -0,0 +1,270 @@
+// Package tasktracker is the Go API of taskTracker: it reads and changes the
+// same task stores as the CLI.
+//
+//	client, err := tasktracker.New(tasktracker.WithJSONFile("tasks.json"))
+//	if err != nil {
+//		return err
+//	}
+//	task, err := client.Add(ctx, tasktracker.Task{Title: "Buy milk", Tags: []string{"home"}})
+//	if errors.Is(err, tasktracker.ErrDuplicateTitle) {
+//		...
+//	}
+//	tasks, err := client.List(ctx, tasktracker.ListOptions{Filter: "tag:home", Sort: "due"})
+//
+// Every Client method loads the task store, applies its change and saves the
+// store again. Batch runs several changes on a single load and save.
+//
+// Tasks are identified by ID or by title; IDs are tried first, and unique
+// prefixes of IDs, such as the short IDs shown by the list command, last.
+package tasktracker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/savabush/taskTracker/internal/services"
+)
+
+// Errors returned by Client and Batch, wrapped with the task or view name.
+// Check for them with errors.Is.
+var (
+	// ErrTaskNotFound is returned for IDs and titles without a task.
+	ErrTaskNotFound = services.ErrTaskNotFound
+	// ErrDuplicateTitle is returned when a task would get the title of
+	// another task.
+	ErrDuplicateTitle = services.ErrDuplicateTitle
+	// ErrInvalidTransition is returned when a task already has the status
+	// it is changed to.
+	ErrInvalidTransition = services.ErrInvalidTransition
+	// ErrViewNotFound is returned for unknown view names.
+	ErrViewNotFound = services.ErrViewNotFound
+	// ErrAmbiguousID is returned for ID prefixes shared by several tasks.
+	ErrAmbiguousID = services.ErrAmbiguousID
+)
+
+// InvalidTaskError reports a task field with an invalid value.
+type InvalidTaskError struct {
+	Field string
+	Err   error
+}
+
+func (e *InvalidTaskError) Error() string {
+	return fmt.Sprintf("invalid %s: %v", e.Field, e.Err)
+}
+
+func (e *InvalidTaskError) Unwrap() error {
+	return e.Err
+}
+
+// Option configures a Client.
+type Option func(*Client) error
+
+// WithStorage stores tasks in s.
+func WithStorage(s Storage) Option {
+	return func(c *Client) error {
+		if s == nil {
+			return errors.New("storage cannot be nil")
+		}
+		c.storage = s
+		return nil
+	}
+}
+
+// WithJSONFile stores tasks in a JSON file, the CLI's default format.
+func WithJSONFile(path string) Option {
+	return func(c *Client) error {
+		c.storage = services.JSONStorage{Path: path}
+		return nil
+	}
+}
+
+// WithTodoTxtFile stores tasks in a todo.txt file, with completed tasks in
+// done.txt next to it.
+func WithTodoTxtFile(path string) Option {
+	return withStorageKind(services.StorageTodoTxt, services.StorageOptions{TodoFile: path})
+}
+
+// WithMarkdownFile stores tasks in a Markdown file such as TASKS.md.
+func WithMarkdownFile(path string) Option {
+	return withStorageKind(services.StorageMarkdown, services.StorageOptions{MarkdownFile: path})
+}
+
+// WithOpLog stores tasks in an operation log directory, writing operations
+// under the given replica name. An empty replica uses the host name.
+func WithOpLog(dir, replica string) Option {
+	return withStorageKind(services.StorageOpLog, services.StorageOptions{LogDir: dir, Replica: replica})
+}
+
+func withStorageKind(kind string, opts services.StorageOptions) Option {
+	return func(c *Client) error {
+		storage, err := services.NewStorage(kind, opts)
+		if err != nil {
+			return err
+		}
+		c.storage = storage
+		return nil
+	}
+}
+
+// Client reads and changes a task store. It is safe for concurrent use;
+// changes are applied one at a time.
+type Client struct {
+	storage services.Storage
+	mu      sync.Mutex
+}
+
+// New creates a client. Without options it uses the storage of the CLI,
+// tasks.json in the working directory unless the CLI selected another.
+func New(opts ...Option) (*Client, error) {
+	c := &Client{storage: services.GetStorage()}
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// Batch loads the task store, runs fn on it and saves the changes fn made,
+// unless fn returns an error or ctx is done, in which case nothing is saved.
+func (c *Client) Batch(ctx context.Context, fn func(b *Batch) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	data, err := c.storage.Load()
+	if err != nil {
+		return fmt.Errorf("load tasks: %w", err)
+	}
+	b := newBatch(data)
+	if err := fn(b); err != nil {
+		return err
+	}
+	if !b.changed {
+		return nil
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := c.storage.Save(b.data()); err != nil {
+		return fmt.Errorf("save tasks: %w", err)
+	}
+	return nil
+}
+
+// ListOptions selects and orders the tasks returned by List.
+type ListOptions struct {
+	// Filter is a status or an expression such as "tag:work and
+	// priority>=high", as accepted by the list command. Empty matches all
+	// tasks.
+	Filter string
+	// Sort is a comma-separated list of fields such as "-priority,due".
+	// Empty sorts by title.
+	Sort string
+}
+
+// List returns the tasks selected by opts.
+func (c *Client) List(ctx context.Context, opts ListOptions) ([]Task, error) {
+	var tasks []Task
+	err := c.Batch(ctx, func(b *Batch) (err error) {
+		tasks, err = b.List(opts)
+		return err
+	})
+	return tasks, err
+}
+
+// Get returns a task.
+func (c *Client) Get(ctx context.Context, key string) (Task, error) {
+	return c.single(ctx, func(b *Batch) (Task, error) { return b.Get(key) })
+}
+
+// Add creates a pending task from the title, description, notes, tags,
+// priority, due date, recurrence and estimate of task.
+func (c *Client) Add(ctx context.Context, task Task) (Task, error) {
+	return c.single(ctx, func(b *Batch) (Task, error) { return b.Add(task) })
+}
+
+// Update changes a task with update; see Batch.Update.
+func (c *Client) Update(ctx context.Context, key string, update func(task *Task)) (Task, error) {
+	return c.single(ctx, func(b *Batch) (Task, error) { return b.Update(key, update) })
+}
+
+// Delete deletes a task.
+func (c *Client) Delete(ctx context.Context, key string) error {
+	return c.Batch(ctx, func(b *Batch) error { return b.Delete(key) })
+}
+
+// Start marks a task as in progress.
+func (c *Client) Start(ctx context.Context, key string) (Task, error) {
+	return c.single(ctx, func(b *Batch) (Task, error) { return b.Start(key) })
+}
+
+// Complete marks a task as completed; see Batch.Complete.
+func (c *Client) Complete(ctx context.Context, key string) (Task, error) {
+	return c.single(ctx, func(b *Batch) (Task, error) { return b.Complete(key) })
+}
+
+// Reopen marks a task as pending again.
+func (c *Client) Reopen(ctx context.Context, key string) (Task, error) {
+	return c.single(ctx, func(b *Batch) (Task, error) { return b.Reopen(key) })
+}
+
+// View returns a view saved with the view command.
+func (c *Client) View(ctx context.Context, name string) (View, error) {
+	var view View
+	err := c.Batch(ctx, func(b *Batch) (err error) {
+		view, err = b.View(name)
+		return err
+	})
+	return view, err
+}
+
+// Views returns the saved views ordered by name.
+func (c *Client) Views(ctx context.Context) ([]View, error) {
+	var views []View
+	err := c.Batch(ctx, func(b *Batch) error {
+		views = b.Views()
+		return nil
+	})
+	return views, err
+}
+
+// SaveView saves a view; see Batch.SaveView.
+func (c *Client) SaveView(ctx context.Context, view View) error {
+	return c.Batch(ctx, func(b *Batch) error { return b.SaveView(view) })
+}
+
+// DeleteView deletes a saved view.
+func (c *Client) DeleteView(ctx context.Context, name string) error {
+	return c.Batch(ctx, func(b *Batch) error { return b.DeleteView(name) })
+}
+
+// StartTimer starts tracking time on a task; see Batch.StartTimer.
+func (c *Client) StartTimer(ctx context.Context, key string) (Task, error) {
+	return c.single(ctx, func(b *Batch) (Task, error) { return b.StartTimer(key) })
+}
+
+// StopTimer stops the running timer; see Batch.StopTimer.
+func (c *Client) StopTimer(ctx context.Context) (Task, time.Duration, error) {
+	var elapsed time.Duration
+	task, err := c.single(ctx, func(b *Batch) (task Task, err error) {
+		task, elapsed, err = b.StopTimer()
+		return task, err
+	})
+	return task, elapsed, err
+}
+
+// single runs a batch of one operation returning a task.
+func (c *Client) single(ctx context.Context, op func(b *Batch) (Task, error)) (Task, error) {
+	var task Task
+	err := c.Batch(ctx, func(b *Batch) (err error) {
+		task, err = op(b)
+		return err
+	})
+	return task, err
+}
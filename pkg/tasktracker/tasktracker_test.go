@@ -0,0 +1,191 @@
+package tasktracker
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func newTestClient(t *testing.T) (*Client, string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "tasks.json")
+	client, err := New(WithJSONFile(path))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	return client, path
+}
+
+func TestNew(t *testing.T) {
+	if _, err := New(WithStorage(nil)); err == nil {
+		t.Error("Expected an error for nil storage")
+	}
+	dir := t.TempDir()
+	for _, opt := range []Option{
+		WithTodoTxtFile(filepath.Join(dir, "todo.txt")),
+		WithMarkdownFile(filepath.Join(dir, "TASKS.md")),
+		WithOpLog(filepath.Join(dir, "log"), "laptop"),
+	} {
+		if _, err := New(opt); err != nil {
+			t.Errorf("New() error = %v", err)
+		}
+	}
+}
+
+func TestClient_Lifecycle(t *testing.T) {
+	ctx := context.Background()
+	client, path := newTestClient(t)
+
+	added, err := client.Add(ctx, Task{Title: "Write report", Tags: []string{"work"}, Priority: PriorityHigh})
+	if err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if added.ID == "" || added.Status != StatusPending || added.CreatedAt.IsZero() {
+		t.Errorf("Add() = %+v, expected a pending task with an ID", added)
+	}
+
+	// Another client on the same file sees the saved task.
+	other, err := New(WithJSONFile(path))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	got, err := other.Get(ctx, added.ID)
+	if err != nil || got.Title != "Write report" {
+		t.Fatalf("Get(id) = %+v, %v", got, err)
+	}
+	if _, err := other.Get(ctx, "Write report"); err != nil {
+		t.Errorf("Get(title) error = %v", err)
+	}
+
+	started, err := client.Start(ctx, "Write report")
+	if err != nil || started.Status != StatusInProgress {
+		t.Errorf("Start() = %+v, %v", started, err)
+	}
+	completed, err := client.Complete(ctx, added.ID)
+	if err != nil || completed.Status != StatusCompleted {
+		t.Errorf("Complete() = %+v, %v", completed, err)
+	}
+	reopened, err := client.Reopen(ctx, added.ID)
+	if err != nil || reopened.Status != StatusPending || !reopened.CompletedAt.IsZero() {
+		t.Errorf("Reopen() = %+v, %v", reopened, err)
+	}
+
+	if err := client.Delete(ctx, added.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := client.Get(ctx, added.ID); !errors.Is(err, ErrTaskNotFound) {
+		t.Errorf("Get() after Delete() error = %v, want ErrTaskNotFound", err)
+	}
+}
+
+func TestClient_Errors(t *testing.T) {
+	ctx := context.Background()
+	client, _ := newTestClient(t)
+	if _, err := client.Add(ctx, Task{Title: "Task"}); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	if _, err := client.Add(ctx, Task{Title: "Task"}); !errors.Is(err, ErrDuplicateTitle) {
+		t.Errorf("Add(duplicate) error = %v, want ErrDuplicateTitle", err)
+	}
+	if _, err := client.Start(ctx, "Missing"); !errors.Is(err, ErrTaskNotFound) {
+		t.Errorf("Start() error = %v, want ErrTaskNotFound", err)
+	}
+	if err := client.Delete(ctx, "Missing"); !errors.Is(err, ErrTaskNotFound) {
+		t.Errorf("Delete() error = %v, want ErrTaskNotFound", err)
+	}
+	if _, err := client.Reopen(ctx, "Task"); !errors.Is(err, ErrInvalidTransition) {
+		t.Errorf("Reopen(pending) error = %v, want ErrInvalidTransition", err)
+	}
+	if _, err := client.View(ctx, "missing"); !errors.Is(err, ErrViewNotFound) {
+		t.Errorf("View() error = %v, want ErrViewNotFound", err)
+	}
+
+	var invalid *InvalidTaskError
+	for _, task := range []Task{
+		{Title: " "},
+		{Title: "Bad priority", Priority: "urgent"},
+		{Title: "Bad recurrence", Recurrence: "sometimes"},
+	} {
+		if _, err := client.Add(ctx, task); !errors.As(err, &invalid) {
+			t.Errorf("Add(%+v) error = %v, want *InvalidTaskError", task, err)
+		}
+	}
+
+	var parseErr *ParseError
+	if _, err := client.List(ctx, ListOptions{Filter: "priority>="}); !errors.As(err, &parseErr) {
+		t.Errorf("List() error = %v, want *ParseError", err)
+	}
+	if _, err := client.List(ctx, ListOptions{Sort: "colour"}); err == nil {
+		t.Error("Expected an error for an unknown sort field")
+	}
+}
+
+func TestClient_List(t *testing.T) {
+	ctx := context.Background()
+	client, _ := newTestClient(t)
+	for _, task := range []Task{
+		{Title: "b", Tags: []string{"work"}, Priority: PriorityLow},
+		{Title: "a", Tags: []string{"work"}, Priority: PriorityCritical},
+		{Title: "c", Tags: []string{"home"}},
+	} {
+		if _, err := client.Add(ctx, task); err != nil {
+			t.Fatalf("Add() error = %v", err)
+		}
+	}
+
+	tasks, err := client.List(ctx, ListOptions{})
+	if err != nil || len(tasks) != 3 {
+		t.Fatalf("List() = %d tasks, %v", len(tasks), err)
+	}
+	tasks, err = client.List(ctx, ListOptions{Filter: "tag:work", Sort: "priority"})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(tasks) != 2 || tasks[0].Title != "b" || tasks[1].Title != "a" {
+		t.Errorf("List() = %v, want b then a", tasks)
+	}
+}
+
+// memoryStorage is a Storage written against the public types only.
+type memoryStorage struct {
+	data StoreData
+}
+
+func (m *memoryStorage) Load() (StoreData, error) {
+	return m.data, nil
+}
+
+func (m *memoryStorage) Save(data StoreData) error {
+	m.data = data
+	return nil
+}
+
+func TestWithStorage_Custom(t *testing.T) {
+	ctx := context.Background()
+	due := time.Date(2026, 11, 1, 0, 0, 0, 0, time.Local)
+	storage := &memoryStorage{data: StoreData{
+		Views: map[string]View{"home": {Name: "home", Query: "tag:home"}},
+	}}
+	client, err := New(WithStorage(storage))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	added, err := client.Add(ctx, Task{Title: "Buy milk", Tags: []string{"home"}, Priority: PriorityHigh, Due: due, Estimate: Estimate{Points: 2}})
+	if err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	saved := storage.data.Tasks["Buy milk"]
+	if saved.ID != added.ID || saved.Priority != PriorityHigh || !saved.Due.Equal(due) || saved.Estimate.Points != 2 || !saved.HasTag("home") {
+		t.Errorf("Expected the task to be saved through the storage, got %+v", saved)
+	}
+	if view, err := client.View(ctx, "home"); err != nil || view.Query != "tag:home" {
+		t.Errorf("View() = %+v, %v", view, err)
+	}
+	if tasks, err := client.List(ctx, ListOptions{Filter: "tag:home"}); err != nil || len(tasks) != 1 {
+		t.Errorf("List() = %v, %v", tasks, err)
+	}
+}
@@ -0,0 +1,5 @@
+// Package tasktrackerpb contains the protocol buffer types and gRPC stubs of
+// the taskTracker API, generated from proto/tasktracker/v1/tasktracker.proto.
+package tasktrackerpb
+
+//go:generate protoc -I ../../proto --go_out=../.. --go_opt=module=github.com/savabush/taskTracker --go-grpc_out=../.. --go-grpc_opt=module=github.com/savabush/taskTracker tasktracker/v1/tasktracker.proto
@@ -0,0 +1,1170 @@
+// The taskTracker gRPC API: the same tasks and operations as the CLI and the
+// REST API, plus a stream of changes.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: tasktracker/v1/tasktracker.proto
+
+package tasktrackerpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type TaskStatus int32
+
+const (
+	TaskStatus_TASK_STATUS_UNSPECIFIED TaskStatus = 0
+	TaskStatus_TASK_STATUS_PENDING     TaskStatus = 1
+	TaskStatus_TASK_STATUS_IN_PROGRESS TaskStatus = 2
+	TaskStatus_TASK_STATUS_COMPLETED   TaskStatus = 3
+)
+
+// Enum value maps for TaskStatus.
+var (
+	TaskStatus_name = map[int32]string{
+		0: "TASK_STATUS_UNSPECIFIED",
+		1: "TASK_STATUS_PENDING",
+		2: "TASK_STATUS_IN_PROGRESS",
+		3: "TASK_STATUS_COMPLETED",
+	}
+	TaskStatus_value = map[string]int32{
+		"TASK_STATUS_UNSPECIFIED": 0,
+		"TASK_STATUS_PENDING":     1,
+		"TASK_STATUS_IN_PROGRESS": 2,
+		"TASK_STATUS_COMPLETED":   3,
+	}
+)
+
+func (x TaskStatus) Enum() *TaskStatus {
+	p := new(TaskStatus)
+	*p = x
+	return p
+}
+
+func (x TaskStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TaskStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_tasktracker_v1_tasktracker_proto_enumTypes[0].Descriptor()
+}
+
+func (TaskStatus) Type() protoreflect.EnumType {
+	return &file_tasktracker_v1_tasktracker_proto_enumTypes[0]
+}
+
+func (x TaskStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TaskStatus.Descriptor instead.
+func (TaskStatus) EnumDescriptor() ([]byte, []int) {
+	return file_tasktracker_v1_tasktracker_proto_rawDescGZIP(), []int{0}
+}
+
+type TaskPriority int32
+
+const (
+	TaskPriority_TASK_PRIORITY_UNSPECIFIED TaskPriority = 0
+	TaskPriority_TASK_PRIORITY_LOW         TaskPriority = 1
+	TaskPriority_TASK_PRIORITY_MEDIUM      TaskPriority = 2
+	TaskPriority_TASK_PRIORITY_HIGH        TaskPriority = 3
+	TaskPriority_TASK_PRIORITY_CRITICAL    TaskPriority = 4
+)
+
+// Enum value maps for TaskPriority.
+var (
+	TaskPriority_name = map[int32]string{
+		0: "TASK_PRIORITY_UNSPECIFIED",
+		1: "TASK_PRIORITY_LOW",
+		2: "TASK_PRIORITY_MEDIUM",
+		3: "TASK_PRIORITY_HIGH",
+		4: "TASK_PRIORITY_CRITICAL",
+	}
+	TaskPriority_value = map[string]int32{
+		"TASK_PRIORITY_UNSPECIFIED": 0,
+		"TASK_PRIORITY_LOW":         1,
+		"TASK_PRIORITY_MEDIUM":      2,
+		"TASK_PRIORITY_HIGH":        3,
+		"TASK_PRIORITY_CRITICAL":    4,
+	}
+)
+
+func (x TaskPriority) Enum() *TaskPriority {
+	p := new(TaskPriority)
+	*p = x
+	return p
+}
+
+func (x TaskPriority) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TaskPriority) Descriptor() protoreflect.EnumDescriptor {
+	return file_tasktracker_v1_tasktracker_proto_enumTypes[1].Descriptor()
+}
+
+func (TaskPriority) Type() protoreflect.EnumType {
+	return &file_tasktracker_v1_tasktracker_proto_enumTypes[1]
+}
+
+func (x TaskPriority) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TaskPriority.Descriptor instead.
+func (TaskPriority) EnumDescriptor() ([]byte, []int) {
+	return file_tasktracker_v1_tasktracker_proto_rawDescGZIP(), []int{1}
+}
+
+type TaskEventType int32
+
+const (
+	TaskEventType_TASK_EVENT_TYPE_UNSPECIFIED TaskEventType = 0
+	TaskEventType_TASK_EVENT_TYPE_CREATED     TaskEventType = 1
+	TaskEventType_TASK_EVENT_TYPE_UPDATED     TaskEventType = 2
+	TaskEventType_TASK_EVENT_TYPE_DELETED     TaskEventType = 3
+)
+
+// Enum value maps for TaskEventType.
+var (
+	TaskEventType_name = map[int32]string{
+		0: "TASK_EVENT_TYPE_UNSPECIFIED",
+		1: "TASK_EVENT_TYPE_CREATED",
+		2: "TASK_EVENT_TYPE_UPDATED",
+		3: "TASK_EVENT_TYPE_DELETED",
+	}
+	TaskEventType_value = map[string]int32{
+		"TASK_EVENT_TYPE_UNSPECIFIED": 0,
+		"TASK_EVENT_TYPE_CREATED":     1,
+		"TASK_EVENT_TYPE_UPDATED":     2,
+		"TASK_EVENT_TYPE_DELETED":     3,
+	}
+)
+
+func (x TaskEventType) Enum() *TaskEventType {
+	p := new(TaskEventType)
+	*p = x
+	return p
+}
+
+func (x TaskEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TaskEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_tasktracker_v1_tasktracker_proto_enumTypes[2].Descriptor()
+}
+
+func (TaskEventType) Type() protoreflect.EnumType {
+	return &file_tasktracker_v1_tasktracker_proto_enumTypes[2]
+}
+
+func (x TaskEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TaskEventType.Descriptor instead.
+func (TaskEventType) EnumDescriptor() ([]byte, []int) {
+	return file_tasktracker_v1_tasktracker_proto_rawDescGZIP(), []int{2}
+}
+
+type TimeEntry struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Start *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	// Unset while the timer is running.
+	End           *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=end,proto3" json:"end,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TimeEntry) Reset() {
+	*x = TimeEntry{}
+	mi := &file_tasktracker_v1_tasktracker_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TimeEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TimeEntry) ProtoMessage() {}
+
+func (x *TimeEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_tasktracker_v1_tasktracker_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TimeEntry.ProtoReflect.Descriptor instead.
+func (*TimeEntry) Descriptor() ([]byte, []int) {
+	return file_tasktracker_v1_tasktracker_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *TimeEntry) GetStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *TimeEntry) GetEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.End
+	}
+	return nil
+}
+
+type Task struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title       string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Notes       []string               `protobuf:"bytes,4,rep,name=notes,proto3" json:"notes,omitempty"`
+	Tags        []string               `protobuf:"bytes,5,rep,name=tags,proto3" json:"tags,omitempty"`
+	Priority    TaskPriority           `protobuf:"varint,6,opt,name=priority,proto3,enum=tasktracker.v1.TaskPriority" json:"priority,omitempty"`
+	// Midnight of the due date in the server's time zone.
+	Due *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=due,proto3" json:"due,omitempty"`
+	// Recurrence rule such as "weekly" or "every 2 weeks on mon,thu".
+	Recurrence string `protobuf:"bytes,8,opt,name=recurrence,proto3" json:"recurrence,omitempty"`
+	// Estimated effort such as "1h30m" or "5pt".
+	Estimate      string                 `protobuf:"bytes,9,opt,name=estimate,proto3" json:"estimate,omitempty"`
+	TimeEntries   []*TimeEntry           `protobuf:"bytes,10,rep,name=time_entries,json=timeEntries,proto3" json:"time_entries,omitempty"`
+	Status        TaskStatus             `protobuf:"varint,11,opt,name=status,proto3,enum=tasktracker.v1.TaskStatus" json:"status,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	StartedAt     *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	CompletedAt   *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Task) Reset() {
+	*x = Task{}
+	mi := &file_tasktracker_v1_tasktracker_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Task) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Task) ProtoMessage() {}
+
+func (x *Task) ProtoReflect() protoreflect.Message {
+	mi := &file_tasktracker_v1_tasktracker_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Task.ProtoReflect.Descriptor instead.
+func (*Task) Descriptor() ([]byte, []int) {
+	return file_tasktracker_v1_tasktracker_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Task) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Task) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Task) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Task) GetNotes() []string {
+	if x != nil {
+		return x.Notes
+	}
+	return nil
+}
+
+func (x *Task) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Task) GetPriority() TaskPriority {
+	if x != nil {
+		return x.Priority
+	}
+	return TaskPriority_TASK_PRIORITY_UNSPECIFIED
+}
+
+func (x *Task) GetDue() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Due
+	}
+	return nil
+}
+
+func (x *Task) GetRecurrence() string {
+	if x != nil {
+		return x.Recurrence
+	}
+	return ""
+}
+
+func (x *Task) GetEstimate() string {
+	if x != nil {
+		return x.Estimate
+	}
+	return ""
+}
+
+func (x *Task) GetTimeEntries() []*TimeEntry {
+	if x != nil {
+		return x.TimeEntries
+	}
+	return nil
+}
+
+func (x *Task) GetStatus() TaskStatus {
+	if x != nil {
+		return x.Status
+	}
+	return TaskStatus_TASK_STATUS_UNSPECIFIED
+}
+
+func (x *Task) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Task) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Task) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *Task) GetCompletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return nil
+}
+
+type ListTasksRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Filter expression, e.g. "tag:work and priority>=high".
+	Filter string `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	// Comma-separated sort fields, e.g. "-priority,due".
+	Sort          string `protobuf:"bytes,2,opt,name=sort,proto3" json:"sort,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTasksRequest) Reset() {
+	*x = ListTasksRequest{}
+	mi := &file_tasktracker_v1_tasktracker_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTasksRequest) ProtoMessage() {}
+
+func (x *ListTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasktracker_v1_tasktracker_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTasksRequest.ProtoReflect.Descriptor instead.
+func (*ListTasksRequest) Descriptor() ([]byte, []int) {
+	return file_tasktracker_v1_tasktracker_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListTasksRequest) GetFilter() string {
+	if x != nil {
+		return x.Filter
+	}
+	return ""
+}
+
+func (x *ListTasksRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+type ListTasksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tasks         []*Task                `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTasksResponse) Reset() {
+	*x = ListTasksResponse{}
+	mi := &file_tasktracker_v1_tasktracker_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTasksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTasksResponse) ProtoMessage() {}
+
+func (x *ListTasksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasktracker_v1_tasktracker_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTasksResponse.ProtoReflect.Descriptor instead.
+func (*ListTasksResponse) Descriptor() ([]byte, []int) {
+	return file_tasktracker_v1_tasktracker_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListTasksResponse) GetTasks() []*Task {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+type GetTaskRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Task ID or title.
+	Task          string `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTaskRequest) Reset() {
+	*x = GetTaskRequest{}
+	mi := &file_tasktracker_v1_tasktracker_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTaskRequest) ProtoMessage() {}
+
+func (x *GetTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasktracker_v1_tasktracker_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTaskRequest.ProtoReflect.Descriptor instead.
+func (*GetTaskRequest) Descriptor() ([]byte, []int) {
+	return file_tasktracker_v1_tasktracker_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetTaskRequest) GetTask() string {
+	if x != nil {
+		return x.Task
+	}
+	return ""
+}
+
+type CreateTaskRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The title, description, notes, tags, priority, due, recurrence and
+	// estimate of the new task. Other fields are ignored.
+	Task          *Task `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTaskRequest) Reset() {
+	*x = CreateTaskRequest{}
+	mi := &file_tasktracker_v1_tasktracker_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTaskRequest) ProtoMessage() {}
+
+func (x *CreateTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasktracker_v1_tasktracker_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTaskRequest.ProtoReflect.Descriptor instead.
+func (*CreateTaskRequest) Descriptor() ([]byte, []int) {
+	return file_tasktracker_v1_tasktracker_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CreateTaskRequest) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+type UpdateTaskRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Task ID or title.
+	Task string `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	// The new values of the fields in update_mask.
+	Fields *Task `protobuf:"bytes,2,opt,name=fields,proto3" json:"fields,omitempty"`
+	// Fields to change: title, description, notes, tags, priority, due,
+	// recurrence or estimate. Fields in the mask that are unset in fields are
+	// cleared. Without a mask, the fields set in fields are changed.
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,3,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateTaskRequest) Reset() {
+	*x = UpdateTaskRequest{}
+	mi := &file_tasktracker_v1_tasktracker_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTaskRequest) ProtoMessage() {}
+
+func (x *UpdateTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasktracker_v1_tasktracker_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTaskRequest.ProtoReflect.Descriptor instead.
+func (*UpdateTaskRequest) Descriptor() ([]byte, []int) {
+	return file_tasktracker_v1_tasktracker_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *UpdateTaskRequest) GetTask() string {
+	if x != nil {
+		return x.Task
+	}
+	return ""
+}
+
+func (x *UpdateTaskRequest) GetFields() *Task {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+func (x *UpdateTaskRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+type DeleteTaskRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Task ID or title.
+	Task          string `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTaskRequest) Reset() {
+	*x = DeleteTaskRequest{}
+	mi := &file_tasktracker_v1_tasktracker_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTaskRequest) ProtoMessage() {}
+
+func (x *DeleteTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasktracker_v1_tasktracker_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTaskRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTaskRequest) Descriptor() ([]byte, []int) {
+	return file_tasktracker_v1_tasktracker_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DeleteTaskRequest) GetTask() string {
+	if x != nil {
+		return x.Task
+	}
+	return ""
+}
+
+type DeleteTaskResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTaskResponse) Reset() {
+	*x = DeleteTaskResponse{}
+	mi := &file_tasktracker_v1_tasktracker_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTaskResponse) ProtoMessage() {}
+
+func (x *DeleteTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasktracker_v1_tasktracker_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTaskResponse.ProtoReflect.Descriptor instead.
+func (*DeleteTaskResponse) Descriptor() ([]byte, []int) {
+	return file_tasktracker_v1_tasktracker_proto_rawDescGZIP(), []int{8}
+}
+
+type StartTaskRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Task ID or title.
+	Task          string `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartTaskRequest) Reset() {
+	*x = StartTaskRequest{}
+	mi := &file_tasktracker_v1_tasktracker_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartTaskRequest) ProtoMessage() {}
+
+func (x *StartTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasktracker_v1_tasktracker_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartTaskRequest.ProtoReflect.Descriptor instead.
+func (*StartTaskRequest) Descriptor() ([]byte, []int) {
+	return file_tasktracker_v1_tasktracker_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *StartTaskRequest) GetTask() string {
+	if x != nil {
+		return x.Task
+	}
+	return ""
+}
+
+type CompleteTaskRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Task ID or title.
+	Task          string `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompleteTaskRequest) Reset() {
+	*x = CompleteTaskRequest{}
+	mi := &file_tasktracker_v1_tasktracker_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompleteTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompleteTaskRequest) ProtoMessage() {}
+
+func (x *CompleteTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasktracker_v1_tasktracker_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompleteTaskRequest.ProtoReflect.Descriptor instead.
+func (*CompleteTaskRequest) Descriptor() ([]byte, []int) {
+	return file_tasktracker_v1_tasktracker_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *CompleteTaskRequest) GetTask() string {
+	if x != nil {
+		return x.Task
+	}
+	return ""
+}
+
+type ReopenTaskRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Task ID or title.
+	Task          string `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReopenTaskRequest) Reset() {
+	*x = ReopenTaskRequest{}
+	mi := &file_tasktracker_v1_tasktracker_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReopenTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReopenTaskRequest) ProtoMessage() {}
+
+func (x *ReopenTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasktracker_v1_tasktracker_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReopenTaskRequest.ProtoReflect.Descriptor instead.
+func (*ReopenTaskRequest) Descriptor() ([]byte, []int) {
+	return file_tasktracker_v1_tasktracker_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ReopenTaskRequest) GetTask() string {
+	if x != nil {
+		return x.Task
+	}
+	return ""
+}
+
+type WatchTasksRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Only report changes to tasks matching this filter expression. Deleted
+	// tasks are matched as they were before deletion.
+	Filter string `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	// Report the matching tasks as TASK_EVENT_TYPE_CREATED before any changes.
+	SendInitial   bool `protobuf:"varint,2,opt,name=send_initial,json=sendInitial,proto3" json:"send_initial,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchTasksRequest) Reset() {
+	*x = WatchTasksRequest{}
+	mi := &file_tasktracker_v1_tasktracker_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchTasksRequest) ProtoMessage() {}
+
+func (x *WatchTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasktracker_v1_tasktracker_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchTasksRequest.ProtoReflect.Descriptor instead.
+func (*WatchTasksRequest) Descriptor() ([]byte, []int) {
+	return file_tasktracker_v1_tasktracker_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *WatchTasksRequest) GetFilter() string {
+	if x != nil {
+		return x.Filter
+	}
+	return ""
+}
+
+func (x *WatchTasksRequest) GetSendInitial() bool {
+	if x != nil {
+		return x.SendInitial
+	}
+	return false
+}
+
+type TaskEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Type  TaskEventType          `protobuf:"varint,1,opt,name=type,proto3,enum=tasktracker.v1.TaskEventType" json:"type,omitempty"`
+	// The task after the change, or before it for deletions.
+	Task          *Task `protobuf:"bytes,2,opt,name=task,proto3" json:"task,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskEvent) Reset() {
+	*x = TaskEvent{}
+	mi := &file_tasktracker_v1_tasktracker_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskEvent) ProtoMessage() {}
+
+func (x *TaskEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_tasktracker_v1_tasktracker_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskEvent.ProtoReflect.Descriptor instead.
+func (*TaskEvent) Descriptor() ([]byte, []int) {
+	return file_tasktracker_v1_tasktracker_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *TaskEvent) GetType() TaskEventType {
+	if x != nil {
+		return x.Type
+	}
+	return TaskEventType_TASK_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *TaskEvent) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+var File_tasktracker_v1_tasktracker_proto protoreflect.FileDescriptor
+
+const file_tasktracker_v1_tasktracker_proto_rawDesc = "" +
+	"\n" +
+	" tasktracker/v1/tasktracker.proto\x12\x0etasktracker.v1\x1a google/protobuf/field_mask.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"k\n" +
+	"\tTimeEntry\x120\n" +
+	"\x05start\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x05start\x12,\n" +
+	"\x03end\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x03end\"\xfe\x04\n" +
+	"\x04Task\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x14\n" +
+	"\x05notes\x18\x04 \x03(\tR\x05notes\x12\x12\n" +
+	"\x04tags\x18\x05 \x03(\tR\x04tags\x128\n" +
+	"\bpriority\x18\x06 \x01(\x0e2\x1c.tasktracker.v1.TaskPriorityR\bpriority\x12,\n" +
+	"\x03due\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\x03due\x12\x1e\n" +
+	"\n" +
+	"recurrence\x18\b \x01(\tR\n" +
+	"recurrence\x12\x1a\n" +
+	"\bestimate\x18\t \x01(\tR\bestimate\x12<\n" +
+	"\ftime_entries\x18\n" +
+	" \x03(\v2\x19.tasktracker.v1.TimeEntryR\vtimeEntries\x122\n" +
+	"\x06status\x18\v \x01(\x0e2\x1a.tasktracker.v1.TaskStatusR\x06status\x129\n" +
+	"\n" +
+	"created_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x129\n" +
+	"\n" +
+	"started_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x12=\n" +
+	"\fcompleted_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\vcompletedAt\">\n" +
+	"\x10ListTasksRequest\x12\x16\n" +
+	"\x06filter\x18\x01 \x01(\tR\x06filter\x12\x12\n" +
+	"\x04sort\x18\x02 \x01(\tR\x04sort\"?\n" +
+	"\x11ListTasksResponse\x12*\n" +
+	"\x05tasks\x18\x01 \x03(\v2\x14.tasktracker.v1.TaskR\x05tasks\"$\n" +
+	"\x0eGetTaskRequest\x12\x12\n" +
+	"\x04task\x18\x01 \x01(\tR\x04task\"=\n" +
+	"\x11CreateTaskRequest\x12(\n" +
+	"\x04task\x18\x01 \x01(\v2\x14.tasktracker.v1.TaskR\x04task\"\x92\x01\n" +
+	"\x11UpdateTaskRequest\x12\x12\n" +
+	"\x04task\x18\x01 \x01(\tR\x04task\x12,\n" +
+	"\x06fields\x18\x02 \x01(\v2\x14.tasktracker.v1.TaskR\x06fields\x12;\n" +
+	"\vupdate_mask\x18\x03 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\"'\n" +
+	"\x11DeleteTaskRequest\x12\x12\n" +
+	"\x04task\x18\x01 \x01(\tR\x04task\"\x14\n" +
+	"\x12DeleteTaskResponse\"&\n" +
+	"\x10StartTaskRequest\x12\x12\n" +
+	"\x04task\x18\x01 \x01(\tR\x04task\")\n" +
+	"\x13CompleteTaskRequest\x12\x12\n" +
+	"\x04task\x18\x01 \x01(\tR\x04task\"'\n" +
+	"\x11ReopenTaskRequest\x12\x12\n" +
+	"\x04task\x18\x01 \x01(\tR\x04task\"N\n" +
+	"\x11WatchTasksRequest\x12\x16\n" +
+	"\x06filter\x18\x01 \x01(\tR\x06filter\x12!\n" +
+	"\fsend_initial\x18\x02 \x01(\bR\vsendInitial\"h\n" +
+	"\tTaskEvent\x121\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x1d.tasktracker.v1.TaskEventTypeR\x04type\x12(\n" +
+	"\x04task\x18\x02 \x01(\v2\x14.tasktracker.v1.TaskR\x04task*z\n" +
+	"\n" +
+	"TaskStatus\x12\x1b\n" +
+	"\x17TASK_STATUS_UNSPECIFIED\x10\x00\x12\x17\n" +
+	"\x13TASK_STATUS_PENDING\x10\x01\x12\x1b\n" +
+	"\x17TASK_STATUS_IN_PROGRESS\x10\x02\x12\x19\n" +
+	"\x15TASK_STATUS_COMPLETED\x10\x03*\x92\x01\n" +
+	"\fTaskPriority\x12\x1d\n" +
+	"\x19TASK_PRIORITY_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11TASK_PRIORITY_LOW\x10\x01\x12\x18\n" +
+	"\x14TASK_PRIORITY_MEDIUM\x10\x02\x12\x16\n" +
+	"\x12TASK_PRIORITY_HIGH\x10\x03\x12\x1a\n" +
+	"\x16TASK_PRIORITY_CRITICAL\x10\x04*\x87\x01\n" +
+	"\rTaskEventType\x12\x1f\n" +
+	"\x1bTASK_EVENT_TYPE_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17TASK_EVENT_TYPE_CREATED\x10\x01\x12\x1b\n" +
+	"\x17TASK_EVENT_TYPE_UPDATED\x10\x02\x12\x1b\n" +
+	"\x17TASK_EVENT_TYPE_DELETED\x10\x032\xa8\x05\n" +
+	"\vTaskTracker\x12P\n" +
+	"\tListTasks\x12 .tasktracker.v1.ListTasksRequest\x1a!.tasktracker.v1.ListTasksResponse\x12?\n" +
+	"\aGetTask\x12\x1e.tasktracker.v1.GetTaskRequest\x1a\x14.tasktracker.v1.Task\x12E\n" +
+	"\n" +
+	"CreateTask\x12!.tasktracker.v1.CreateTaskRequest\x1a\x14.tasktracker.v1.Task\x12E\n" +
+	"\n" +
+	"UpdateTask\x12!.tasktracker.v1.UpdateTaskRequest\x1a\x14.tasktracker.v1.Task\x12S\n" +
+	"\n" +
+	"DeleteTask\x12!.tasktracker.v1.DeleteTaskRequest\x1a\".tasktracker.v1.DeleteTaskResponse\x12C\n" +
+	"\tStartTask\x12 .tasktracker.v1.StartTaskRequest\x1a\x14.tasktracker.v1.Task\x12I\n" +
+	"\fCompleteTask\x12#.tasktracker.v1.CompleteTaskRequest\x1a\x14.tasktracker.v1.Task\x12E\n" +
+	"\n" +
+	"ReopenTask\x12!.tasktracker.v1.ReopenTaskRequest\x1a\x14.tasktracker.v1.Task\x12L\n" +
+	"\n" +
+	"WatchTasks\x12!.tasktracker.v1.WatchTasksRequest\x1a\x19.tasktracker.v1.TaskEvent0\x01B3Z1github.com/savabush/taskTracker/pkg/tasktrackerpbb\x06proto3"
+
+var (
+	file_tasktracker_v1_tasktracker_proto_rawDescOnce sync.Once
+	file_tasktracker_v1_tasktracker_proto_rawDescData []byte
+)
+
+func file_tasktracker_v1_tasktracker_proto_rawDescGZIP() []byte {
+	file_tasktracker_v1_tasktracker_proto_rawDescOnce.Do(func() {
+		file_tasktracker_v1_tasktracker_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_tasktracker_v1_tasktracker_proto_rawDesc), len(file_tasktracker_v1_tasktracker_proto_rawDesc)))
+	})
+	return file_tasktracker_v1_tasktracker_proto_rawDescData
+}
+
+var file_tasktracker_v1_tasktracker_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_tasktracker_v1_tasktracker_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_tasktracker_v1_tasktracker_proto_goTypes = []any{
+	(TaskStatus)(0),               // 0: tasktracker.v1.TaskStatus
+	(TaskPriority)(0),             // 1: tasktracker.v1.TaskPriority
+	(TaskEventType)(0),            // 2: tasktracker.v1.TaskEventType
+	(*TimeEntry)(nil),             // 3: tasktracker.v1.TimeEntry
+	(*Task)(nil),                  // 4: tasktracker.v1.Task
+	(*ListTasksRequest)(nil),      // 5: tasktracker.v1.ListTasksRequest
+	(*ListTasksResponse)(nil),     // 6: tasktracker.v1.ListTasksResponse
+	(*GetTaskRequest)(nil),        // 7: tasktracker.v1.GetTaskRequest
+	(*CreateTaskRequest)(nil),     // 8: tasktracker.v1.CreateTaskRequest
+	(*UpdateTaskRequest)(nil),     // 9: tasktracker.v1.UpdateTaskRequest
+	(*DeleteTaskRequest)(nil),     // 10: tasktracker.v1.DeleteTaskRequest
+	(*DeleteTaskResponse)(nil),    // 11: tasktracker.v1.DeleteTaskResponse
+	(*StartTaskRequest)(nil),      // 12: tasktracker.v1.StartTaskRequest
+	(*CompleteTaskRequest)(nil),   // 13: tasktracker.v1.CompleteTaskRequest
+	(*ReopenTaskRequest)(nil),     // 14: tasktracker.v1.ReopenTaskRequest
+	(*WatchTasksRequest)(nil),     // 15: tasktracker.v1.WatchTasksRequest
+	(*TaskEvent)(nil),             // 16: tasktracker.v1.TaskEvent
+	(*timestamppb.Timestamp)(nil), // 17: google.protobuf.Timestamp
+	(*fieldmaskpb.FieldMask)(nil), // 18: google.protobuf.FieldMask
+}
+var file_tasktracker_v1_tasktracker_proto_depIdxs = []int32{
+	17, // 0: tasktracker.v1.TimeEntry.start:type_name -> google.protobuf.Timestamp
+	17, // 1: tasktracker.v1.TimeEntry.end:type_name -> google.protobuf.Timestamp
+	1,  // 2: tasktracker.v1.Task.priority:type_name -> tasktracker.v1.TaskPriority
+	17, // 3: tasktracker.v1.Task.due:type_name -> google.protobuf.Timestamp
+	3,  // 4: tasktracker.v1.Task.time_entries:type_name -> tasktracker.v1.TimeEntry
+	0,  // 5: tasktracker.v1.Task.status:type_name -> tasktracker.v1.TaskStatus
+	17, // 6: tasktracker.v1.Task.created_at:type_name -> google.protobuf.Timestamp
+	17, // 7: tasktracker.v1.Task.updated_at:type_name -> google.protobuf.Timestamp
+	17, // 8: tasktracker.v1.Task.started_at:type_name -> google.protobuf.Timestamp
+	17, // 9: tasktracker.v1.Task.completed_at:type_name -> google.protobuf.Timestamp
+	4,  // 10: tasktracker.v1.ListTasksResponse.tasks:type_name -> tasktracker.v1.Task
+	4,  // 11: tasktracker.v1.CreateTaskRequest.task:type_name -> tasktracker.v1.Task
+	4,  // 12: tasktracker.v1.UpdateTaskRequest.fields:type_name -> tasktracker.v1.Task
+	18, // 13: tasktracker.v1.UpdateTaskRequest.update_mask:type_name -> google.protobuf.FieldMask
+	2,  // 14: tasktracker.v1.TaskEvent.type:type_name -> tasktracker.v1.TaskEventType
+	4,  // 15: tasktracker.v1.TaskEvent.task:type_name -> tasktracker.v1.Task
+	5,  // 16: tasktracker.v1.TaskTracker.ListTasks:input_type -> tasktracker.v1.ListTasksRequest
+	7,  // 17: tasktracker.v1.TaskTracker.GetTask:input_type -> tasktracker.v1.GetTaskRequest
+	8,  // 18: tasktracker.v1.TaskTracker.CreateTask:input_type -> tasktracker.v1.CreateTaskRequest
+	9,  // 19: tasktracker.v1.TaskTracker.UpdateTask:input_type -> tasktracker.v1.UpdateTaskRequest
+	10, // 20: tasktracker.v1.TaskTracker.DeleteTask:input_type -> tasktracker.v1.DeleteTaskRequest
+	12, // 21: tasktracker.v1.TaskTracker.StartTask:input_type -> tasktracker.v1.StartTaskRequest
+	13, // 22: tasktracker.v1.TaskTracker.CompleteTask:input_type -> tasktracker.v1.CompleteTaskRequest
+	14, // 23: tasktracker.v1.TaskTracker.ReopenTask:input_type -> tasktracker.v1.ReopenTaskRequest
+	15, // 24: tasktracker.v1.TaskTracker.WatchTasks:input_type -> tasktracker.v1.WatchTasksRequest
+	6,  // 25: tasktracker.v1.TaskTracker.ListTasks:output_type -> tasktracker.v1.ListTasksResponse
+	4,  // 26: tasktracker.v1.TaskTracker.GetTask:output_type -> tasktracker.v1.Task
+	4,  // 27: tasktracker.v1.TaskTracker.CreateTask:output_type -> tasktracker.v1.Task
+	4,  // 28: tasktracker.v1.TaskTracker.UpdateTask:output_type -> tasktracker.v1.Task
+	11, // 29: tasktracker.v1.TaskTracker.DeleteTask:output_type -> tasktracker.v1.DeleteTaskResponse
+	4,  // 30: tasktracker.v1.TaskTracker.StartTask:output_type -> tasktracker.v1.Task
+	4,  // 31: tasktracker.v1.TaskTracker.CompleteTask:output_type -> tasktracker.v1.Task
+	4,  // 32: tasktracker.v1.TaskTracker.ReopenTask:output_type -> tasktracker.v1.Task
+	16, // 33: tasktracker.v1.TaskTracker.WatchTasks:output_type -> tasktracker.v1.TaskEvent
+	25, // [25:34] is the sub-list for method output_type
+	16, // [16:25] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
+}
+
+func init() { file_tasktracker_v1_tasktracker_proto_init() }
+func file_tasktracker_v1_tasktracker_proto_init() {
+	if File_tasktracker_v1_tasktracker_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_tasktracker_v1_tasktracker_proto_rawDesc), len(file_tasktracker_v1_tasktracker_proto_rawDesc)),
+			NumEnums:      3,
+			NumMessages:   14,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_tasktracker_v1_tasktracker_proto_goTypes,
+		DependencyIndexes: file_tasktracker_v1_tasktracker_proto_depIdxs,
+		EnumInfos:         file_tasktracker_v1_tasktracker_proto_enumTypes,
+		MessageInfos:      file_tasktracker_v1_tasktracker_proto_msgTypes,
+	}.Build()
+	File_tasktracker_v1_tasktracker_proto = out.File
+	file_tasktracker_v1_tasktracker_proto_goTypes = nil
+	file_tasktracker_v1_tasktracker_proto_depIdxs = nil
+}